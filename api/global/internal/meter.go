@@ -115,6 +115,7 @@ type syncHandle struct {
 
 var _ metric.Provider = &meterProvider{}
 var _ metric.MeterImpl = &meterImpl{}
+var _ metric.AsyncUnregisterer = &meterImpl{}
 var _ metric.InstrumentImpl = &syncImpl{}
 var _ metric.BoundSyncImpl = &syncHandle{}
 var _ metric.AsyncImpl = &asyncImpl{}
@@ -297,6 +298,38 @@ func (obs *asyncImpl) setDelegate(d metric.MeterImpl) {
 	atomic.StorePointer(&obs.delegate, unsafe.Pointer(implPtr))
 }
 
+func (m *meterImpl) UnregisterAsyncInstrument(inst metric.AsyncImpl) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if obs, ok := inst.(*asyncImpl); ok {
+		implPtr := (*metric.AsyncImpl)(atomic.LoadPointer(&obs.delegate))
+		if implPtr == nil {
+			// The instrument has not been delegated yet, so
+			// dropping it prevents setDelegate from
+			// registering it with the SDK.
+			for i, pending := range m.asyncInsts {
+				if pending == obs {
+					m.asyncInsts = append(m.asyncInsts[:i], m.asyncInsts[i+1:]...)
+					break
+				}
+			}
+			return nil
+		}
+		inst = *implPtr
+	}
+
+	meterPtr := (*metric.MeterImpl)(atomic.LoadPointer(&m.delegate))
+	if meterPtr == nil {
+		return nil
+	}
+	unregisterer, ok := (*meterPtr).(metric.AsyncUnregisterer)
+	if !ok {
+		return metric.ErrAsyncUnregisterUnsupported
+	}
+	return unregisterer.UnregisterAsyncInstrument(inst)
+}
+
 // Metric updates
 
 func (m *meterImpl) RecordBatch(ctx context.Context, labels []kv.KeyValue, measurements ...metric.Measurement) {
@@ -371,6 +371,77 @@ func TestImplementationIndirection(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestGauge(t *testing.T) {
+	internal.ResetForTest()
+
+	ctx := context.Background()
+	meter := global.Meter("test")
+	labels := []kv.KeyValue{kv.String("A", "B")}
+
+	gauge := Must(meter).NewInt64Gauge("test.gauge")
+	gauge.Set(ctx, 1, labels...)
+
+	mock, provider := metrictest.NewProvider()
+	global.SetMeterProvider(provider)
+
+	gauge.Set(ctx, 2, labels...)
+
+	require.EqualValues(t,
+		[]measured{
+			{
+				Name:                "test.gauge",
+				InstrumentationName: "test",
+				Labels:              asMap(labels...),
+				Number:              asInt(2),
+			},
+		},
+		asStructs(mock.MeasurementBatches))
+}
+
+func TestUnregisterAsync(t *testing.T) {
+	internal.ResetForTest()
+
+	meter := global.Meter("test")
+
+	before := Must(meter).NewInt64ValueObserver("test.before", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	after := Must(meter).NewInt64ValueObserver("test.after", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(2)
+	})
+
+	// Unregistering before the delegate is set prevents the
+	// instrument from reaching the SDK.
+	require.NoError(t, before.Unregister())
+
+	mock, provider := metrictest.NewProvider()
+	global.SetMeterProvider(provider)
+
+	mock.RunAsyncInstruments()
+	require.EqualValues(t,
+		[]measured{
+			{
+				Name:                "test.after",
+				InstrumentationName: "test",
+				Labels:              asMap(),
+				Number:              asInt(2),
+			},
+		},
+		asStructs(mock.MeasurementBatches))
+
+	require.NoError(t, after.Unregister())
+
+	mock.MeasurementBatches = nil
+	mock.RunAsyncInstruments()
+	require.Empty(t, mock.MeasurementBatches)
+
+	// Both names are available again.
+	_, err := meter.NewInt64Gauge("test.before")
+	require.NoError(t, err)
+	_, err = meter.NewInt64Gauge("test.after")
+	require.NoError(t, err)
+}
+
 func TestRecordBatchMock(t *testing.T) {
 	internal.ResetForTest()
 
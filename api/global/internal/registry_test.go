@@ -43,6 +43,12 @@ var (
 		"valuerecorder.float64": func(name, libraryName string) (metric.InstrumentImpl, error) {
 			return unwrap(MeterProvider().Meter(libraryName).NewFloat64ValueRecorder(name))
 		},
+		"gauge.int64": func(name, libraryName string) (metric.InstrumentImpl, error) {
+			return unwrap(MeterProvider().Meter(libraryName).NewInt64Gauge(name))
+		},
+		"gauge.float64": func(name, libraryName string) (metric.InstrumentImpl, error) {
+			return unwrap(MeterProvider().Meter(libraryName).NewFloat64Gauge(name))
+		},
 		"valueobserver.int64": func(name, libraryName string) (metric.InstrumentImpl, error) {
 			return unwrap(MeterProvider().Meter(libraryName).NewInt64ValueObserver(name, func(context.Context, metric.Int64ObserverResult) {}))
 		},
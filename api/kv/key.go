@@ -171,3 +171,16 @@ func (k Key) Array(v interface{}) KeyValue {
 		Value: value.Array(v),
 	}
 }
+
+// Map creates a KeyValue instance with a MAP Value.
+//
+// If creating both key and a map value at the same time, then
+// instead of calling kv.Key(name).Map(value) consider using a
+// convenience function provided by the api/key package -
+// key.Map(name, value).
+func (k Key) Map(v map[string]value.Value) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: value.Map(v),
+	}
+}
@@ -96,27 +96,40 @@ func Uint(k string, v uint) KeyValue {
 }
 
 // Array creates a new key-value pair with a passed name and a array.
-// Only arrays of primitive type are supported.
+// Only homogeneous arrays of booleans, integers, floating point numbers
+// or strings are supported, see value.Array.
 func Array(k string, v interface{}) KeyValue {
 	return Key(k).Array(v)
 }
 
+// Map creates a new key-value pair with a passed name and a map of
+// values.
+func Map(k string, v map[string]value.Value) KeyValue {
+	return Key(k).Map(v)
+}
+
 // Infer creates a new key-value pair instance with a passed name and
 // automatic type inference. This is slower, and not type-safe.
-func Infer(k string, value interface{}) KeyValue {
-	if value == nil {
+func Infer(k string, v interface{}) KeyValue {
+	if v == nil {
 		return String(k, "<nil>")
 	}
 
-	if stringer, ok := value.(fmt.Stringer); ok {
+	if stringer, ok := v.(fmt.Stringer); ok {
 		return String(k, stringer.String())
 	}
 
-	rv := reflect.ValueOf(value)
+	rv := reflect.ValueOf(v)
 
 	switch rv.Kind() {
 	case reflect.Array, reflect.Slice:
-		return Array(k, value)
+		if kv := Array(k, v); kv.Value.Type() != value.INVALID {
+			return kv
+		}
+	case reflect.Map:
+		if kv, ok := inferMap(k, rv); ok {
+			return kv
+		}
 	case reflect.Bool:
 		return Bool(k, rv.Bool())
 	case reflect.Int, reflect.Int8, reflect.Int16:
@@ -138,5 +151,19 @@ func Infer(k string, value interface{}) KeyValue {
 	case reflect.String:
 		return String(k, rv.String())
 	}
-	return String(k, fmt.Sprint(value))
+	return String(k, fmt.Sprint(v))
+}
+
+// inferMap creates a MAP key-value pair from a map with string keys,
+// inferring the type of its values.
+func inferMap(k string, rv reflect.Value) (KeyValue, bool) {
+	if rv.Type().Key().Kind() != reflect.String {
+		return KeyValue{}, false
+	}
+	m := make(map[string]value.Value, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		key := iter.Key().String()
+		m[key] = Infer(key, iter.Value().Interface()).Value
+	}
+	return Map(k, m), true
 }
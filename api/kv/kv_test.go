@@ -184,6 +184,30 @@ func TestInfer(t *testing.T) {
 			wantType:  value.STRING,
 			wantValue: "foo",
 		},
+		{
+			key:       "homogeneous array inferred",
+			value:     []interface{}{"a", "b"},
+			wantType:  value.ARRAY,
+			wantValue: []string{"a", "b"},
+		},
+		{
+			key:       "heterogeneous array serialized as %v",
+			value:     []interface{}{"a", 1},
+			wantType:  value.STRING,
+			wantValue: "[a 1]",
+		},
+		{
+			key:       "nested map inferred",
+			value:     map[string]interface{}{"a": 1.5, "b": map[string][]int{"c": {1, 2}}},
+			wantType:  value.MAP,
+			wantValue: map[string]interface{}{"a": 1.5, "b": map[string]interface{}{"c": []int64{1, 2}}},
+		},
+		{
+			key:       "map without string keys serialized as %v",
+			value:     map[int]string{1: "a"},
+			wantType:  value.STRING,
+			wantValue: "map[1:a]",
+		},
 		{
 			key:       "unknown value serialized as %v",
 			value:     nil,
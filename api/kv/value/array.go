@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"math"
+	"reflect"
+)
+
+// Array creates a homogeneous ARRAY value from an array or a slice of
+// booleans, integers, floating point numbers or strings. The elements
+// of a slice of interface{} must all be of the same of these kinds.
+//
+// Integers are stored as int64 and floating point numbers as float64,
+// so the element type of the value is one of BOOL, INT64, FLOAT64 or
+// STRING. An INVALID value is returned for any other input, including
+// nested arrays, nil elements and unsigned integers overflowing int64.
+func Array(array interface{}) Value {
+	invalid := Value{vtype: INVALID}
+	if array == nil {
+		return invalid
+	}
+	rv := reflect.ValueOf(array)
+	if rv.Kind() != reflect.Array && rv.Kind() != reflect.Slice {
+		return invalid
+	}
+
+	elemType := INVALID
+	if k := rv.Type().Elem().Kind(); k != reflect.Interface {
+		if elemType = arrayElementType(k); elemType == INVALID {
+			return invalid
+		}
+	}
+
+	var (
+		bools  []bool
+		ints   []int64
+		floats []float64
+		strs   []string
+	)
+	for i := 0; i < rv.Len(); i++ {
+		e := rv.Index(i)
+		if e.Kind() == reflect.Interface {
+			e = e.Elem()
+			if !e.IsValid() {
+				return invalid
+			}
+			t := arrayElementType(e.Kind())
+			if t == INVALID || (elemType != INVALID && t != elemType) {
+				return invalid
+			}
+			elemType = t
+		}
+		switch elemType {
+		case BOOL:
+			bools = append(bools, e.Bool())
+		case INT64:
+			switch e.Kind() {
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				u := e.Uint()
+				if u > math.MaxInt64 {
+					return invalid
+				}
+				ints = append(ints, int64(u))
+			default:
+				ints = append(ints, e.Int())
+			}
+		case FLOAT64:
+			floats = append(floats, e.Float())
+		case STRING:
+			strs = append(strs, e.String())
+		}
+	}
+
+	switch elemType {
+	case BOOL:
+		return BoolArray(bools)
+	case INT64:
+		return Int64Array(ints)
+	case FLOAT64:
+		return Float64Array(floats)
+	case STRING:
+		return StringArray(strs)
+	}
+	// An empty slice of interface{} has no element type.
+	return invalid
+}
+
+// BoolArray creates an ARRAY value of BOOL elements.
+func BoolArray(v []bool) Value {
+	return newArray(BOOL, v)
+}
+
+// Int64Array creates an ARRAY value of INT64 elements.
+func Int64Array(v []int64) Value {
+	return newArray(INT64, v)
+}
+
+// Float64Array creates an ARRAY value of FLOAT64 elements.
+func Float64Array(v []float64) Value {
+	return newArray(FLOAT64, v)
+}
+
+// StringArray creates an ARRAY value of STRING elements.
+func StringArray(v []string) Value {
+	return newArray(STRING, v)
+}
+
+// newArray copies the elements of slice into a fixed size array.
+func newArray(elemType Type, slice interface{}) Value {
+	sv := reflect.ValueOf(slice)
+	av := reflect.New(reflect.ArrayOf(sv.Len(), sv.Type().Elem())).Elem()
+	reflect.Copy(av, sv)
+	return Value{
+		vtype: ARRAY,
+		// The numeric field is unused by arrays, it holds the
+		// element type.
+		numeric:   uint64(elemType),
+		composite: av.Interface(),
+	}
+}
+
+// arrayElementType returns the element type of an ARRAY value holding
+// elements of the kind k, or INVALID if they are not supported.
+func arrayElementType(k reflect.Kind) Type {
+	switch k {
+	case reflect.Bool:
+		return BOOL
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return INT64
+	case reflect.Float32, reflect.Float64:
+		return FLOAT64
+	case reflect.String:
+		return STRING
+	}
+	return INVALID
+}
+
+// ArrayElementType returns the type of the elements of an ARRAY value:
+// BOOL, INT64, FLOAT64 or STRING. It returns INVALID for other values.
+func (v Value) ArrayElementType() Type {
+	if v.vtype != ARRAY {
+		return INVALID
+	}
+	return Type(v.numeric)
+}
+
+// ArrayLen returns the number of elements of an ARRAY value.
+func (v Value) ArrayLen() int {
+	if v.vtype != ARRAY {
+		return 0
+	}
+	return reflect.ValueOf(v.composite).Len()
+}
+
+// AsArray returns a copy of the elements of the ARRAY value as a
+// []bool, []int64, []float64 or []string, depending on its element
+// type. It returns nil for other values.
+func (v Value) AsArray() interface{} {
+	switch v.ArrayElementType() {
+	case BOOL:
+		return v.AsBoolArray()
+	case INT64:
+		return v.AsInt64Array()
+	case FLOAT64:
+		return v.AsFloat64Array()
+	case STRING:
+		return v.AsStringArray()
+	}
+	return nil
+}
+
+// AsBoolArray returns a copy of the elements of an ARRAY value of BOOL
+// elements, nil otherwise.
+func (v Value) AsBoolArray() []bool {
+	if v.ArrayElementType() != BOOL {
+		return nil
+	}
+	s := make([]bool, v.ArrayLen())
+	reflect.Copy(reflect.ValueOf(s), reflect.ValueOf(v.composite))
+	return s
+}
+
+// AsInt64Array returns a copy of the elements of an ARRAY value of
+// INT64 elements, nil otherwise.
+func (v Value) AsInt64Array() []int64 {
+	if v.ArrayElementType() != INT64 {
+		return nil
+	}
+	s := make([]int64, v.ArrayLen())
+	reflect.Copy(reflect.ValueOf(s), reflect.ValueOf(v.composite))
+	return s
+}
+
+// AsFloat64Array returns a copy of the elements of an ARRAY value of
+// FLOAT64 elements, nil otherwise.
+func (v Value) AsFloat64Array() []float64 {
+	if v.ArrayElementType() != FLOAT64 {
+		return nil
+	}
+	s := make([]float64, v.ArrayLen())
+	reflect.Copy(reflect.ValueOf(s), reflect.ValueOf(v.composite))
+	return s
+}
+
+// AsStringArray returns a copy of the elements of an ARRAY value of
+// STRING elements, nil otherwise.
+func (v Value) AsStringArray() []string {
+	if v.ArrayElementType() != STRING {
+		return nil
+	}
+	s := make([]string, v.ArrayLen())
+	reflect.Copy(reflect.ValueOf(s), reflect.ValueOf(v.composite))
+	return s
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package value
+
+import (
+	"reflect"
+	"sort"
+)
+
+// MapEntry is an entry of a MAP value.
+type MapEntry struct {
+	Key   string
+	Value Value
+}
+
+// Map creates a MAP value from a map of values, which may be MAP
+// values themselves. An INVALID value is returned if any value of the
+// map is INVALID.
+func Map(m map[string]Value) Value {
+	entries := make([]MapEntry, 0, len(m))
+	for k, v := range m {
+		if v.Type() == INVALID {
+			return Value{vtype: INVALID}
+		}
+		entries = append(entries, MapEntry{Key: k, Value: v})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	sv := reflect.ValueOf(entries)
+	av := reflect.New(reflect.ArrayOf(len(entries), sv.Type().Elem())).Elem()
+	reflect.Copy(av, sv)
+	return Value{
+		vtype:     MAP,
+		composite: av.Interface(),
+	}
+}
+
+// AsMapEntries returns a copy of the entries of the MAP value sorted by
+// key. It returns nil for other values.
+func (v Value) AsMapEntries() []MapEntry {
+	if v.vtype != MAP {
+		return nil
+	}
+	av := reflect.ValueOf(v.composite)
+	entries := make([]MapEntry, av.Len())
+	reflect.Copy(reflect.ValueOf(entries), av)
+	return entries
+}
+
+// AsMap returns a copy of the entries of the MAP value as a map. It
+// returns nil for other values.
+func (v Value) AsMap() map[string]Value {
+	if v.vtype != MAP {
+		return nil
+	}
+	entries := v.AsMapEntries()
+	m := make(map[string]Value, len(entries))
+	for _, e := range entries {
+		m[e.Key] = e.Value
+	}
+	return m
+}
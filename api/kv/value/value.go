@@ -17,9 +17,7 @@ package value
 import (
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"strconv"
-	"strings"
 	"unsafe"
 
 	"github.com/Ch1f/otel/api/internal"
@@ -37,7 +35,9 @@ type Value struct {
 	stringly string
 	// TODO Lazy value type?
 
-	array interface{}
+	// composite holds the elements of ARRAY and MAP values in a
+	// fixed size array, so that values remain comparable.
+	composite interface{}
 }
 
 const (
@@ -50,7 +50,8 @@ const (
 	FLOAT32             // 32 bit floating point value, use AsFloat32() to get it.
 	FLOAT64             // 64 bit floating point value, use AsFloat64() to get it.
 	STRING              // String value, use AsString() to get it.
-	ARRAY               // Array value of arbitrary type, use AsArray() to get it.
+	ARRAY               // Homogeneous array value, use AsArray() to get it.
+	MAP                 // Map value with string keys, use AsMap() to get it.
 )
 
 // Bool creates a BOOL Value.
@@ -135,32 +136,6 @@ func Uint(v uint) Value {
 	return Uint64(uint64(v))
 }
 
-// Array creates an ARRAY value.
-func Array(array interface{}) Value {
-	switch reflect.TypeOf(array).Kind() {
-	case reflect.Array, reflect.Slice:
-		isValidType := func() bool {
-			// get array type regardless of dimensions
-			typeName := reflect.TypeOf(array).String()
-			typeName = typeName[strings.LastIndex(typeName, "]")+1:]
-			switch typeName {
-			case "bool", "int", "int32", "int64",
-				"float32", "float64", "string",
-				"uint", "uint32", "uint64":
-				return true
-			}
-			return false
-		}()
-		if isValidType {
-			return Value{
-				vtype: ARRAY,
-				array: array,
-			}
-		}
-	}
-	return Value{vtype: INVALID}
-}
-
 // Type returns a type of the Value.
 func (v Value) Type() Type {
 	return v.vtype
@@ -214,11 +189,6 @@ func (v Value) AsString() string {
 	return v.stringly
 }
 
-// AsArray returns the array Value as an interface{}.
-func (v Value) AsArray() interface{} {
-	return v.array
-}
-
 type unknownValueType struct{}
 
 // AsInterface returns Value's data as interface{}.
@@ -226,6 +196,13 @@ func (v Value) AsInterface() interface{} {
 	switch v.Type() {
 	case ARRAY:
 		return v.AsArray()
+	case MAP:
+		entries := v.AsMapEntries()
+		m := make(map[string]interface{}, len(entries))
+		for _, e := range entries {
+			m[e.Key] = e.Value.AsInterface()
+		}
+		return m
 	case BOOL:
 		return v.AsBool()
 	case INT32:
@@ -249,8 +226,15 @@ func (v Value) AsInterface() interface{} {
 // Emit returns a string representation of Value's data.
 func (v Value) Emit() string {
 	switch v.Type() {
-	case ARRAY:
-		return fmt.Sprint(v.array)
+	case ARRAY, MAP:
+		// Composite values are emitted in JSON, falling back to the
+		// default format for values JSON does not support, such as
+		// NaN.
+		data, err := json.Marshal(v.AsInterface())
+		if err != nil {
+			return fmt.Sprint(v.AsInterface())
+		}
+		return string(data)
 	case BOOL:
 		return strconv.FormatBool(v.AsBool())
 	case INT32:
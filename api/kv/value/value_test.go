@@ -15,6 +15,7 @@
 package value_test
 
 import (
+	"math"
 	"testing"
 	"unsafe"
 
@@ -110,7 +111,7 @@ func TestValue(t *testing.T) {
 			name:      "KeyArray([]uint64) correctly returns keys's internal uint64 values",
 			value:     k.Array([]uint64{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []uint64{42, 43},
+			wantValue: []int64{42, 43},
 		},
 		{
 			name:      "Key.Array([]float64) correctly returns keys's internal float64 values",
@@ -122,19 +123,19 @@ func TestValue(t *testing.T) {
 			name:      "Key.Array([]int32) correctly returns keys's internal int32 values",
 			value:     k.Array([]int32{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []int32{42, 43},
+			wantValue: []int64{42, 43},
 		},
 		{
 			name:      "Key.Array([]uint32) correctly returns keys's internal uint32 values",
 			value:     k.Array([]uint32{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []uint32{42, 43},
+			wantValue: []int64{42, 43},
 		},
 		{
 			name:      "Key.Array([]float32) correctly returns keys's internal float32 values",
 			value:     k.Array([]float32{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []float32{42, 43},
+			wantValue: []float64{42, 43},
 		},
 		{
 			name:      "Key.Array([]string) correctly return key's internal string values",
@@ -146,25 +147,58 @@ func TestValue(t *testing.T) {
 			name:      "Key.Array([]int) correctly returns keys's internal signed integral values",
 			value:     k.Array([]int{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []int{42, 43},
+			wantValue: []int64{42, 43},
 		},
 		{
 			name:      "Key.Array([]uint) correctly returns keys's internal unsigned integral values",
 			value:     k.Array([]uint{42, 43}).Value,
 			wantType:  value.ARRAY,
-			wantValue: []uint{42, 43},
+			wantValue: []int64{42, 43},
 		},
 		{
-			name:      "Key.Array([][]int) correctly return key's multi dimensional array",
+			name:      "Key.Array([][]int) refuses multi dimensional arrays",
 			value:     k.Array([][]int{{1, 2}, {3, 4}}).Value,
+			wantType:  value.INVALID,
+			wantValue: nil,
+		},
+		{
+			name:      "Key.Array([]interface{}) correctly returns key's homogeneous values",
+			value:     k.Array([]interface{}{int8(1), uint16(2), 3}).Value,
+			wantType:  value.ARRAY,
+			wantValue: []int64{1, 2, 3},
+		},
+		{
+			name:      "Key.Array([]interface{}) refuses heterogeneous values",
+			value:     k.Array([]interface{}{1, "two"}).Value,
+			wantType:  value.INVALID,
+			wantValue: nil,
+		},
+		{
+			name:      "Key.Array([]uint64) refuses values overflowing int64",
+			value:     k.Array([]uint64{math.MaxUint64}).Value,
+			wantType:  value.INVALID,
+			wantValue: nil,
+		},
+		{
+			name:      "Key.Array([2]string) correctly returns key's internal string values",
+			value:     k.Array([2]string{"foo", "bar"}).Value,
 			wantType:  value.ARRAY,
-			wantValue: [][]int{{1, 2}, {3, 4}},
+			wantValue: []string{"foo", "bar"},
+		},
+		{
+			name:      "Key.Map() correctly returns key's internal values",
+			value:     k.Map(map[string]value.Value{"a": value.Int64(1), "b": value.StringArray([]string{"c"})}).Value,
+			wantType:  value.MAP,
+			wantValue: map[string]interface{}{"a": int64(1), "b": []string{"c"}},
 		},
 	} {
 		t.Logf("Running test case %s", testcase.name)
 		if testcase.value.Type() != testcase.wantType {
 			t.Errorf("wrong value type, got %#v, expected %#v", testcase.value.Type(), testcase.wantType)
 		}
+		if testcase.wantType == value.INVALID {
+			continue
+		}
 		got := testcase.value.AsInterface()
 		if diff := cmp.Diff(testcase.wantValue, got); diff != "" {
 			t.Errorf("+got, -want: %s", diff)
@@ -201,3 +235,71 @@ func getBitlessInfo(i int) bitlessInfo {
 		unsignedValue: uint64(i),
 	}
 }
+
+func TestCompositeEmit(t *testing.T) {
+	for _, testcase := range []struct {
+		value value.Value
+		want  string
+	}{
+		{value: value.BoolArray([]bool{true, false}), want: `[true,false]`},
+		{value: value.Int64Array([]int64{1, 2}), want: `[1,2]`},
+		{value: value.Float64Array([]float64{1.5, 2}), want: `[1.5,2]`},
+		{value: value.StringArray([]string{"a", "b,c"}), want: `["a","b,c"]`},
+		{value: value.StringArray(nil), want: `[]`},
+		{
+			value: value.Map(map[string]value.Value{
+				"b": value.Int64Array([]int64{1}),
+				"a": value.Map(map[string]value.Value{"c": value.String("d")}),
+			}),
+			want: `{"a":{"c":"d"},"b":[1]}`,
+		},
+	} {
+		if got := testcase.value.Emit(); got != testcase.want {
+			t.Errorf("Emit() = %s, want %s", got, testcase.want)
+		}
+	}
+}
+
+func TestCompositeComparable(t *testing.T) {
+	// Composite values are comparable, so they can be used in label
+	// sets and resources.
+	if value.StringArray([]string{"a"}) != value.Array([]string{"a"}) {
+		t.Error("equal arrays are not equal")
+	}
+	if value.Int64Array([]int64{1}) == value.Float64Array([]float64{1}) {
+		t.Error("arrays of different element types are equal")
+	}
+	m := func() value.Value {
+		return value.Map(map[string]value.Value{"a": value.Int64(1), "b": value.BoolArray([]bool{true})})
+	}
+	if m() != m() {
+		t.Error("equal maps are not equal")
+	}
+}
+
+func TestArrayAccessors(t *testing.T) {
+	v := value.Array([]int32{1, 2})
+	if got := v.ArrayElementType(); got != value.INT64 {
+		t.Errorf("ArrayElementType() = %v, want INT64", got)
+	}
+	if got := v.ArrayLen(); got != 2 {
+		t.Errorf("ArrayLen() = %d, want 2", got)
+	}
+	if got := v.AsStringArray(); got != nil {
+		t.Errorf("AsStringArray() = %v, want nil", got)
+	}
+
+	// The returned slices are copies.
+	s := v.AsInt64Array()
+	s[0] = 42
+	if got := v.AsInt64Array()[0]; got != 1 {
+		t.Errorf("array value modified through a returned slice: %d", got)
+	}
+}
+
+func TestMapInvalidValue(t *testing.T) {
+	v := value.Map(map[string]value.Value{"a": value.Array([][]int{{1}})})
+	if v.Type() != value.INVALID {
+		t.Errorf("got type %v, want INVALID", v.Type())
+	}
+}
@@ -119,9 +119,13 @@ func (d *defaultLabelEncoder) Encode(iter Iterator) string {
 
 		_, _ = buf.WriteRune('=')
 
-		if keyValue.Value.Type() == value.STRING {
+		switch keyValue.Value.Type() {
+		case value.STRING:
 			copyAndEscape(buf, keyValue.Value.AsString())
-		} else {
+		case value.ARRAY, value.MAP:
+			// The JSON encoding of composite values contains commas.
+			copyAndEscape(buf, keyValue.Value.Emit())
+		default:
 			_, _ = buf.WriteString(keyValue.Value.Emit())
 		}
 	}
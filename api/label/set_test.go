@@ -114,3 +114,16 @@ func TestSetDedup(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCompositeValues(t *testing.T) {
+	enc := label.DefaultEncoder()
+
+	a := label.NewSet(kv.Array("A", []string{"x", "y"}), kv.Int("B", 1))
+	b := label.NewSet(kv.Array("A", []interface{}{"x", "y"}), kv.Int("B", 1))
+	c := label.NewSet(kv.Array("A", []string{"x,y"}), kv.Int("B", 1))
+
+	require.Equal(t, a.Equivalent(), b.Equivalent())
+	require.NotEqual(t, a.Equivalent(), c.Equivalent())
+	require.Equal(t, `A=["x"\,"y"],B=1`, a.Encoded(enc))
+	require.Equal(t, `A=["x\,y"],B=1`, c.Encoded(enc))
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+)
+
+// Provider supports named Logger instances.
+type Provider interface {
+	// Logger creates an implementation of the Logger interface.
+	// The instrumentationName must be the name of the library providing
+	// instrumentation. If the instrumentationName is empty, then a
+	// implementation defined default name will be used instead.
+	Logger(instrumentationName string, opts ...LoggerOption) Logger
+}
+
+// LoggerConfig contains options for a Logger.
+type LoggerConfig struct {
+	InstrumentationVersion string
+}
+
+// LoggerOption configures a LoggerConfig option.
+type LoggerOption func(*LoggerConfig)
+
+// WithInstrumentationVersion sets the instrumentation version for a Logger.
+func WithInstrumentationVersion(version string) LoggerOption {
+	return func(c *LoggerConfig) {
+		c.InstrumentationVersion = version
+	}
+}
+
+// Logger emits log records.
+type Logger interface {
+	// Emit records a log Record. If ctx carries an active span the
+	// record is correlated with it.
+	Emit(ctx context.Context, record Record)
+}
+
+// Record is a single log entry as produced by instrumentation.
+type Record struct {
+	// Timestamp is the time the event occurred. If it is zero the
+	// time of the call to Emit is used.
+	Timestamp time.Time
+
+	// Severity is the normalized severity of the record.
+	Severity Severity
+
+	// SeverityText is the severity as known by the source, e.g.
+	// "WARNING". If it is empty the SDK uses Severity.String().
+	SeverityText string
+
+	// Body is the content of the record, usually a human-readable
+	// message.
+	Body value.Value
+
+	// Attributes are additional information about the event.
+	Attributes []kv.KeyValue
+}
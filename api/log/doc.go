@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package log provides the OpenTelemetry logs API.
+//
+// A Logger emits Records. Records emitted with a context carrying an
+// active span are correlated with that span by the SDK, which stamps
+// them with the span's trace ID, span ID and trace flags, so that log
+// backends can join log lines with traces.
+//
+// Logs are emitted through a Logger obtained from a Provider:
+//
+//	logger := provider.Logger("github.com/example/instrumentation")
+//	logger.Emit(ctx, log.Record{
+//		Severity: log.SeverityInfo,
+//		Body:     value.String("request handled"),
+//		Attributes: []kv.KeyValue{
+//			kv.String("http.route", "/users"),
+//		},
+//	})
+//
+// This package is currently in a pre-GA phase. Backwards incompatible
+// changes may be introduced in subsequent minor version releases as we
+// work to track the evolving OpenTelemetry specification and user
+// feedback.
+package log // import "github.com/Ch1f/otel/api/log"
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import "context"
+
+// NoopProvider is a Provider that returns NoopLoggers.
+type NoopProvider struct{}
+
+var _ Provider = NoopProvider{}
+
+// Logger returns noop implementation of Logger.
+func (NoopProvider) Logger(_ string, _ ...LoggerOption) Logger {
+	return NoopLogger{}
+}
+
+// NoopLogger is a Logger that discards all records.
+type NoopLogger struct{}
+
+var _ Logger = NoopLogger{}
+
+// Emit does nothing.
+func (NoopLogger) Emit(context.Context, Record) {}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import "strconv"
+
+// Severity is the normalized severity of a log record. Smaller numbers
+// are less severe. Each of the six ranges covers four values so that
+// sources with finer-grained levels can be mapped without loss, e.g.
+// SeverityWarn+1 is a more severe warning.
+type Severity int32
+
+const (
+	// SeverityUndefined is the zero value and means the severity is
+	// not known.
+	SeverityUndefined Severity = 0
+	// SeverityTrace is a fine-grained debugging event.
+	SeverityTrace Severity = 1
+	// SeverityDebug is a debugging event.
+	SeverityDebug Severity = 5
+	// SeverityInfo is an informational event.
+	SeverityInfo Severity = 9
+	// SeverityWarn is a warning event.
+	SeverityWarn Severity = 13
+	// SeverityError is an error event.
+	SeverityError Severity = 17
+	// SeverityFatal is a fatal error such as an application crash.
+	SeverityFatal Severity = 21
+)
+
+var severityNames = [...]string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// String returns the short name of the severity, e.g. "INFO" or "WARN2"
+// for SeverityWarn+1.
+func (s Severity) String() string {
+	if s < SeverityTrace || s > SeverityFatal+3 {
+		if s == SeverityUndefined {
+			return "UNDEFINED"
+		}
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+	i := int(s - SeverityTrace)
+	name := severityNames[i/4]
+	if n := i % 4; n > 0 {
+		name += strconv.Itoa(n + 1)
+	}
+	return name
+}
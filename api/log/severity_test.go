@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Ch1f/otel/api/log"
+)
+
+func TestSeverityString(t *testing.T) {
+	for _, tc := range []struct {
+		severity log.Severity
+		want     string
+	}{
+		{log.SeverityUndefined, "UNDEFINED"},
+		{log.SeverityTrace, "TRACE"},
+		{log.SeverityDebug, "DEBUG"},
+		{log.SeverityInfo, "INFO"},
+		{log.SeverityWarn, "WARN"},
+		{log.SeverityWarn + 1, "WARN2"},
+		{log.SeverityError + 3, "ERROR4"},
+		{log.SeverityFatal, "FATAL"},
+		{log.SeverityFatal + 4, "Severity(25)"},
+		{-1, "Severity(-1)"},
+	} {
+		assert.Equal(t, tc.want, tc.severity.String())
+	}
+}
@@ -180,6 +180,35 @@ func TestValueRecorder(t *testing.T) {
 	})
 }
 
+func TestGauge(t *testing.T) {
+	t.Run("float64 gauge", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		g := Must(meter).NewFloat64Gauge("test.gauge.float")
+		ctx := context.Background()
+		labels := []kv.KeyValue{}
+		g.Set(ctx, 42, labels...)
+		boundInstrument := g.Bind(labels...)
+		boundInstrument.Set(ctx, 0)
+		meter.RecordBatch(ctx, labels, g.Measurement(-100.5))
+		checkSyncBatches(t, ctx, labels, mockSDK, metric.Float64NumberKind, metric.GaugeKind, g.SyncImpl(),
+			42, 0, -100.5,
+		)
+	})
+	t.Run("int64 gauge", func(t *testing.T) {
+		mockSDK, meter := mockTest.NewMeter()
+		g := Must(meter).NewInt64Gauge("test.gauge.int")
+		ctx := context.Background()
+		labels := []kv.KeyValue{kv.Int("I", 1)}
+		g.Set(ctx, 173, labels...)
+		boundInstrument := g.Bind(labels...)
+		boundInstrument.Set(ctx, 80)
+		meter.RecordBatch(ctx, labels, g.Measurement(0))
+		checkSyncBatches(t, ctx, labels, mockSDK, metric.Int64NumberKind, metric.GaugeKind, g.SyncImpl(),
+			173, 80, 0,
+		)
+	})
+}
+
 func TestObserverInstruments(t *testing.T) {
 	t.Run("float valueobserver", func(t *testing.T) {
 		labels := []kv.KeyValue{kv.String("O", "P")}
@@ -336,6 +365,76 @@ func TestBatchObserverInstruments(t *testing.T) {
 	require.Equal(t, 0, m2.Number.CompareNumber(metric.Float64NumberKind, number(t, metric.Float64NumberKind, 42)))
 }
 
+func TestUnregisterObserver(t *testing.T) {
+	mockSDK, meter := mockTest.NewMeter()
+	calls := 0
+	o := Must(meter).NewInt64ValueObserver("test.observer", func(_ context.Context, result metric.Int64ObserverResult) {
+		calls++
+		result.Observe(1)
+	})
+
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, calls)
+
+	require.NoError(t, o.Unregister())
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, calls)
+	require.Len(t, mockSDK.MeasurementBatches, 1)
+
+	// Unregistering twice has no effect.
+	require.NoError(t, o.Unregister())
+
+	// The name may be registered again.
+	_, err := meter.NewInt64SumObserver("test.observer", func(context.Context, metric.Int64ObserverResult) {})
+	require.NoError(t, err)
+}
+
+func TestUnregisterBatchObserver(t *testing.T) {
+	mockSDK, meter := mockTest.NewMeter()
+
+	var obs1 metric.Int64ValueObserver
+	var obs2 metric.Float64SumObserver
+	calls := 0
+
+	cb := Must(meter).NewBatchObserver(
+		func(_ context.Context, result metric.BatchObserverResult) {
+			calls++
+			result.Observe(nil,
+				obs1.Observation(1),
+				obs2.Observation(2),
+			)
+		},
+	)
+	obs1 = cb.NewInt64ValueObserver("test.observer.int")
+	obs2 = cb.NewFloat64SumObserver("test.observer.float")
+
+	// Removing one instrument keeps the callback running.
+	require.NoError(t, obs1.Unregister())
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, calls)
+
+	require.NoError(t, cb.Unregister())
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, calls)
+}
+
+type testNoUnregisterMeter struct {
+	metric.MeterImpl
+}
+
+func TestUnregisterUnsupported(t *testing.T) {
+	impl, _ := mockTest.NewMeter()
+	meter := metric.WrapMeterImpl(testNoUnregisterMeter{impl}, "test")
+
+	o := Must(meter).NewInt64ValueObserver("test.observer", func(context.Context, metric.Int64ObserverResult) {})
+	require.True(t, errors.Is(o.Unregister(), metric.ErrAsyncUnregisterUnsupported))
+
+	// Instruments without a callback have nothing to unregister.
+	noop := Must(meter).NewInt64ValueObserver("test.noop", nil)
+	require.NoError(t, noop.Unregister())
+	require.NoError(t, metric.BatchObserver{}.Unregister())
+}
+
 func checkObserverBatch(t *testing.T, labels []kv.KeyValue, mock *mockTest.MeterImpl, nkind metric.NumberKind, mkind metric.Kind, observer metric.AsyncImpl, expected float64) {
 	t.Helper()
 	assert.Len(t, mock.MeasurementBatches, 1)
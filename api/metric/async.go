@@ -180,38 +180,32 @@ func (b *BatchObserverCallback) Run(ctx context.Context, function func([]kv.KeyV
 	})
 }
 
-// wrapInt64ValueObserverInstrument converts an AsyncImpl into Int64ValueObserver.
-func wrapInt64ValueObserverInstrument(asyncInst AsyncImpl, err error) (Int64ValueObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapInt64ValueObserverInstrument converts an asyncInstrument into Int64ValueObserver.
+func wrapInt64ValueObserverInstrument(common asyncInstrument, err error) (Int64ValueObserver, error) {
 	return Int64ValueObserver{asyncInstrument: common}, err
 }
 
-// wrapFloat64ValueObserverInstrument converts an AsyncImpl into Float64ValueObserver.
-func wrapFloat64ValueObserverInstrument(asyncInst AsyncImpl, err error) (Float64ValueObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapFloat64ValueObserverInstrument converts an asyncInstrument into Float64ValueObserver.
+func wrapFloat64ValueObserverInstrument(common asyncInstrument, err error) (Float64ValueObserver, error) {
 	return Float64ValueObserver{asyncInstrument: common}, err
 }
 
-// wrapInt64SumObserverInstrument converts an AsyncImpl into Int64SumObserver.
-func wrapInt64SumObserverInstrument(asyncInst AsyncImpl, err error) (Int64SumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapInt64SumObserverInstrument converts an asyncInstrument into Int64SumObserver.
+func wrapInt64SumObserverInstrument(common asyncInstrument, err error) (Int64SumObserver, error) {
 	return Int64SumObserver{asyncInstrument: common}, err
 }
 
-// wrapFloat64SumObserverInstrument converts an AsyncImpl into Float64SumObserver.
-func wrapFloat64SumObserverInstrument(asyncInst AsyncImpl, err error) (Float64SumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapFloat64SumObserverInstrument converts an asyncInstrument into Float64SumObserver.
+func wrapFloat64SumObserverInstrument(common asyncInstrument, err error) (Float64SumObserver, error) {
 	return Float64SumObserver{asyncInstrument: common}, err
 }
 
-// wrapInt64UpDownSumObserverInstrument converts an AsyncImpl into Int64UpDownSumObserver.
-func wrapInt64UpDownSumObserverInstrument(asyncInst AsyncImpl, err error) (Int64UpDownSumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapInt64UpDownSumObserverInstrument converts an asyncInstrument into Int64UpDownSumObserver.
+func wrapInt64UpDownSumObserverInstrument(common asyncInstrument, err error) (Int64UpDownSumObserver, error) {
 	return Int64UpDownSumObserver{asyncInstrument: common}, err
 }
 
-// wrapFloat64UpDownSumObserverInstrument converts an AsyncImpl into Float64UpDownSumObserver.
-func wrapFloat64UpDownSumObserverInstrument(asyncInst AsyncImpl, err error) (Float64UpDownSumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+// wrapFloat64UpDownSumObserverInstrument converts an asyncInstrument into Float64UpDownSumObserver.
+func wrapFloat64UpDownSumObserverInstrument(common asyncInstrument, err error) (Float64UpDownSumObserver, error) {
 	return Float64UpDownSumObserver{asyncInstrument: common}, err
 }
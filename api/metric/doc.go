@@ -29,6 +29,7 @@
 //   Counter:           additive, monotonic
 //   UpDownCounter:     additive
 //   ValueRecorder:     non-additive
+//   Gauge:             non-additive, last value
 //
 // and the asynchronous instruments are:
 //
@@ -43,6 +44,9 @@
 // interfaces for recording batches of synchronous measurements or
 // asynchronous observations.  To obtain a Meter, use a Provider.
 //
+// Asynchronous instruments and BatchObservers may be removed using
+// Unregister, when supported by the SDK.
+//
 // The Provider interface supports obtaining a named Meter interface.
 // To obtain a Provider implementation, initialize and configure any
 // compatible SDK.
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric
+
+import (
+	"context"
+
+	"github.com/Ch1f/otel/api/kv"
+)
+
+// Float64Gauge is a metric that records the current float64 value.
+type Float64Gauge struct {
+	syncInstrument
+}
+
+// Int64Gauge is a metric that records the current int64 value.
+type Int64Gauge struct {
+	syncInstrument
+}
+
+// BoundFloat64Gauge is a bound instrument for Float64Gauge.
+//
+// It inherits the Unbind function from syncBoundInstrument.
+type BoundFloat64Gauge struct {
+	syncBoundInstrument
+}
+
+// BoundInt64Gauge is a bound instrument for Int64Gauge.
+//
+// It inherits the Unbind function from syncBoundInstrument.
+type BoundInt64Gauge struct {
+	syncBoundInstrument
+}
+
+// Bind creates a bound instrument for this Gauge. The labels are
+// associated with values set via subsequent calls to Set.
+func (g Float64Gauge) Bind(labels ...kv.KeyValue) (h BoundFloat64Gauge) {
+	h.syncBoundInstrument = g.bind(labels)
+	return
+}
+
+// Bind creates a bound instrument for this Gauge. The labels are
+// associated with values set via subsequent calls to Set.
+func (g Int64Gauge) Bind(labels ...kv.KeyValue) (h BoundInt64Gauge) {
+	h.syncBoundInstrument = g.bind(labels)
+	return
+}
+
+// Measurement creates a Measurement object to use with batch
+// recording.
+func (g Float64Gauge) Measurement(value float64) Measurement {
+	return g.float64Measurement(value)
+}
+
+// Measurement creates a Measurement object to use with batch
+// recording.
+func (g Int64Gauge) Measurement(value int64) Measurement {
+	return g.int64Measurement(value)
+}
+
+// Set replaces the current value of the Gauge. The labels should
+// contain the keys and values to be associated with this value.
+func (g Float64Gauge) Set(ctx context.Context, value float64, labels ...kv.KeyValue) {
+	g.directRecord(ctx, NewFloat64Number(value), labels)
+}
+
+// Set replaces the current value of the Gauge. The labels should
+// contain the keys and values to be associated with this value.
+func (g Int64Gauge) Set(ctx context.Context, value int64, labels ...kv.KeyValue) {
+	g.directRecord(ctx, NewInt64Number(value), labels)
+}
+
+// Set replaces the current value of the Gauge using the labels
+// previously bound to the Gauge via Bind().
+func (b BoundFloat64Gauge) Set(ctx context.Context, value float64) {
+	b.directRecord(ctx, NewFloat64Number(value))
+}
+
+// Set replaces the current value of the Gauge using the labels
+// previously bound to the Gauge via Bind().
+func (b BoundInt64Gauge) Set(ctx context.Context, value int64) {
+	b.directRecord(ctx, NewInt64Number(value))
+}
@@ -34,12 +34,15 @@ const (
 	SumObserverKind
 	// UpDownSumObserverKind indicates a UpDownSumObserver instrument.
 	UpDownSumObserverKind
+
+	// GaugeKind indicates a Gauge instrument.
+	GaugeKind
 )
 
 // Synchronous returns whether this is a synchronous kind of instrument.
 func (k Kind) Synchronous() bool {
 	switch k {
-	case CounterKind, UpDownCounterKind, ValueRecorderKind:
+	case CounterKind, UpDownCounterKind, ValueRecorderKind, GaugeKind:
 		return true
 	}
 	return false
@@ -14,11 +14,12 @@ func _() {
 	_ = x[UpDownCounterKind-3]
 	_ = x[SumObserverKind-4]
 	_ = x[UpDownSumObserverKind-5]
+	_ = x[GaugeKind-6]
 }
 
-const _Kind_name = "ValueRecorderKindValueObserverKindCounterKindUpDownCounterKindSumObserverKindUpDownSumObserverKind"
+const _Kind_name = "ValueRecorderKindValueObserverKindCounterKindUpDownCounterKindSumObserverKindUpDownSumObserverKindGaugeKind"
 
-var _Kind_index = [...]uint8{0, 17, 34, 45, 62, 77, 98}
+var _Kind_index = [...]uint8{0, 17, 34, 45, 62, 77, 98, 107}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
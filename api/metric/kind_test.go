@@ -27,6 +27,7 @@ var (
 		metric.ValueRecorderKind,
 		metric.CounterKind,
 		metric.UpDownCounterKind,
+		metric.GaugeKind,
 	}
 	asyncKinds = []metric.Kind{
 		metric.ValueObserverKind,
@@ -42,6 +43,7 @@ var (
 	groupingKinds = []metric.Kind{
 		metric.ValueRecorderKind,
 		metric.ValueObserverKind,
+		metric.GaugeKind,
 	}
 
 	monotonicKinds = []metric.Kind{
@@ -54,6 +56,7 @@ var (
 		metric.UpDownSumObserverKind,
 		metric.ValueRecorderKind,
 		metric.ValueObserverKind,
+		metric.GaugeKind,
 	}
 
 	precomputedSumKinds = []metric.Kind{
@@ -66,6 +69,7 @@ var (
 		metric.UpDownCounterKind,
 		metric.ValueRecorderKind,
 		metric.ValueObserverKind,
+		metric.GaugeKind,
 	}
 )
 
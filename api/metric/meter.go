@@ -65,6 +65,7 @@ func (m Meter) NewBatchObserver(callback BatchObserverCallback) BatchObserver {
 	return BatchObserver{
 		meter:  m,
 		runner: newBatchAsyncRunner(callback),
+		state:  &batchObserverState{},
 	}
 }
 
@@ -122,13 +123,31 @@ func (m Meter) NewFloat64ValueRecorder(name string, opts ...InstrumentOption) (F
 		m.newSync(name, ValueRecorderKind, Float64NumberKind, opts))
 }
 
+// NewInt64Gauge creates a new integer Gauge instrument with the
+// given name, customized with options.  May return an error if the
+// name is invalid (e.g., empty) or improperly registered (e.g.,
+// duplicate registration).
+func (m Meter) NewInt64Gauge(name string, opts ...InstrumentOption) (Int64Gauge, error) {
+	return wrapInt64GaugeInstrument(
+		m.newSync(name, GaugeKind, Int64NumberKind, opts))
+}
+
+// NewFloat64Gauge creates a new floating point Gauge with the given
+// name, customized with options.  May return an error if the name is
+// invalid (e.g., empty) or improperly registered (e.g., duplicate
+// registration).
+func (m Meter) NewFloat64Gauge(name string, opts ...InstrumentOption) (Float64Gauge, error) {
+	return wrapFloat64GaugeInstrument(
+		m.newSync(name, GaugeKind, Float64NumberKind, opts))
+}
+
 // NewInt64ValueObserver creates a new integer ValueObserver instrument
 // with the given name, running a given callback, and customized with
 // options.  May return an error if the name is invalid (e.g., empty)
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64ValueObserver(name string, callback Int64ObserverCallback, opts ...InstrumentOption) (Int64ValueObserver, error) {
 	if callback == nil {
-		return wrapInt64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64ValueObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64ValueObserverInstrument(
 		m.newAsync(name, ValueObserverKind, Int64NumberKind, opts,
@@ -141,7 +160,7 @@ func (m Meter) NewInt64ValueObserver(name string, callback Int64ObserverCallback
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64ValueObserver(name string, callback Float64ObserverCallback, opts ...InstrumentOption) (Float64ValueObserver, error) {
 	if callback == nil {
-		return wrapFloat64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64ValueObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64ValueObserverInstrument(
 		m.newAsync(name, ValueObserverKind, Float64NumberKind, opts,
@@ -154,7 +173,7 @@ func (m Meter) NewFloat64ValueObserver(name string, callback Float64ObserverCall
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64SumObserver(name string, callback Int64ObserverCallback, opts ...InstrumentOption) (Int64SumObserver, error) {
 	if callback == nil {
-		return wrapInt64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64SumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64SumObserverInstrument(
 		m.newAsync(name, SumObserverKind, Int64NumberKind, opts,
@@ -167,7 +186,7 @@ func (m Meter) NewInt64SumObserver(name string, callback Int64ObserverCallback,
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64SumObserver(name string, callback Float64ObserverCallback, opts ...InstrumentOption) (Float64SumObserver, error) {
 	if callback == nil {
-		return wrapFloat64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64SumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64SumObserverInstrument(
 		m.newAsync(name, SumObserverKind, Float64NumberKind, opts,
@@ -180,7 +199,7 @@ func (m Meter) NewFloat64SumObserver(name string, callback Float64ObserverCallba
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64UpDownSumObserver(name string, callback Int64ObserverCallback, opts ...InstrumentOption) (Int64UpDownSumObserver, error) {
 	if callback == nil {
-		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64UpDownSumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64UpDownSumObserverInstrument(
 		m.newAsync(name, UpDownSumObserverKind, Int64NumberKind, opts,
@@ -193,7 +212,7 @@ func (m Meter) NewInt64UpDownSumObserver(name string, callback Int64ObserverCall
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64UpDownSumObserver(name string, callback Float64ObserverCallback, opts ...InstrumentOption) (Float64UpDownSumObserver, error) {
 	if callback == nil {
-		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64UpDownSumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64UpDownSumObserverInstrument(
 		m.newAsync(name, UpDownSumObserverKind, Float64NumberKind, opts,
@@ -206,10 +225,10 @@ func (m Meter) NewFloat64UpDownSumObserver(name string, callback Float64Observer
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64ValueObserver(name string, opts ...InstrumentOption) (Int64ValueObserver, error) {
 	if b.runner == nil {
-		return wrapInt64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64ValueObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64ValueObserverInstrument(
-		b.meter.newAsync(name, ValueObserverKind, Int64NumberKind, opts, b.runner))
+		b.newAsync(name, ValueObserverKind, Int64NumberKind, opts))
 }
 
 // NewFloat64ValueObserver creates a new floating point ValueObserver with
@@ -218,11 +237,10 @@ func (b BatchObserver) NewInt64ValueObserver(name string, opts ...InstrumentOpti
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64ValueObserver(name string, opts ...InstrumentOption) (Float64ValueObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64ValueObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64ValueObserverInstrument(
-		b.meter.newAsync(name, ValueObserverKind, Float64NumberKind, opts,
-			b.runner))
+		b.newAsync(name, ValueObserverKind, Float64NumberKind, opts))
 }
 
 // NewInt64SumObserver creates a new integer SumObserver instrument
@@ -231,10 +249,10 @@ func (b BatchObserver) NewFloat64ValueObserver(name string, opts ...InstrumentOp
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64SumObserver(name string, opts ...InstrumentOption) (Int64SumObserver, error) {
 	if b.runner == nil {
-		return wrapInt64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64SumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64SumObserverInstrument(
-		b.meter.newAsync(name, SumObserverKind, Int64NumberKind, opts, b.runner))
+		b.newAsync(name, SumObserverKind, Int64NumberKind, opts))
 }
 
 // NewFloat64SumObserver creates a new floating point SumObserver with
@@ -243,11 +261,10 @@ func (b BatchObserver) NewInt64SumObserver(name string, opts ...InstrumentOption
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64SumObserver(name string, opts ...InstrumentOption) (Float64SumObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64SumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64SumObserverInstrument(
-		b.meter.newAsync(name, SumObserverKind, Float64NumberKind, opts,
-			b.runner))
+		b.newAsync(name, SumObserverKind, Float64NumberKind, opts))
 }
 
 // NewInt64UpDownSumObserver creates a new integer UpDownSumObserver instrument
@@ -256,10 +273,10 @@ func (b BatchObserver) NewFloat64SumObserver(name string, opts ...InstrumentOpti
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64UpDownSumObserver(name string, opts ...InstrumentOption) (Int64UpDownSumObserver, error) {
 	if b.runner == nil {
-		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64UpDownSumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapInt64UpDownSumObserverInstrument(
-		b.meter.newAsync(name, UpDownSumObserverKind, Int64NumberKind, opts, b.runner))
+		b.newAsync(name, UpDownSumObserverKind, Int64NumberKind, opts))
 }
 
 // NewFloat64UpDownSumObserver creates a new floating point UpDownSumObserver with
@@ -268,11 +285,10 @@ func (b BatchObserver) NewInt64UpDownSumObserver(name string, opts ...Instrument
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64UpDownSumObserver(name string, opts ...InstrumentOption) (Float64UpDownSumObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64UpDownSumObserverInstrument(noopAsyncInstrument, nil)
 	}
 	return wrapFloat64UpDownSumObserverInstrument(
-		b.meter.newAsync(name, UpDownSumObserverKind, Float64NumberKind, opts,
-			b.runner))
+		b.newAsync(name, UpDownSumObserverKind, Float64NumberKind, opts))
 }
 
 // MeterImpl returns the underlying MeterImpl of this Meter.
@@ -288,16 +304,35 @@ func (m Meter) newAsync(
 	opts []InstrumentOption,
 	runner AsyncRunner,
 ) (
-	AsyncImpl,
+	asyncInstrument,
 	error,
 ) {
 	if m.impl == nil {
-		return NoopAsync{}, nil
+		return noopAsyncInstrument, nil
 	}
 	desc := NewDescriptor(name, mkind, nkind, opts...)
 	desc.config.InstrumentationName = m.name
 	desc.config.InstrumentationVersion = m.version
-	return m.impl.NewAsyncInstrument(desc, runner)
+	inst, err := m.impl.NewAsyncInstrument(desc, runner)
+	return checkNewAsync(m.impl, inst, err)
+}
+
+// newAsync constructs one new asynchronous instrument running in
+// this batch callback and remembers it for Unregister.
+func (b BatchObserver) newAsync(
+	name string,
+	mkind Kind,
+	nkind NumberKind,
+	opts []InstrumentOption,
+) (
+	asyncInstrument,
+	error,
+) {
+	common, err := b.meter.newAsync(name, mkind, nkind, opts, b.runner)
+	if err == nil && b.state != nil {
+		b.state.add(common)
+	}
+	return common, err
 }
 
 // newSync constructs one new synchronous instrument.
@@ -93,6 +93,26 @@ func (mm MeterMust) NewFloat64ValueRecorder(name string, mos ...InstrumentOption
 	}
 }
 
+// NewInt64Gauge calls `Meter.NewInt64Gauge` and returns the
+// instrument, panicking if it encounters an error.
+func (mm MeterMust) NewInt64Gauge(name string, mos ...InstrumentOption) Int64Gauge {
+	if inst, err := mm.meter.NewInt64Gauge(name, mos...); err != nil {
+		panic(err)
+	} else {
+		return inst
+	}
+}
+
+// NewFloat64Gauge calls `Meter.NewFloat64Gauge` and returns the
+// instrument, panicking if it encounters an error.
+func (mm MeterMust) NewFloat64Gauge(name string, mos ...InstrumentOption) Float64Gauge {
+	if inst, err := mm.meter.NewFloat64Gauge(name, mos...); err != nil {
+		panic(err)
+	} else {
+		return inst
+	}
+}
+
 // NewInt64ValueObserver calls `Meter.NewInt64ValueObserver` and
 // returns the instrument, panicking if it encounters an error.
 func (mm MeterMust) NewInt64ValueObserver(name string, callback Int64ObserverCallback, oos ...InstrumentOption) Int64ValueObserver {
@@ -220,3 +240,8 @@ func (bm BatchObserverMust) NewFloat64UpDownSumObserver(name string, oos ...Inst
 		return inst
 	}
 }
+
+// Unregister calls `BatchObserver.Unregister`.
+func (bm BatchObserverMust) Unregister() error {
+	return bm.batch.Unregister()
+}
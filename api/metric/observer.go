@@ -14,11 +14,21 @@
 
 package metric
 
+import "sync"
+
 // BatchObserver represents an Observer callback that can report
 // observations for multiple instruments.
 type BatchObserver struct {
 	meter  Meter
 	runner AsyncBatchRunner
+	state  *batchObserverState
+}
+
+// batchObserverState tracks the instruments constructed through a
+// BatchObserver, so that they can be unregistered together.
+type batchObserverState struct {
+	lock        sync.Mutex
+	instruments []asyncInstrument
 }
 
 // Int64ValueObserver is a metric that captures a set of int64 values at a
@@ -122,3 +132,34 @@ func (f Float64UpDownSumObserver) Observation(v float64) Observation {
 		instrument: f.instrument,
 	}
 }
+
+// Unregister stops running the batch callback and unregisters every
+// instrument constructed through this BatchObserver.  The first error
+// encountered is returned.  Unregister may be called from within the
+// batch callback.
+func (b BatchObserver) Unregister() error {
+	if b.state == nil {
+		return nil
+	}
+	var firstErr error
+	for _, inst := range b.state.take() {
+		if err := inst.Unregister(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *batchObserverState) add(inst asyncInstrument) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.instruments = append(s.instruments, inst)
+}
+
+func (s *batchObserverState) take() []asyncInstrument {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	insts := s.instruments
+	s.instruments = nil
+	return insts
+}
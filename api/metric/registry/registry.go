@@ -40,6 +40,7 @@ type uniqueInstrumentMeterImpl struct {
 }
 
 var _ metric.MeterImpl = (*uniqueInstrumentMeterImpl)(nil)
+var _ metric.AsyncUnregisterer = (*uniqueInstrumentMeterImpl)(nil)
 
 type key struct {
 	instrumentName         string
@@ -168,3 +169,23 @@ func (u *uniqueInstrumentMeterImpl) NewAsyncInstrument(
 	u.state[keyOf(descriptor)] = asyncInst
 	return asyncInst, nil
 }
+
+// UnregisterAsyncInstrument implements metric.AsyncUnregisterer.  The
+// instrument name becomes available for registration again.
+func (u *uniqueInstrumentMeterImpl) UnregisterAsyncInstrument(inst metric.AsyncImpl) error {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	unregisterer, ok := u.impl.(metric.AsyncUnregisterer)
+	if !ok {
+		return metric.ErrAsyncUnregisterUnsupported
+	}
+	if err := unregisterer.UnregisterAsyncInstrument(inst); err != nil {
+		return err
+	}
+	k := keyOf(inst.Descriptor())
+	if existing, ok := u.state[k]; ok && existing == inst {
+		delete(u.state, k)
+	}
+	return nil
+}
@@ -44,6 +44,12 @@ var (
 		"valuerecorder.float64": func(m metric.Meter, name string) (metric.InstrumentImpl, error) {
 			return unwrap(m.NewFloat64ValueRecorder(name))
 		},
+		"gauge.int64": func(m metric.Meter, name string) (metric.InstrumentImpl, error) {
+			return unwrap(m.NewInt64Gauge(name))
+		},
+		"gauge.float64": func(m metric.Meter, name string) (metric.InstrumentImpl, error) {
+			return unwrap(m.NewFloat64Gauge(name))
+		},
 		"valueobserver.int64": func(m metric.Meter, name string) (metric.InstrumentImpl, error) {
 			return unwrap(m.NewInt64ValueObserver(name, func(context.Context, metric.Int64ObserverResult) {}))
 		},
@@ -120,6 +126,23 @@ func TestRegistryDiffInstruments(t *testing.T) {
 	}
 }
 
+func TestRegistryUnregister(t *testing.T) {
+	_, provider := mockTest.NewProvider()
+	meter := provider.Meter("meter")
+
+	obs, err := meter.NewInt64ValueObserver("this", func(context.Context, metric.Int64ObserverResult) {})
+	require.NoError(t, err)
+
+	_, err = meter.NewInt64Gauge("this")
+	require.True(t, errors.Is(err, registry.ErrMetricKindMismatch))
+
+	require.NoError(t, obs.Unregister())
+
+	gauge, err := meter.NewInt64Gauge("this")
+	require.NoError(t, err)
+	require.Equal(t, metric.GaugeKind, gauge.SyncImpl().Descriptor().MetricKind())
+}
+
 func TestProvider(t *testing.T) {
 	impl, _ := mockTest.NewMeter()
 	p := registry.NewProvider(impl)
@@ -16,6 +16,7 @@ package metric
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Ch1f/otel/api/kv"
 )
@@ -40,6 +41,22 @@ type MeterImpl interface {
 	) (AsyncImpl, error)
 }
 
+// ErrAsyncUnregisterUnsupported is returned when unregistering an
+// asynchronous instrument whose MeterImpl does not implement
+// AsyncUnregisterer.
+var ErrAsyncUnregisterUnsupported = errors.New("MeterImpl does not support unregistering asynchronous instruments")
+
+// AsyncUnregisterer is an optional interface of MeterImpl, supporting
+// removal of asynchronous instruments.
+type AsyncUnregisterer interface {
+	// UnregisterAsyncInstrument stops observing the instrument
+	// and releases its state.  A batch callback is no longer run
+	// once all of its instruments have been unregistered.
+	// Unregistering an unknown instrument has no effect.  This
+	// must be safe to call from within observer callbacks.
+	UnregisterAsyncInstrument(AsyncImpl) error
+}
+
 // InstrumentImpl is a common interface for synchronous and
 // asynchronous instruments.
 type InstrumentImpl interface {
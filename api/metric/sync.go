@@ -40,9 +40,17 @@ type syncBoundInstrument struct {
 	boundInstrument BoundSyncImpl
 }
 
-// asyncInstrument contains a AsyncImpl.
+// asyncInstrument contains a AsyncImpl and the MeterImpl that
+// created it.
 type asyncInstrument struct {
 	instrument AsyncImpl
+	meter      MeterImpl
+}
+
+// noopAsyncInstrument is returned for observers constructed without a
+// callback.
+var noopAsyncInstrument = asyncInstrument{
+	instrument: NoopAsync{},
 }
 
 // SyncImpl returns the instrument that created this measurement.
@@ -74,6 +82,24 @@ func (a asyncInstrument) AsyncImpl() AsyncImpl {
 	return a.instrument
 }
 
+// Unregister stops running the callback of this instrument and
+// releases its state in the SDK.  Instruments belonging to a
+// BatchObserver are removed individually; the batch callback keeps
+// running for the remaining instruments.  Returns
+// ErrAsyncUnregisterUnsupported if the SDK does not support removing
+// instruments.  Unregister may be called from within the
+// instrument's own callback.
+func (a asyncInstrument) Unregister() error {
+	if a.meter == nil {
+		return nil
+	}
+	u, ok := a.meter.(AsyncUnregisterer)
+	if !ok {
+		return ErrAsyncUnregisterUnsupported
+	}
+	return u.UnregisterAsyncInstrument(a.instrument)
+}
+
 // SyncImpl returns the implementation object for synchronous instruments.
 func (s syncInstrument) SyncImpl() SyncImpl {
 	return s.instrument
@@ -107,15 +133,16 @@ func (h syncBoundInstrument) Unbind() {
 // checkNewAsync receives an AsyncImpl and potential
 // error, and returns the same types, checking for and ensuring that
 // the returned interface is not nil.
-func checkNewAsync(instrument AsyncImpl, err error) (asyncInstrument, error) {
+func checkNewAsync(meter MeterImpl, instrument AsyncImpl, err error) (asyncInstrument, error) {
 	if instrument == nil {
 		if err == nil {
 			err = oterror.ErrSDKReturnedNilImpl
 		}
-		instrument = NoopAsync{}
+		return noopAsyncInstrument, err
 	}
 	return asyncInstrument{
 		instrument: instrument,
+		meter:      meter,
 	}, err
 }
 
@@ -187,3 +214,15 @@ func wrapFloat64ValueRecorderInstrument(syncInst SyncImpl, err error) (Float64Va
 	common, err := checkNewSync(syncInst, err)
 	return Float64ValueRecorder{syncInstrument: common}, err
 }
+
+// wrapInt64GaugeInstrument converts a SyncImpl into Int64Gauge.
+func wrapInt64GaugeInstrument(syncInst SyncImpl, err error) (Int64Gauge, error) {
+	common, err := checkNewSync(syncInst, err)
+	return Int64Gauge{syncInstrument: common}, err
+}
+
+// wrapFloat64GaugeInstrument converts a SyncImpl into Float64Gauge.
+func wrapFloat64GaugeInstrument(syncInst SyncImpl, err error) (Float64Gauge, error) {
+	common, err := checkNewSync(syncInst, err)
+	return Float64Gauge{syncInstrument: common}, err
+}
@@ -156,6 +156,18 @@ func httpCommonAttributesFromHTTPRequest(request *http.Request) []kv.KeyValue {
 }
 
 func httpBasicAttributesFromHTTPRequest(request *http.Request) []kv.KeyValue {
+	attrs := httpConnectionAttributesFromHTTPRequest(request)
+
+	if request.ContentLength > 0 {
+		attrs = append(attrs, HTTPRequestContentLengthKey.Int64(request.ContentLength))
+	}
+
+	return attrs
+}
+
+// httpConnectionAttributesFromHTTPRequest generates the scheme, host and
+// flavor attributes of a request, all of which are low-cardinality.
+func httpConnectionAttributesFromHTTPRequest(request *http.Request) []kv.KeyValue {
 	attrs := []kv.KeyValue{}
 
 	if request.TLS != nil {
@@ -177,21 +189,34 @@ func httpBasicAttributesFromHTTPRequest(request *http.Request) []kv.KeyValue {
 	if flavor != "" {
 		attrs = append(attrs, HTTPFlavorKey.String(flavor))
 	}
-	if request.ContentLength > 0 {
-		attrs = append(attrs, HTTPRequestContentLengthKey.Int64(request.ContentLength))
-	}
 
 	return attrs
 }
 
+// httpMethodAttribute returns the method attribute of a request, an
+// empty method meaning GET.
+func httpMethodAttribute(request *http.Request) kv.KeyValue {
+	if request.Method != "" {
+		return HTTPMethodKey.String(request.Method)
+	}
+	return HTTPMethodKey.String(http.MethodGet)
+}
+
 // HTTPServerMetricAttributesFromHTTPRequest generates low-cardinality attributes
 // to be used with server-side HTTP metrics.
 func HTTPServerMetricAttributesFromHTTPRequest(serverName string, request *http.Request) []kv.KeyValue {
-	attrs := []kv.KeyValue{}
+	attrs := []kv.KeyValue{httpMethodAttribute(request)}
 	if serverName != "" {
 		attrs = append(attrs, HTTPServerNameKey.String(serverName))
 	}
-	return append(attrs, httpBasicAttributesFromHTTPRequest(request)...)
+	return append(attrs, httpConnectionAttributesFromHTTPRequest(request)...)
+}
+
+// HTTPClientMetricAttributesFromHTTPRequest generates low-cardinality
+// attributes to be used with client-side HTTP metrics.
+func HTTPClientMetricAttributesFromHTTPRequest(request *http.Request) []kv.KeyValue {
+	attrs := []kv.KeyValue{httpMethodAttribute(request)}
+	return append(attrs, httpConnectionAttributesFromHTTPRequest(request)...)
 }
 
 // HTTPServerAttributesFromHTTPRequest generates attributes of the
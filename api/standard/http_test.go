@@ -680,6 +680,27 @@ func TestHTTPServerAttributesFromHTTPRequest(t *testing.T) {
 	}
 }
 
+func TestHTTPMetricAttributesFromHTTPRequest(t *testing.T) {
+	r := testRequest("POST", "/user/123", "HTTP/1.1", "", "example.com", &url.URL{Path: "/user/123"}, nil, withTLS)
+	r.ContentLength = 42
+
+	assertElementsMatch(t, []otelkv.KeyValue{
+		otelkv.String("http.method", "POST"),
+		otelkv.String("http.server_name", "my-server"),
+		otelkv.String("http.scheme", "https"),
+		otelkv.String("http.host", "example.com"),
+		otelkv.String("http.flavor", "1.1"),
+	}, HTTPServerMetricAttributesFromHTTPRequest("my-server", r), "server metric attributes")
+
+	r.Method = ""
+	assertElementsMatch(t, []otelkv.KeyValue{
+		otelkv.String("http.method", "GET"),
+		otelkv.String("http.scheme", "https"),
+		otelkv.String("http.host", "example.com"),
+		otelkv.String("http.flavor", "1.1"),
+	}, HTTPClientMetricAttributesFromHTTPRequest(r), "client metric attributes")
+}
+
 func TestHTTPAttributesFromHTTPStatusCode(t *testing.T) {
 	expected := []otelkv.KeyValue{
 		otelkv.Int("http.status_code", 404),
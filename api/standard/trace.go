@@ -211,6 +211,9 @@ const (
 	// The name of the method being called.
 	RPCMethodKey = kv.Key("rpc.method")
 
+	// The numeric status code of the gRPC request.
+	RPCGRPCStatusCodeKey = kv.Key("rpc.grpc.status_code")
+
 	// Name of message transmitted or received.
 	RPCNameKey = kv.Key("name")
 
@@ -336,3 +339,28 @@ var (
 	FaaSDocumentOperationEdit   = FaaSDocumentOperationKey.String("edit")
 	FaaSDocumentOperationDelete = FaaSDocumentOperationKey.String("delete")
 )
+
+// Standard attribute keys for exceptions, such as panics, recorded as
+// span events.
+const (
+	// The message of the exception. For a panic, the value passed to
+	// panic formatted as a string.
+	ExceptionMessageKey = kv.Key("exception.message")
+
+	// The stack trace of the exception. For a panic, the stack trace of
+	// the goroutine that panicked.
+	ExceptionStacktraceKey = kv.Key("exception.stacktrace")
+)
+
+// Standard attribute keys for errors recorded as span events.
+const (
+	// The type of the error.
+	ErrorTypeKey = kv.Key("error.type")
+
+	// The message of the error.
+	ErrorMessageKey = kv.Key("error.message")
+
+	// The type and message of each error wrapped by the error, one per
+	// line, outermost first.
+	ErrorCausesKey = kv.Key("error.causes")
+)
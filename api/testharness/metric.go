@@ -0,0 +1,481 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testharness
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/label"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/metric/registry"
+	"github.com/Ch1f/otel/api/unit"
+	"github.com/Ch1f/otel/internal/matchers"
+)
+
+// MeterImplSubject is a metric.MeterImpl under test, with a way to
+// read back what it recorded.
+type MeterImplSubject struct {
+	// Impl is the implementation under test.
+	Impl metric.MeterImpl
+
+	// Collect runs the callbacks of the asynchronous instruments of
+	// Impl and returns a result for each instrument and label set
+	// that was updated since the previous call.
+	Collect func(context.Context) []MeterImplResult
+}
+
+// MeterImplResult is the result of a collection for one instrument
+// and label set.
+type MeterImplResult struct {
+	// Descriptor is the descriptor of the instrument.
+	Descriptor metric.Descriptor
+	// Labels is the label set.
+	Labels *label.Set
+	// Sum is the sum of the values recorded since the previous
+	// collection for a synchronous instrument, or the last value
+	// observed during the collection for an asynchronous instrument.
+	Sum metric.Number
+}
+
+type meterImplHarness struct {
+	subject MeterImplSubject
+	meter   metric.Meter
+}
+
+func newMeterImplHarness(subjectFactory func() MeterImplSubject) *meterImplHarness {
+	subject := subjectFactory()
+	return &meterImplHarness{
+		subject: subject,
+		meter:   registry.NewProvider(subject.Impl).Meter("testharness"),
+	}
+}
+
+// collect returns the sum collected for the instrument name and
+// labels, and whether there was a result for them.
+func (h *meterImplHarness) collect(results []MeterImplResult, name string, labels ...kv.KeyValue) (float64, bool) {
+	set := label.NewSet(labels...)
+	for _, r := range results {
+		if r.Descriptor.Name() == name && r.Labels.Equals(&set) {
+			return r.Sum.CoerceToFloat64(r.Descriptor.NumberKind()), true
+		}
+	}
+	return 0, false
+}
+
+// TestMeterImpl validates a metric.MeterImpl, used through the
+// uniqueness checking of the metric registry.
+func (h *Harness) TestMeterImpl(subjectFactory func() MeterImplSubject) {
+	ctx := context.Background()
+
+	h.t.Run("#NewSyncInstrument", func(t *testing.T) {
+		t.Run("returns the registered instrument for the same name and kind", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			c1 := metric.Must(m.meter).NewInt64Counter("counter")
+			c2 := metric.Must(m.meter).NewInt64Counter("counter")
+
+			e.Expect(c1.SyncImpl()).ToEqual(c2.SyncImpl())
+		})
+
+		t.Run("fails for the same name with another kind", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			metric.Must(m.meter).NewInt64Counter("instrument")
+			_, err := m.meter.NewFloat64Counter("instrument")
+			e.Expect(errors.Is(err, registry.ErrMetricKindMismatch)).ToBeTrue()
+			_, err = m.meter.NewInt64ValueRecorder("instrument")
+			e.Expect(errors.Is(err, registry.ErrMetricKindMismatch)).ToBeTrue()
+			_, err = m.meter.NewInt64SumObserver("instrument", func(context.Context, metric.Int64ObserverResult) {})
+			e.Expect(errors.Is(err, registry.ErrMetricKindMismatch)).ToBeTrue()
+		})
+
+		t.Run("allows the same name for another instrumentation library", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+			other := metric.WrapMeterImpl(registry.NewUniqueInstrumentMeterImpl(m.subject.Impl), "other")
+
+			metric.Must(m.meter).NewInt64Counter("counter")
+			_, err := other.NewFloat64ValueRecorder("counter")
+
+			e.Expect(err).ToBeNil()
+		})
+	})
+
+	h.t.Run("#Descriptor", func(t *testing.T) {
+		opts := []metric.InstrumentOption{
+			metric.WithDescription("description"),
+			metric.WithUnit(unit.Milliseconds),
+		}
+
+		t.Run("propagates the instrument configuration", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+			meter := registry.NewProvider(m.subject.Impl).Meter("library", metric.WithInstrumentationVersion("v1"))
+
+			counter := metric.Must(meter).NewFloat64Counter("counter", opts...)
+			observer := metric.Must(meter).NewInt64ValueObserver("observer", func(context.Context, metric.Int64ObserverResult) {}, opts...)
+
+			for _, test := range []struct {
+				desc       metric.Descriptor
+				name       string
+				kind       metric.Kind
+				numberKind metric.NumberKind
+			}{
+				{counter.SyncImpl().Descriptor(), "counter", metric.CounterKind, metric.Float64NumberKind},
+				{observer.AsyncImpl().Descriptor(), "observer", metric.ValueObserverKind, metric.Int64NumberKind},
+			} {
+				e.Expect(test.desc.Name()).ToEqual(test.name)
+				e.Expect(test.desc.MetricKind()).ToEqual(test.kind)
+				e.Expect(test.desc.NumberKind()).ToEqual(test.numberKind)
+				e.Expect(test.desc.Description()).ToEqual("description")
+				e.Expect(test.desc.Unit()).ToEqual(unit.Milliseconds)
+				e.Expect(test.desc.InstrumentationName()).ToEqual("library")
+				e.Expect(test.desc.InstrumentationVersion()).ToEqual("v1")
+			}
+		})
+
+		t.Run("is collected with the results", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewInt64Counter("counter", opts...)
+			counter.Add(ctx, 1)
+
+			results := m.subject.Collect(ctx)
+
+			e.Expect(len(results)).ToEqual(1)
+			e.Expect(results[0].Descriptor).ToEqual(counter.SyncImpl().Descriptor())
+		})
+	})
+
+	h.t.Run("#RecordOne", func(t *testing.T) {
+		t.Run("records measurements per label set", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewInt64Counter("counter")
+			recorder := metric.Must(m.meter).NewFloat64ValueRecorder("recorder")
+			counter.Add(ctx, 1, kv.String("A", "1"))
+			counter.Add(ctx, 2, kv.String("A", "1"))
+			counter.Add(ctx, 5, kv.String("A", "2"))
+			recorder.Record(ctx, 1.5)
+			recorder.Record(ctx, 2.5)
+
+			results := m.subject.Collect(ctx)
+
+			e.Expect(len(results)).ToEqual(3)
+			sum, _ := m.collect(results, "counter", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(3.0)
+			sum, _ = m.collect(results, "counter", kv.String("A", "2"))
+			e.Expect(sum).ToEqual(5.0)
+			sum, _ = m.collect(results, "recorder")
+			e.Expect(sum).ToEqual(4.0)
+		})
+	})
+
+	h.t.Run("#Bind", func(t *testing.T) {
+		t.Run("records bound measurements with the bound labels", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewInt64Counter("counter")
+			bound := counter.Bind(kv.String("A", "1"))
+			defer bound.Unbind()
+			bound.Add(ctx, 1)
+			counter.Add(ctx, 2, kv.String("A", "1"))
+
+			sum, ok := m.collect(m.subject.Collect(ctx), "counter", kv.String("A", "1"))
+
+			e.Expect(ok).ToBeTrue()
+			e.Expect(sum).ToEqual(3.0)
+		})
+
+		t.Run("can be bound twice with the same labels", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			recorder := metric.Must(m.meter).NewInt64ValueRecorder("recorder")
+			bound1 := recorder.Bind(kv.String("A", "1"))
+			bound2 := recorder.Bind(kv.String("A", "1"))
+			bound1.Record(ctx, 1)
+			bound2.Record(ctx, 2)
+			bound1.Unbind()
+			bound2.Record(ctx, 3)
+			bound2.Unbind()
+
+			sum, _ := m.collect(m.subject.Collect(ctx), "recorder", kv.String("A", "1"))
+
+			e.Expect(sum).ToEqual(6.0)
+		})
+
+		t.Run("can be bound again after being unbound", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewFloat64Counter("counter")
+			bound := counter.Bind(kv.String("A", "1"))
+			bound.Add(ctx, 1)
+			bound.Unbind()
+
+			sum, _ := m.collect(m.subject.Collect(ctx), "counter", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(1.0)
+
+			bound = counter.Bind(kv.String("A", "1"))
+			bound.Add(ctx, 2)
+			bound.Unbind()
+
+			sum, _ = m.collect(m.subject.Collect(ctx), "counter", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(2.0)
+		})
+	})
+
+	h.t.Run("#RecordBatch", func(t *testing.T) {
+		t.Run("records each measurement with the batch labels", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewInt64Counter("counter")
+			upDown := metric.Must(m.meter).NewFloat64UpDownCounter("updown")
+			recorder := metric.Must(m.meter).NewInt64ValueRecorder("recorder")
+
+			m.meter.RecordBatch(ctx, []kv.KeyValue{kv.String("A", "1")},
+				counter.Measurement(1),
+				upDown.Measurement(-2.5),
+				recorder.Measurement(3),
+			)
+			m.meter.RecordBatch(ctx, []kv.KeyValue{kv.String("A", "1")},
+				counter.Measurement(4),
+			)
+
+			results := m.subject.Collect(ctx)
+
+			e.Expect(len(results)).ToEqual(3)
+			sum, _ := m.collect(results, "counter", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(5.0)
+			sum, _ = m.collect(results, "updown", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(-2.5)
+			sum, _ = m.collect(results, "recorder", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(3.0)
+		})
+	})
+
+	h.t.Run("#NewAsyncInstrument", func(t *testing.T) {
+		t.Run("invokes the callback once per collection", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			calls := 0
+			metric.Must(m.meter).NewInt64SumObserver("observer", func(_ context.Context, result metric.Int64ObserverResult) {
+				calls++
+				result.Observe(int64(calls*10), kv.String("A", "1"))
+			})
+			e.Expect(calls).ToEqual(0)
+
+			for i := 1; i <= 2; i++ {
+				sum, ok := m.collect(m.subject.Collect(ctx), "observer", kv.String("A", "1"))
+
+				e.Expect(calls).ToEqual(i)
+				e.Expect(ok).ToBeTrue()
+				e.Expect(sum).ToEqual(float64(i * 10))
+			}
+		})
+
+		t.Run("keeps the last observation of a collection", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			metric.Must(m.meter).NewFloat64UpDownSumObserver("observer", func(_ context.Context, result metric.Float64ObserverResult) {
+				result.Observe(1, kv.String("A", "1"))
+				result.Observe(-2, kv.String("A", "1"))
+			})
+
+			sum, _ := m.collect(m.subject.Collect(ctx), "observer", kv.String("A", "1"))
+
+			e.Expect(sum).ToEqual(-2.0)
+		})
+
+		t.Run("invokes batch observer callbacks", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			var sumObs metric.Int64SumObserver
+			var valueObs metric.Float64ValueObserver
+			batch := metric.Must(m.meter).NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+				result.Observe([]kv.KeyValue{kv.String("A", "1")},
+					sumObs.Observation(3),
+					valueObs.Observation(1.5),
+				)
+			})
+			sumObs = batch.NewInt64SumObserver("sum")
+			valueObs = batch.NewFloat64ValueObserver("value")
+
+			results := m.subject.Collect(ctx)
+
+			e.Expect(len(results)).ToEqual(2)
+			sum, _ := m.collect(results, "sum", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(3.0)
+			sum, _ = m.collect(results, "value", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(1.5)
+		})
+	})
+
+	h.t.Run("#Concurrency", func(t *testing.T) {
+		t.Run("records concurrently", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			const goroutines, measurements = 10, 100
+			counter := metric.Must(m.meter).NewInt64Counter("counter")
+			bound := counter.Bind(kv.String("A", "1"))
+			defer bound.Unbind()
+
+			var wg sync.WaitGroup
+			wg.Add(goroutines)
+			for g := 0; g < goroutines; g++ {
+				go func() {
+					defer wg.Done()
+					for i := 0; i < measurements; i++ {
+						bound.Add(ctx, 1)
+						counter.Add(ctx, 1, kv.String("A", "1"))
+						m.meter.RecordBatch(ctx, []kv.KeyValue{kv.String("A", "1")}, counter.Measurement(1))
+						// Creating instruments concurrently returns the
+						// registered instrument.
+						metric.Must(m.meter).NewInt64Counter("counter").Add(ctx, 1, kv.String("A", "2"))
+					}
+				}()
+			}
+			wg.Wait()
+
+			results := m.subject.Collect(ctx)
+
+			sum, _ := m.collect(results, "counter", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(float64(3 * goroutines * measurements))
+			sum, _ = m.collect(results, "counter", kv.String("A", "2"))
+			e.Expect(sum).ToEqual(float64(goroutines * measurements))
+		})
+	})
+
+	h.t.Run("#Monotonic", func(t *testing.T) {
+		t.Run("drops negative measurements of counters", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewInt64Counter("counter")
+			counter.Add(ctx, 2)
+			counter.Add(ctx, -1)
+
+			sum, _ := m.collect(m.subject.Collect(ctx), "counter")
+
+			e.Expect(sum).ToEqual(2.0)
+		})
+
+		t.Run("drops negative observations of sum observers", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			metric.Must(m.meter).NewFloat64SumObserver("observer", func(_ context.Context, result metric.Float64ObserverResult) {
+				result.Observe(2, kv.String("A", "1"))
+				result.Observe(-1, kv.String("A", "2"))
+			})
+
+			results := m.subject.Collect(ctx)
+
+			sum, _ := m.collect(results, "observer", kv.String("A", "1"))
+			e.Expect(sum).ToEqual(2.0)
+			_, ok := m.collect(results, "observer", kv.String("A", "2"))
+			e.Expect(ok).ToBeFalse()
+		})
+
+		t.Run("drops NaN measurements", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			counter := metric.Must(m.meter).NewFloat64Counter("counter")
+			recorder := metric.Must(m.meter).NewFloat64ValueRecorder("recorder")
+			counter.Add(ctx, 1)
+			counter.Add(ctx, math.NaN())
+			recorder.Record(ctx, math.NaN())
+			recorder.Record(ctx, 2)
+
+			results := m.subject.Collect(ctx)
+
+			sum, _ := m.collect(results, "counter")
+			e.Expect(sum).ToEqual(1.0)
+			sum, _ = m.collect(results, "recorder")
+			e.Expect(sum).ToEqual(2.0)
+		})
+
+		t.Run("accepts negative measurements of non-monotonic instruments", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+			m := newMeterImplHarness(subjectFactory)
+
+			upDown := metric.Must(m.meter).NewInt64UpDownCounter("updown")
+			recorder := metric.Must(m.meter).NewInt64ValueRecorder("recorder")
+			upDown.Add(ctx, 2)
+			upDown.Add(ctx, -3)
+			recorder.Record(ctx, -4)
+
+			results := m.subject.Collect(ctx)
+
+			sum, _ := m.collect(results, "updown")
+			e.Expect(sum).ToEqual(-1.0)
+			sum, _ = m.collect(results, "recorder")
+			e.Expect(sum).ToEqual(-4.0)
+		})
+	})
+}
@@ -86,6 +86,8 @@ func WithEndTime(t time.Time) EndOption {
 type ErrorConfig struct {
 	Timestamp  time.Time
 	StatusCode codes.Code
+	StackTrace bool
+	Causes     bool
 }
 
 // ErrorOption applies changes to ErrorConfig that sets options when an error event is recorded.
@@ -105,6 +107,25 @@ func WithErrorStatus(s codes.Code) ErrorOption {
 	}
 }
 
+// WithErrorStackTrace records a stack trace with the error event. The
+// stack trace is taken from the innermost error of the chain returned by
+// errors.Unwrap with a StackTrace method returning a string, and
+// otherwise from the caller of RecordError.
+func WithErrorStackTrace() ErrorOption {
+	return func(c *ErrorConfig) {
+		c.StackTrace = true
+	}
+}
+
+// WithErrorCauses records the types and messages of the errors wrapped
+// by the recorded error, as returned by errors.Unwrap, with the error
+// event.
+func WithErrorCauses() ErrorOption {
+	return func(c *ErrorConfig) {
+		c.Causes = true
+	}
+}
+
 type Span interface {
 	// Tracer returns tracer used to create this span. Tracer cannot be nil.
 	Tracer() Tracer
@@ -19,5 +19,7 @@ type Unit string
 const (
 	Dimensionless Unit = "1"
 	Bytes         Unit = "By"
+	Nanoseconds   Unit = "ns"
 	Milliseconds  Unit = "ms"
+	Seconds       Unit = "s"
 )
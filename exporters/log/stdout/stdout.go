@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	export "github.com/Ch1f/otel/sdk/export/log"
+)
+
+// Options are the options to be used when initializing a stdout export.
+type Options struct {
+	// Writer is the destination.  If not set, os.Stdout is used.
+	Writer io.Writer
+
+	// PrettyPrint will pretty the json representation of the record,
+	// making it print "pretty". Default is false.
+	PrettyPrint bool
+}
+
+// Exporter is an implementation of log.LogSyncer and log.LogBatcher that
+// writes records to stdout, one JSON document per record.
+type Exporter struct {
+	pretty       bool
+	mu           sync.Mutex
+	outputWriter io.Writer
+}
+
+var (
+	_ export.LogSyncer  = (*Exporter)(nil)
+	_ export.LogBatcher = (*Exporter)(nil)
+)
+
+func NewExporter(o Options) (*Exporter, error) {
+	if o.Writer == nil {
+		o.Writer = os.Stdout
+	}
+	return &Exporter{
+		pretty:       o.PrettyPrint,
+		outputWriter: o.Writer,
+	}, nil
+}
+
+// ExportLog writes a Record in json format to stdout.
+func (e *Exporter) ExportLog(ctx context.Context, r *export.Record) {
+	var jsonRecord []byte
+	var err error
+	if e.pretty {
+		jsonRecord, err = json.MarshalIndent(r, "", "\t")
+	} else {
+		jsonRecord, err = json.Marshal(r)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		// ignore writer failures for now
+		_, _ = e.outputWriter.Write([]byte("Error converting log record to json: " + err.Error()))
+		return
+	}
+	// ignore writer failures for now
+	_, _ = e.outputWriter.Write(append(jsonRecord, byte('\n')))
+}
+
+// ExportLogs writes each Record in json format to stdout.
+func (e *Exporter) ExportLogs(ctx context.Context, rs []*export.Record) {
+	for _, r := range rs {
+		e.ExportLog(ctx, r)
+	}
+}
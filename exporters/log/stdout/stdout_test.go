@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+	apilog "github.com/Ch1f/otel/api/log"
+	"github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/log"
+	"github.com/Ch1f/otel/sdk/instrumentation"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+func TestExporter_ExportLog(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := NewExporter(Options{Writer: &b})
+	require.NoError(t, err)
+
+	now := time.Now()
+	traceID, _ := trace.IDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+	ex.ExportLog(context.Background(), &export.Record{
+		Timestamp:    now,
+		Severity:     apilog.SeverityError,
+		SeverityText: "ERROR",
+		Body:         value.String("boom"),
+		Attributes:   []kv.KeyValue{kv.String("key", "value")},
+		TraceID:      traceID,
+		SpanID:       spanID,
+		TraceFlags:   trace.FlagsSampled,
+		Resource:     resource.New(kv.String("rk1", "rv11")),
+		InstrumentationLibrary: instrumentation.Library{
+			Name: "instr",
+		},
+	})
+
+	expectedSerializedNow, _ := json.Marshal(now)
+	expectedOutput := `{"Timestamp":` + string(expectedSerializedNow) + "," +
+		`"Severity":17,` +
+		`"SeverityText":"ERROR",` +
+		`"Body":{"Type":"STRING","Value":"boom"},` +
+		`"Attributes":[{"Key":"key","Value":{"Type":"STRING","Value":"value"}}],` +
+		`"TraceID":"0102030405060708090a0b0c0d0e0f10",` +
+		`"SpanID":"0102030405060708",` +
+		`"TraceFlags":1,` +
+		`"Resource":[{"Key":"rk1","Value":{"Type":"STRING","Value":"rv11"}}],` +
+		`"InstrumentationLibrary":{"Name":"instr","Version":""}}` + "\n"
+	assert.Equal(t, expectedOutput, b.String())
+}
+
+func TestExporter_ExportLogs(t *testing.T) {
+	var b bytes.Buffer
+	ex, err := NewExporter(Options{Writer: &b, PrettyPrint: true})
+	require.NoError(t, err)
+
+	ex.ExportLogs(context.Background(), []*export.Record{
+		{Body: value.String("one")},
+		{Body: value.String("two")},
+	})
+
+	out := b.String()
+	assert.Equal(t, 2, strings.Count(out, "\n}\n"))
+	assert.Contains(t, out, `"Value": "one"`)
+	assert.Contains(t, out, `"Value": "two"`)
+}
@@ -5,7 +5,9 @@ go 1.13
 replace github.com/Ch1f/otel => ../../..
 
 require (
+	github.com/Ch1f/otel v0.7.0
+	github.com/golang/protobuf v1.4.2
 	github.com/prometheus/client_golang v1.7.1
+	github.com/prometheus/client_model v0.2.0
 	github.com/stretchr/testify v1.6.1
-	github.com/Ch1f/otel v0.7.0
 )
@@ -18,10 +18,14 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"sync"
+	"unicode/utf8"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/label"
@@ -81,6 +85,15 @@ type Config struct {
 	// DefaultHistogramBoundaries defines the default histogram bucket
 	// boundaries.
 	DefaultHistogramBoundaries []float64
+
+	// ExemplarReservoirSize is the number of exemplars sampled per
+	// counter and per histogram bucket from the measurements
+	// recorded within a sampled span. If it is positive, the most
+	// recent exemplar of each counter and bucket is exposed to
+	// scrapers that negotiate the OpenMetrics format.
+	//
+	// If not set exemplars are disabled.
+	ExemplarReservoirSize int
 }
 
 // NewExportPipeline sets up a complete export pipeline with the recommended setup,
@@ -99,7 +112,9 @@ func NewExportPipeline(config Config, options ...pull.Option) (*Exporter, error)
 	}
 
 	e := &Exporter{
-		handler:                    promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{}),
+		handler: promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: config.ExemplarReservoirSize > 0,
+		}),
 		registerer:                 config.Registerer,
 		gatherer:                   config.Gatherer,
 		defaultSummaryQuantiles:    config.DefaultSummaryQuantiles,
@@ -144,7 +159,9 @@ func (e *Exporter) SetController(config Config, options ...pull.Option) {
 	defer e.lock.Unlock()
 
 	e.controller = pull.New(
-		simple.NewWithHistogramDistribution(config.DefaultHistogramBoundaries),
+		simple.NewWithHistogramDistribution(config.DefaultHistogramBoundaries,
+			simple.WithExemplars(config.ExemplarReservoirSize),
+		),
 		e,
 		options...,
 	)
@@ -274,7 +291,11 @@ func (c *collector) exportCounter(ch chan<- prometheus.Metric, sum aggregation.S
 		return fmt.Errorf("error creating constant metric: %w", err)
 	}
 
-	ch <- m
+	exemplars, err := latestExemplars(sum, nil, kind)
+	if err != nil {
+		return fmt.Errorf("error retrieving exemplars: %w", err)
+	}
+	ch <- withExemplars(m, exemplars)
 	return nil
 }
 
@@ -332,7 +353,113 @@ func (c *collector) exportHistogram(ch chan<- prometheus.Metric, hist aggregatio
 		return fmt.Errorf("error creating constant histogram: %w", err)
 	}
 
-	ch <- m
+	exemplars, err := latestExemplars(hist, buckets.Boundaries, kind)
+	if err != nil {
+		return fmt.Errorf("error retrieving exemplars: %w", err)
+	}
+	ch <- withExemplars(m, exemplars)
+	return nil
+}
+
+// latestExemplars returns the most recent exemplar of each of the
+// buckets defined by boundaries, nil for buckets without exemplars. A
+// counter has a single bucket, boundaries is nil. It returns nil if agg
+// does not sample exemplars.
+func latestExemplars(agg aggregation.Aggregation, boundaries []float64, kind metric.NumberKind) ([]*dto.Exemplar, error) {
+	ea, ok := agg.(aggregation.Exemplars)
+	if !ok {
+		return nil, nil
+	}
+	exemplars, err := ea.Exemplars()
+	if err != nil || len(exemplars) == 0 {
+		return nil, err
+	}
+
+	latest := make([]*aggregation.Exemplar, len(boundaries)+1)
+	for i := range exemplars {
+		e := &exemplars[i]
+		v := e.Value.CoerceToFloat64(kind)
+		b := sort.Search(len(boundaries), func(j int) bool {
+			return v < boundaries[j]
+		})
+		if latest[b] == nil || !e.Time.Before(latest[b].Time) {
+			latest[b] = e
+		}
+	}
+
+	result := make([]*dto.Exemplar, len(latest))
+	for i, e := range latest {
+		if e == nil {
+			continue
+		}
+		ts, err := ptypes.TimestampProto(e.Time)
+		if err != nil {
+			return nil, err
+		}
+		value := e.Value.CoerceToFloat64(kind)
+		result[i] = &dto.Exemplar{
+			Label:     exemplarLabels(e),
+			Value:     &value,
+			Timestamp: ts,
+		}
+	}
+	return result, nil
+}
+
+// exemplarLabels returns the trace context of e followed by as many of
+// its filtered labels as fit into prometheus.ExemplarMaxRunes.
+func exemplarLabels(e *aggregation.Exemplar) []*dto.LabelPair {
+	var pairs []*dto.LabelPair
+	runes := 0
+	add := func(name, value string) bool {
+		n := utf8.RuneCountInString(name) + utf8.RuneCountInString(value)
+		if runes+n > prometheus.ExemplarMaxRunes {
+			return false
+		}
+		runes += n
+		pairs = append(pairs, &dto.LabelPair{Name: &name, Value: &value})
+		return true
+	}
+	add("trace_id", e.TraceID.String())
+	add("span_id", e.SpanID.String())
+	for _, l := range e.FilteredLabels {
+		if !add(sanitize(string(l.Key)), l.Value.Emit()) {
+			break
+		}
+	}
+	return pairs
+}
+
+// exemplarMetric adds exemplars to the counter or histogram buckets
+// written by a prometheus.Metric.
+type exemplarMetric struct {
+	prometheus.Metric
+	exemplars []*dto.Exemplar
+}
+
+// withExemplars returns m with the given exemplars, m if there are none.
+func withExemplars(m prometheus.Metric, exemplars []*dto.Exemplar) prometheus.Metric {
+	if len(exemplars) == 0 {
+		return m
+	}
+	return exemplarMetric{Metric: m, exemplars: exemplars}
+}
+
+func (m exemplarMetric) Write(out *dto.Metric) error {
+	if err := m.Metric.Write(out); err != nil {
+		return err
+	}
+	if out.Counter != nil {
+		out.Counter.Exemplar = m.exemplars[0]
+	}
+	if out.Histogram != nil {
+		// The +Inf bucket is implicit and cannot carry an exemplar.
+		for i, b := range out.Histogram.Bucket {
+			if i < len(m.exemplars) {
+				b.Exemplar = m.exemplars[i]
+			}
+		}
+	}
 	return nil
 }
 
@@ -29,8 +29,10 @@ import (
 	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/metric"
 	"github.com/Ch1f/otel/exporters/metric/prometheus"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
 	"github.com/Ch1f/otel/sdk/metric/controller/pull"
 	"github.com/Ch1f/otel/sdk/resource"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
 
 func TestPrometheusExporter(t *testing.T) {
@@ -143,3 +145,73 @@ a_counter{key="value"} 200
 `, scrape())
 
 }
+
+func TestPrometheusExemplars(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			DefaultHistogramBoundaries: []float64{1},
+			ExemplarReservoirSize:      1,
+		},
+		pull.WithCachePeriod(0),
+	)
+	require.NoError(t, err)
+	meter := exporter.Provider().Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	valuerecorder := metric.Must(meter).NewFloat64ValueRecorder("valuerecorder")
+
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	ctx = exemplar.ContextWithFilteredLabels(ctx, kv.String("u", "1"))
+	ids := `trace_id="` + span.SpanContext().TraceID.String() + `",span_id="` + span.SpanContext().SpanID.String() + `"`
+
+	counter.Add(ctx, 3)
+	counter.Add(context.Background(), 4)
+	valuerecorder.Record(ctx, 0.5)
+	valuerecorder.Record(ctx, 2)
+
+	scrape := func(accept string) string {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/metrics", nil)
+		req.Header.Set("Accept", accept)
+		exporter.ServeHTTP(rec, req)
+
+		// Strip the exemplar timestamps.
+		var lines []string
+		for _, line := range strings.Split(rec.Body.String(), "\n") {
+			if strings.Contains(line, " # {") {
+				line = line[:strings.LastIndex(line, " ")]
+			}
+			lines = append(lines, line)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	// Counters not named *_total are exposed as unknown in the
+	// OpenMetrics format. The filtered label does not fit into the
+	// exemplar label limit.
+	require.Equal(t, `# HELP counter 
+# TYPE counter unknown
+counter 7.0 # {`+ids+`} 3.0
+# HELP valuerecorder 
+# TYPE valuerecorder histogram
+valuerecorder_bucket{le="1.0"} 1 # {`+ids+`} 0.5
+valuerecorder_bucket{le="+Inf"} 2
+valuerecorder_sum 2.5
+valuerecorder_count 2
+# EOF
+`, scrape("application/openmetrics-text; version=0.0.1"))
+
+	// The text format has no exemplars.
+	require.Equal(t, `# HELP counter 
+# TYPE counter counter
+counter 7
+# HELP valuerecorder 
+# TYPE valuerecorder histogram
+valuerecorder_bucket{le="1"} 1
+valuerecorder_bucket{le="+Inf"} 2
+valuerecorder_sum 2.5
+valuerecorder_count 2
+`, scrape("text/plain"))
+}
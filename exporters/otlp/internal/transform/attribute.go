@@ -51,35 +51,86 @@ func ResourceAttributes(resource *resource.Resource) []*commonpb.KeyValue {
 }
 
 func toAttribute(v kv.KeyValue) *commonpb.KeyValue {
-	result := &commonpb.KeyValue{
+	return &commonpb.KeyValue{
 		Key:   string(v.Key),
-		Value: new(commonpb.AnyValue),
+		Value: toAnyValue(v.Value),
 	}
-	switch v.Value.Type() {
+}
+
+func toAnyValue(v value.Value) *commonpb.AnyValue {
+	result := new(commonpb.AnyValue)
+	switch v.Type() {
 	case value.BOOL:
-		result.Value.Value = &commonpb.AnyValue_BoolValue{
-			BoolValue: v.Value.AsBool(),
+		result.Value = &commonpb.AnyValue_BoolValue{
+			BoolValue: v.AsBool(),
 		}
 	case value.INT64, value.INT32, value.UINT32, value.UINT64:
-		result.Value.Value = &commonpb.AnyValue_IntValue{
-			IntValue: v.Value.AsInt64(),
+		result.Value = &commonpb.AnyValue_IntValue{
+			IntValue: v.AsInt64(),
 		}
 	case value.FLOAT32:
-		result.Value.Value = &commonpb.AnyValue_DoubleValue{
-			DoubleValue: float64(v.Value.AsFloat32()),
+		result.Value = &commonpb.AnyValue_DoubleValue{
+			DoubleValue: float64(v.AsFloat32()),
 		}
 	case value.FLOAT64:
-		result.Value.Value = &commonpb.AnyValue_DoubleValue{
-			DoubleValue: v.Value.AsFloat64(),
+		result.Value = &commonpb.AnyValue_DoubleValue{
+			DoubleValue: v.AsFloat64(),
 		}
 	case value.STRING:
-		result.Value.Value = &commonpb.AnyValue_StringValue{
-			StringValue: v.Value.AsString(),
+		result.Value = &commonpb.AnyValue_StringValue{
+			StringValue: v.AsString(),
+		}
+	case value.ARRAY:
+		result.Value = &commonpb.AnyValue_ArrayValue{
+			ArrayValue: toArrayValue(v),
+		}
+	case value.MAP:
+		entries := v.AsMapEntries()
+		values := make([]*commonpb.KeyValue, 0, len(entries))
+		for _, e := range entries {
+			values = append(values, &commonpb.KeyValue{
+				Key:   e.Key,
+				Value: toAnyValue(e.Value),
+			})
+		}
+		result.Value = &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: values},
 		}
 	default:
-		result.Value.Value = &commonpb.AnyValue_StringValue{
+		result.Value = &commonpb.AnyValue_StringValue{
 			StringValue: "INVALID",
 		}
 	}
 	return result
 }
+
+func toArrayValue(v value.Value) *commonpb.ArrayValue {
+	values := make([]*commonpb.AnyValue, 0, v.ArrayLen())
+	switch v.ArrayElementType() {
+	case value.BOOL:
+		for _, b := range v.AsBoolArray() {
+			values = append(values, &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_BoolValue{BoolValue: b},
+			})
+		}
+	case value.INT64:
+		for _, i := range v.AsInt64Array() {
+			values = append(values, &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_IntValue{IntValue: i},
+			})
+		}
+	case value.FLOAT64:
+		for _, f := range v.AsFloat64Array() {
+			values = append(values, &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_DoubleValue{DoubleValue: f},
+			})
+		}
+	case value.STRING:
+		for _, s := range v.AsStringArray() {
+			values = append(values, &commonpb.AnyValue{
+				Value: &commonpb.AnyValue_StringValue{StringValue: s},
+			})
+		}
+	}
+	return &commonpb.ArrayValue{Values: values}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Ch1f/otel/api/kv"
+	ottest "github.com/Ch1f/otel/internal/testing"
 )
 
 func TestAttributes(t *testing.T) {
@@ -146,3 +147,44 @@ func TestAttributes(t *testing.T) {
 		}
 	}
 }
+
+func TestCompositeAttributes(t *testing.T) {
+	anyValue := func(v interface{}) *commonpb.AnyValue {
+		switch v := v.(type) {
+		case bool:
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+		case int64:
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+		case float64:
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+		case string:
+			return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+		}
+		panic(v)
+	}
+	array := func(values ...interface{}) *commonpb.AnyValue {
+		av := &commonpb.ArrayValue{}
+		for _, v := range values {
+			av.Values = append(av.Values, anyValue(v))
+		}
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: av}}
+	}
+	kvlist := func(values ...*commonpb.KeyValue) *commonpb.AnyValue {
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_KvlistValue{
+			KvlistValue: &commonpb.KeyValueList{Values: values},
+		}}
+	}
+
+	assert.Equal(t, []*commonpb.KeyValue{
+		{Key: "bools", Value: array(true, false)},
+		{Key: "ints", Value: array(int64(1), int64(-2))},
+		{Key: "floats", Value: array(1.5, -2.)},
+		{Key: "strings", Value: array("a", "b,c")},
+		{Key: "map", Value: kvlist(
+			&commonpb.KeyValue{Key: "nested", Value: kvlist(
+				&commonpb.KeyValue{Key: "ints", Value: array(int64(3))},
+			)},
+			&commonpb.KeyValue{Key: "string", Value: anyValue("d")},
+		)},
+	}, Attributes(ottest.CompositeAttributes()))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -235,6 +236,8 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 // error is returned if the Record Aggregator is not supported.
 func Record(r export.Record) (*metricpb.Metric, error) {
 	switch a := r.Aggregation().(type) {
+	case aggregation.Histogram:
+		return histogram(r, a)
 	case aggregation.MinMaxSumCount:
 		return minMaxSumCount(r, a)
 	case aggregation.Sum:
@@ -346,6 +349,98 @@ func minMaxSumCount(record export.Record, a aggregation.MinMaxSumCount) (*metric
 	}, nil
 }
 
+// histogram transforms a Histogram Aggregator into an OTLP Metric. If
+// the Aggregator also samples exemplars, the most recent exemplar of
+// each bucket is included.
+func histogram(record export.Record, a aggregation.Histogram) (*metricpb.Metric, error) {
+	desc := record.Descriptor()
+	labels := record.Labels()
+	buckets, err := a.Histogram()
+	if err != nil {
+		return nil, err
+	}
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+
+	var exemplars []aggregation.Exemplar
+	if ea, ok := a.(aggregation.Exemplars); ok {
+		if exemplars, err = ea.Exemplars(); err != nil {
+			return nil, err
+		}
+	}
+	latest := latestExemplars(buckets.Boundaries, exemplars, desc.NumberKind())
+
+	var count uint64
+	pbBuckets := make([]*metricpb.HistogramDataPoint_Bucket, len(buckets.Counts))
+	for i, c := range buckets.Counts {
+		count += uint64(c)
+		pbBuckets[i] = &metricpb.HistogramDataPoint_Bucket{Count: uint64(c)}
+		if e := latest[i]; e != nil {
+			pbBuckets[i].Exemplar = &metricpb.HistogramDataPoint_Bucket_Exemplar{
+				Value:        e.Value.CoerceToFloat64(desc.NumberKind()),
+				TimeUnixNano: uint64(e.Time.UnixNano()),
+				Attachments:  exemplarAttachments(e),
+			}
+		}
+	}
+
+	return &metricpb.Metric{
+		MetricDescriptor: &metricpb.MetricDescriptor{
+			Name:        desc.Name(),
+			Description: desc.Description(),
+			Unit:        string(desc.Unit()),
+			Type:        metricpb.MetricDescriptor_HISTOGRAM,
+		},
+		HistogramDataPoints: []*metricpb.HistogramDataPoint{
+			{
+				Labels:            stringKeyValues(labels.Iter()),
+				StartTimeUnixNano: uint64(record.StartTime().UnixNano()),
+				TimeUnixNano:      uint64(record.EndTime().UnixNano()),
+				Count:             count,
+				Sum:               sum.CoerceToFloat64(desc.NumberKind()),
+				Buckets:           pbBuckets,
+				ExplicitBounds:    buckets.Boundaries,
+			},
+		},
+	}, nil
+}
+
+// latestExemplars returns the most recent exemplar of each of the
+// buckets defined by boundaries, nil for buckets without exemplars.
+func latestExemplars(boundaries []float64, exemplars []aggregation.Exemplar, kind metric.NumberKind) []*aggregation.Exemplar {
+	latest := make([]*aggregation.Exemplar, len(boundaries)+1)
+	for i := range exemplars {
+		e := &exemplars[i]
+		v := e.Value.CoerceToFloat64(kind)
+		b := sort.Search(len(boundaries), func(j int) bool {
+			return v < boundaries[j]
+		})
+		if latest[b] == nil || !e.Time.Before(latest[b].Time) {
+			latest[b] = e
+		}
+	}
+	return latest
+}
+
+// exemplarAttachments returns the trace context and filtered labels of
+// an exemplar as OTLP StringKeyValues.
+func exemplarAttachments(e *aggregation.Exemplar) []*commonpb.StringKeyValue {
+	attachments := make([]*commonpb.StringKeyValue, 0, 2+len(e.FilteredLabels))
+	attachments = append(attachments,
+		&commonpb.StringKeyValue{Key: "trace_id", Value: e.TraceID.String()},
+		&commonpb.StringKeyValue{Key: "span_id", Value: e.SpanID.String()},
+	)
+	for _, l := range e.FilteredLabels {
+		attachments = append(attachments, &commonpb.StringKeyValue{
+			Key:   string(l.Key),
+			Value: l.Value.Emit(),
+		})
+	}
+	return attachments
+}
+
 // stringKeyValues transforms a label iterator into an OTLP StringKeyValues.
 func stringKeyValues(iter label.Iterator) []*commonpb.StringKeyValue {
 	l := iter.Len()
@@ -32,8 +32,11 @@ import (
 	"github.com/Ch1f/otel/exporters/metric/test"
 	export "github.com/Ch1f/otel/sdk/export/metric"
 	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
+	histogramAgg "github.com/Ch1f/otel/sdk/metric/aggregator/histogram"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/minmaxsumcount"
 	sumAgg "github.com/Ch1f/otel/sdk/metric/aggregator/sum"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
 
 var (
@@ -320,3 +323,75 @@ func TestSumErrUnknownValueType(t *testing.T) {
 		t.Errorf("expected ErrUnknownValueType, got %v", err)
 	}
 }
+
+func TestHistogramDataPoints(t *testing.T) {
+	desc := metric.NewDescriptor("latency", metric.ValueRecorderKind, metric.Int64NumberKind,
+		metric.WithUnit(unit.Milliseconds),
+	)
+	labels := label.NewSet(kv.String("A", "1"))
+	h, ckpt := test.Unslice2(histogramAgg.New(2, &desc, []float64{10, 100}))
+	for _, v := range []int64{1, 50, 60, 200} {
+		require.NoError(t, h.Update(context.Background(), metric.NewInt64Number(v), &desc))
+	}
+	require.NoError(t, h.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, nil, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	m, err := Record(record)
+	require.NoError(t, err)
+	assert.Equal(t, &metricpb.MetricDescriptor{
+		Name: "latency",
+		Unit: "ms",
+		Type: metricpb.MetricDescriptor_HISTOGRAM,
+	}, m.MetricDescriptor)
+	assert.Equal(t, []*metricpb.HistogramDataPoint{{
+		Labels:            []*commonpb.StringKeyValue{{Key: "A", Value: "1"}},
+		StartTimeUnixNano: uint64(intervalStart.UnixNano()),
+		TimeUnixNano:      uint64(intervalEnd.UnixNano()),
+		Count:             4,
+		Sum:               311,
+		Buckets: []*metricpb.HistogramDataPoint_Bucket{
+			{Count: 1}, {Count: 2}, {Count: 1},
+		},
+		ExplicitBounds: []float64{10, 100},
+	}}, m.HistogramDataPoints)
+	assert.Nil(t, m.Int64DataPoints)
+	assert.Nil(t, m.SummaryDataPoints)
+}
+
+func TestHistogramExemplars(t *testing.T) {
+	desc := metric.NewDescriptor("latency", metric.ValueRecorderKind, metric.Float64NumberKind)
+	labels := label.NewSet()
+	h, ckpt := test.Unslice2(histogramAgg.New(2, &desc, []float64{10}, histogramAgg.WithExemplars(2)))
+
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.End()
+	sc := span.SpanContext()
+	ctx = exemplar.ContextWithFilteredLabels(ctx, kv.Int("user.id", 42))
+
+	require.NoError(t, h.Update(ctx, metric.NewFloat64Number(20), &desc))
+	require.NoError(t, h.Update(ctx, metric.NewFloat64Number(30), &desc))
+	require.NoError(t, h.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, nil, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	exemplars, err := ckpt.Aggregation().(aggregation.Exemplars).Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2)
+
+	m, err := Record(record)
+	require.NoError(t, err)
+	require.Len(t, m.HistogramDataPoints, 1)
+	buckets := m.HistogramDataPoints[0].Buckets
+	require.Len(t, buckets, 2)
+	assert.Nil(t, buckets[0].Exemplar)
+	assert.Equal(t, &metricpb.HistogramDataPoint_Bucket_Exemplar{
+		Value:        30,
+		TimeUnixNano: uint64(exemplars[1].Time.UnixNano()),
+		Attachments: []*commonpb.StringKeyValue{
+			{Key: "trace_id", Value: sc.TraceID.String()},
+			{Key: "span_id", Value: sc.SpanID.String()},
+			{Key: "user.id", Value: "42"},
+		},
+	}, buckets[1].Exemplar)
+}
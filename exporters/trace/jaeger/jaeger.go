@@ -24,6 +24,7 @@ import (
 	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/kv/value"
+	"github.com/Ch1f/otel/api/standard"
 	apitrace "github.com/Ch1f/otel/api/trace"
 	gen "github.com/Ch1f/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
 	export "github.com/Ch1f/otel/sdk/export/trace"
@@ -237,18 +238,7 @@ func spanDataToThrift(data *export.SpanData) *gen.Span {
 
 	var logs []*gen.Log
 	for _, a := range data.MessageEvents {
-		fields := make([]*gen.Tag, 0, len(a.Attributes))
-		for _, kv := range a.Attributes {
-			tag := keyValueToTag(kv)
-			if tag != nil {
-				fields = append(fields, tag)
-			}
-		}
-		fields = append(fields, getStringTag("name", a.Name))
-		logs = append(logs, &gen.Log{
-			Timestamp: a.Time.UnixNano() / 1000,
-			Fields:    fields,
-		})
+		logs = append(logs, eventToLog(a))
 	}
 
 	var refs []*gen.SpanRef
@@ -278,6 +268,41 @@ func spanDataToThrift(data *export.SpanData) *gen.Span {
 	}
 }
 
+// errorLogFields maps the attributes of error and panic events to the
+// fields of the OpenTracing log conventions understood by Jaeger.
+var errorLogFields = map[kv.Key]string{
+	standard.ErrorTypeKey:           "error.kind",
+	standard.ErrorMessageKey:        "message",
+	standard.ExceptionMessageKey:    "message",
+	standard.ExceptionStacktraceKey: "stack",
+}
+
+// eventToLog converts a span event to a Jaeger log. Error and panic
+// events are logged as an "error" event with the conventional fields.
+func eventToLog(event export.Event) *gen.Log {
+	isError := event.Name == "error" || event.Name == "panic"
+
+	fields := make([]*gen.Tag, 0, len(event.Attributes)+2)
+	for _, kv := range event.Attributes {
+		tag := keyValueToTag(kv)
+		if tag == nil {
+			continue
+		}
+		if key, ok := errorLogFields[kv.Key]; ok && isError {
+			tag.Key = key
+		}
+		fields = append(fields, tag)
+	}
+	fields = append(fields, getStringTag("name", event.Name))
+	if isError {
+		fields = append(fields, getStringTag("event", "error"))
+	}
+	return &gen.Log{
+		Timestamp: event.Time.UnixNano() / 1000,
+		Fields:    fields,
+	}
+}
+
 func keyValueToTag(keyValue kv.KeyValue) *gen.Tag {
 	var tag *gen.Tag
 	switch keyValue.Value.Type() {
@@ -323,6 +348,15 @@ func keyValueToTag(keyValue kv.KeyValue) *gen.Tag {
 			VDouble: &f,
 			VType:   gen.TagType_DOUBLE,
 		}
+	case value.ARRAY, value.MAP:
+		// Jaeger has no composite tag type, arrays and maps are
+		// encoded in JSON.
+		s := keyValue.Value.Emit()
+		tag = &gen.Tag{
+			Key:   string(keyValue.Key),
+			VStr:  &s,
+			VType: gen.TagType_STRING,
+		}
 	}
 	return tag
 }
@@ -29,6 +29,7 @@ import (
 
 	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
 	apitrace "github.com/Ch1f/otel/api/trace"
 	gen "github.com/Ch1f/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
 	ottest "github.com/Ch1f/otel/internal/testing"
@@ -317,6 +318,57 @@ func Test_spanDataToThrift(t *testing.T) {
 	}
 }
 
+func TestErrorEventToLog(t *testing.T) {
+	now := time.Now()
+	log := eventToLog(export.Event{
+		Name: "error",
+		Time: now,
+		Attributes: []kv.KeyValue{
+			standard.ErrorTypeKey.String("*errors.errorString"),
+			standard.ErrorMessageKey.String("failed"),
+			standard.ExceptionStacktraceKey.String("main.main\n\tmain.go:1\n"),
+			standard.ErrorCausesKey.String("*errors.errorString: cause"),
+		},
+	})
+
+	fields := map[string]string{}
+	for _, f := range log.Fields {
+		fields[f.Key] = f.GetVStr()
+	}
+	assert.Equal(t, now.UnixNano()/1000, log.Timestamp)
+	assert.Equal(t, map[string]string{
+		"error.kind":   "*errors.errorString",
+		"message":      "failed",
+		"stack":        "main.main\n\tmain.go:1\n",
+		"error.causes": "*errors.errorString: cause",
+		"name":         "error",
+		"event":        "error",
+	}, fields)
+
+	// Attributes of other events are not renamed.
+	log = eventToLog(export.Event{
+		Name:       "event",
+		Time:       now,
+		Attributes: []kv.KeyValue{standard.ErrorMessageKey.String("failed")},
+	})
+	fields = map[string]string{}
+	for _, f := range log.Fields {
+		fields[f.Key] = f.GetVStr()
+	}
+	assert.Equal(t, map[string]string{"error.message": "failed", "name": "event"}, fields)
+}
+
+func TestCompositeAttributesToTags(t *testing.T) {
+	got := map[string]string{}
+	for _, kv := range ottest.CompositeAttributes() {
+		tag := keyValueToTag(kv)
+		require.NotNil(t, tag, kv.Key)
+		assert.Equal(t, gen.TagType_STRING, tag.VType)
+		got[tag.Key] = tag.GetVStr()
+	}
+	assert.Equal(t, ottest.CompositeAttributesJSON, got)
+}
+
 func TestNewExporterPipelineWithDisabled(t *testing.T) {
 	tp, fn, err := NewExportPipeline(
 		WithCollectorEndpoint("http://localhost:14268/api/traces"),
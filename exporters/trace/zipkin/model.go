@@ -22,6 +22,7 @@ import (
 	zkmodel "github.com/openzipkin/zipkin-go/model"
 
 	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/trace"
 	export "github.com/Ch1f/otel/sdk/export/trace"
 )
@@ -107,9 +108,21 @@ func toZipkinAnnotations(events []export.Event) []zkmodel.Annotation {
 	}
 	annotations := make([]zkmodel.Annotation, 0, len(events))
 	for _, event := range events {
+		// Stack traces are unreadable when escaped in JSON, they are
+		// annotated separately at the time of the event.
+		attributes := make([]kv.KeyValue, 0, len(event.Attributes))
+		var stacks []kv.KeyValue
+		for _, attribute := range event.Attributes {
+			if _, ok := stackTraceKeys[attribute.Key]; ok {
+				stacks = append(stacks, attribute)
+			} else {
+				attributes = append(attributes, attribute)
+			}
+		}
+
 		value := event.Name
-		if len(event.Attributes) > 0 {
-			jsonString := attributesToJSONMapString(event.Attributes)
+		if len(attributes) > 0 {
+			jsonString := attributesToJSONMapString(attributes)
 			if jsonString != "" {
 				value = fmt.Sprintf("%s: %s", event.Name, jsonString)
 			}
@@ -118,10 +131,21 @@ func toZipkinAnnotations(events []export.Event) []zkmodel.Annotation {
 			Timestamp: event.Time,
 			Value:     value,
 		})
+		for _, stack := range stacks {
+			annotations = append(annotations, zkmodel.Annotation{
+				Timestamp: event.Time,
+				Value:     fmt.Sprintf("%s %s:\n%s", event.Name, stack.Key, stack.Value.Emit()),
+			})
+		}
 	}
 	return annotations
 }
 
+// stackTraceKeys are the attributes of span events holding stack traces.
+var stackTraceKeys = map[kv.Key]struct{}{
+	standard.ExceptionStacktraceKey: {},
+}
+
 func attributesToJSONMapString(attributes []kv.KeyValue) string {
 	m := make(map[string]interface{}, len(attributes))
 	for _, attribute := range attributes {
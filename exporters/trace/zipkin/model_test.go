@@ -23,7 +23,9 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/trace"
+	ottest "github.com/Ch1f/otel/internal/testing"
 	export "github.com/Ch1f/otel/sdk/export/trace"
 )
 
@@ -656,3 +658,34 @@ func zkmodelIDPtr(n uint64) *zkmodel.ID {
 	id := zkmodel.ID(n)
 	return &id
 }
+
+func TestErrorEventAnnotations(t *testing.T) {
+	now := time.Now()
+	annotations := toZipkinAnnotations([]export.Event{
+		{
+			Name: "error",
+			Time: now,
+			Attributes: []kv.KeyValue{
+				standard.ErrorMessageKey.String("failed"),
+				standard.ExceptionStacktraceKey.String("main.main\n\tmain.go:1\n"),
+			},
+		},
+	})
+	require.Equal(t, []zkmodel.Annotation{
+		{
+			Timestamp: now,
+			Value:     `error: {"error.message":"failed"}`,
+		},
+		{
+			Timestamp: now,
+			Value:     "error exception.stacktrace:\nmain.main\n\tmain.go:1\n",
+		},
+	}, annotations)
+}
+
+func TestCompositeAttributesTags(t *testing.T) {
+	tags := toZipkinTags(&export.SpanData{Attributes: ottest.CompositeAttributes()})
+	for k, want := range ottest.CompositeAttributesJSON {
+		require.Equal(t, want, tags[k], k)
+	}
+}
@@ -22,26 +22,67 @@ import (
 	"github.com/Ch1f/otel/api/correlation"
 	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
 	"github.com/Ch1f/otel/api/propagation"
 	"github.com/Ch1f/otel/api/trace"
 )
 
-// Option is a function that allows configuration of the grpctrace Extract()
-// and Inject() functions
+// instrumentationName is the name of this instrumentation package.
+const instrumentationName = "github.com/Ch1f/otel/instrumentation/grpctrace"
+
+// Option is a function that allows configuration of the grpctrace
+// interceptors and the Extract() and Inject() functions
 type Option func(*config)
 
 type config struct {
-	propagators propagation.Propagators
+	propagators       propagation.Propagators
+	meter             metric.Meter
+	filters           []Filter
+	spanNameFormatter func(fullMethod string) string
+	sentEvent         bool
+	receivedEvent     bool
+	eventSampling     int
+	maxEvents         int
+	recordPanics      bool
 }
 
 func newConfig(opts []Option) *config {
-	c := &config{propagators: global.Propagators()}
+	c := &config{}
 	for _, o := range opts {
 		o(c)
 	}
 	return c
 }
 
+// newInterceptorConfig returns the configuration of an interceptor,
+// defaulting to the global meter and recording all message events.
+func newInterceptorConfig(opts []Option) *config {
+	defaultOpts := []Option{
+		WithMeter(global.Meter(instrumentationName)),
+		WithMessageEvents(SentEvents, ReceivedEvents),
+	}
+	return newConfig(append(defaultOpts, opts...))
+}
+
+// getPropagators returns the propagators set with WithPropagators, or
+// else the current global propagators.
+func (c *config) getPropagators() propagation.Propagators {
+	if c.propagators == nil {
+		return global.Propagators()
+	}
+	return c.propagators
+}
+
+// traced returns true if all filters allow fullMethod to be traced.
+func (c *config) traced(fullMethod string) bool {
+	for _, f := range c.filters {
+		if !f(fullMethod) {
+			return false
+		}
+	}
+	return true
+}
+
 // WithPropagators sets the propagators to use for Extraction and Injection
 func WithPropagators(props propagation.Propagators) Option {
 	return func(c *config) {
@@ -49,6 +90,100 @@ func WithPropagators(props propagation.Propagators) Option {
 	}
 }
 
+// WithMeter sets the meter used by the interceptors to record RPC
+// metrics. If this option isn't specified then the global meter is used.
+func WithMeter(meter metric.Meter) Option {
+	return func(c *config) {
+		c.meter = meter
+	}
+}
+
+// Filter is a predicate used to determine whether a gRPC method should be
+// traced. A Filter must return true if the method should be traced.
+// The argument is the full RPC method string, i.e.,
+// /package.service/method.
+type Filter func(fullMethod string) bool
+
+// WithFilter adds a filter to the list of filters used by the
+// interceptors. If any filter indicates to exclude a method then no span
+// is created and no metrics are recorded for calls of that method. If no
+// filters are provided then all methods are traced. Filters are invoked
+// for each call, it is advised to make them simple and fast.
+func WithFilter(f Filter) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, f)
+	}
+}
+
+// WithSpanNameFormatter takes a function that will be called with the
+// full RPC method string of every call, the returned string will become
+// the span name. By default the name is the method string without its
+// leading slash, i.e., package.service/method.
+func WithSpanNameFormatter(f func(fullMethod string) string) Option {
+	return func(c *config) {
+		c.spanNameFormatter = f
+	}
+}
+
+type event int
+
+// Different types of events that can be recorded, see WithMessageEvents
+const (
+	SentEvents event = iota
+	ReceivedEvents
+)
+
+// WithMessageEvents configures the interceptors to record only the
+// specified message events (span.AddEvent) on spans. By default both sent
+// and received message events are recorded, calling WithMessageEvents
+// without any event disables message events.
+//
+// Valid events are:
+//   - SentEvents: Record an event for every message sent.
+//   - ReceivedEvents: Record an event for every message received.
+func WithMessageEvents(events ...event) Option {
+	return func(c *config) {
+		c.sentEvent, c.receivedEvent = false, false
+		for _, e := range events {
+			switch e {
+			case SentEvents:
+				c.sentEvent = true
+			case ReceivedEvents:
+				c.receivedEvent = true
+			}
+		}
+	}
+}
+
+// WithMessageEventSampling configures the interceptors to record a
+// message event for only one in every n messages sent or received on a
+// stream. The message ID of recorded events still counts every message.
+// Values of n less than or equal to 1 record every message.
+func WithMessageEventSampling(n int) Option {
+	return func(c *config) {
+		c.eventSampling = n
+	}
+}
+
+// WithMaxMessageEvents limits the number of message events recorded on
+// the span of a single RPC to n. Messages exceeding the limit are not
+// recorded as events. Values of n less than or equal to 0 do not limit
+// the number of events.
+func WithMaxMessageEvents(n int) Option {
+	return func(c *config) {
+		c.maxEvents = n
+	}
+}
+
+// WithPanicRecording configures the server interceptors to record a
+// panic of the handler as a span event with its stack trace and an
+// error status. The panic is then propagated to the server.
+func WithPanicRecording() Option {
+	return func(c *config) {
+		c.recordPanics = true
+	}
+}
+
 type metadataSupplier struct {
 	metadata *metadata.MD
 }
@@ -70,7 +205,7 @@ func (s *metadataSupplier) Set(key string, value string) {
 // requests.
 func Inject(ctx context.Context, metadata *metadata.MD, opts ...Option) {
 	c := newConfig(opts)
-	propagation.InjectHTTP(ctx, c.propagators, &metadataSupplier{
+	propagation.InjectHTTP(ctx, c.getPropagators(), &metadataSupplier{
 		metadata: metadata,
 	})
 }
@@ -80,7 +215,7 @@ func Inject(ctx context.Context, metadata *metadata.MD, opts ...Option) {
 // This function is meant to be used on incoming requests.
 func Extract(ctx context.Context, metadata *metadata.MD, opts ...Option) ([]kv.KeyValue, trace.SpanContext) {
 	c := newConfig(opts)
-	ctx = propagation.ExtractHTTP(ctx, c.propagators, &metadataSupplier{
+	ctx = propagation.ExtractHTTP(ctx, c.getPropagators(), &metadataSupplier{
 		metadata: metadata,
 	})
 
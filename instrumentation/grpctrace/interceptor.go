@@ -21,12 +21,14 @@ import (
 	"io"
 	"net"
 	"strings"
+	"sync/atomic"
 
 	"github.com/Ch1f/otel/api/standard"
 
 	"github.com/golang/protobuf/proto" //nolint:staticcheck
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
@@ -34,6 +36,7 @@ import (
 	"github.com/Ch1f/otel/api/correlation"
 	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/internal/trace/panics"
 )
 
 type messageType kv.KeyValue
@@ -56,20 +59,88 @@ func (m messageType) Event(ctx context.Context, id int, message interface{}) {
 	}
 }
 
+// PayloadEvent adds an event of the messageType to the span associated
+// with the passed context with id, the uncompressed size and the
+// compressed size of the message as sent over the wire.
+func (m messageType) PayloadEvent(ctx context.Context, id, uncompressedSize, compressedSize int) {
+	span := trace.SpanFromContext(ctx)
+	span.AddEvent(ctx, "message",
+		kv.KeyValue(m),
+		standard.RPCMessageIDKey.Int(id),
+		standard.RPCMessageUncompressedSizeKey.Int(uncompressedSize),
+		standard.RPCMessageCompressedSizeKey.Int(compressedSize),
+	)
+}
+
 var (
 	messageSent     = messageType(standard.RPCMessageTypeSent)
 	messageReceived = messageType(standard.RPCMessageTypeReceived)
 )
 
+// messageEvents adds the message events of a single RPC to its span
+// according to the message event options of the interceptor.
+type messageEvents struct {
+	// recorded needs to be aligned for 64-bit atomic operations.
+	recorded int64
+
+	cfg *config
+}
+
+func newMessageEvents(c *config) *messageEvents {
+	return &messageEvents{cfg: c}
+}
+
+// sent adds an event for the sent message with id, if it is sampled.
+func (e *messageEvents) sent(ctx context.Context, id int, message interface{}) {
+	if e.sampleSent(id) {
+		messageSent.Event(ctx, id, message)
+	}
+}
+
+// received adds an event for the received message with id, if it is
+// sampled.
+func (e *messageEvents) received(ctx context.Context, id int, message interface{}) {
+	if e.sampleReceived(id) {
+		messageReceived.Event(ctx, id, message)
+	}
+}
+
+// sampleSent returns true if the event of the sent message with id is to
+// be recorded.
+func (e *messageEvents) sampleSent(id int) bool {
+	return e.cfg.sentEvent && e.sample(id)
+}
+
+// sampleReceived returns true if the event of the received message with
+// id is to be recorded.
+func (e *messageEvents) sampleReceived(id int) bool {
+	return e.cfg.receivedEvent && e.sample(id)
+}
+
+// sample returns true if the event of the message with id is to be
+// recorded.
+func (e *messageEvents) sample(id int) bool {
+	if n := e.cfg.eventSampling; n > 1 && (id-1)%n != 0 {
+		return false
+	}
+	if max := e.cfg.maxEvents; max > 0 && atomic.AddInt64(&e.recorded, 1) > int64(max) {
+		return false
+	}
+	return true
+}
+
 // UnaryClientInterceptor returns a grpc.UnaryClientInterceptor suitable
-// for use in a grpc.Dial call.
+// for use in a grpc.Dial call. RPC metrics are recorded using the meter
+// configured with WithMeter, or the global meter by default.
 //
 // For example:
 //     tracer := global.Tracer("client-tracer")
 //     s := grpc.NewServer(
 //         grpc.WithUnaryInterceptor(grpctrace.UnaryClientInterceptor(tracer)),
 //         ...,  // (existing DialOptions))
-func UnaryClientInterceptor(tracer trace.Tracer) grpc.UnaryClientInterceptor {
+func UnaryClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryClientInterceptor {
+	c := newInterceptorConfig(opts)
+	metrics := newClientMetrics(c.meter)
 	return func(
 		ctx context.Context,
 		method string,
@@ -78,10 +149,14 @@ func UnaryClientInterceptor(tracer trace.Tracer) grpc.UnaryClientInterceptor {
 		invoker grpc.UnaryInvoker,
 		opts ...grpc.CallOption,
 	) error {
+		if !c.traced(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
 		metadataCopy := requestMetadata.Copy()
 
-		name, attr := spanInfo(method, cc.Target())
+		name, attr := c.spanInfo(method, cc.Target())
 		var span trace.Span
 		ctx, span = tracer.Start(
 			ctx,
@@ -91,19 +166,26 @@ func UnaryClientInterceptor(tracer trace.Tracer) grpc.UnaryClientInterceptor {
 		)
 		defer span.End()
 
-		Inject(ctx, &metadataCopy)
+		Inject(ctx, &metadataCopy, WithPropagators(c.propagators))
 		ctx = metadata.NewOutgoingContext(ctx, metadataCopy)
 
-		messageSent.Event(ctx, 1, req)
+		call := metrics.startCall(ctx, method, false)
+		events := newMessageEvents(c)
+
+		events.sent(ctx, 1, req)
+		call.request(ctx, req)
 
 		err := invoker(ctx, method, req, reply, cc, opts...)
 
-		messageReceived.Event(ctx, 1, reply)
+		events.received(ctx, 1, reply)
 
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
+		} else {
+			call.response(ctx, reply)
 		}
+		call.end(ctx, err)
 
 		return err
 	}
@@ -128,6 +210,8 @@ type clientStream struct {
 	grpc.ClientStream
 
 	desc       *grpc.StreamDesc
+	call       *rpcCall
+	msgEvents  *messageEvents
 	events     chan streamEvent
 	eventsDone chan struct{}
 	finished   chan error
@@ -141,6 +225,10 @@ var _ = proto.Marshal
 func (w *clientStream) RecvMsg(m interface{}) error {
 	err := w.ClientStream.RecvMsg(m)
 
+	if err == nil {
+		w.call.response(w.Context(), m)
+	}
+
 	if err == nil && !w.desc.ServerStreams {
 		w.sendStreamEvent(receiveEndEvent, nil)
 	} else if err == io.EOF {
@@ -149,7 +237,7 @@ func (w *clientStream) RecvMsg(m interface{}) error {
 		w.sendStreamEvent(errorEvent, err)
 	} else {
 		w.receivedMessageID++
-		messageReceived.Event(w.Context(), w.receivedMessageID, m)
+		w.msgEvents.received(w.Context(), w.receivedMessageID, m)
 	}
 
 	return err
@@ -159,10 +247,12 @@ func (w *clientStream) SendMsg(m interface{}) error {
 	err := w.ClientStream.SendMsg(m)
 
 	w.sentMessageID++
-	messageSent.Event(w.Context(), w.sentMessageID, m)
+	w.msgEvents.sent(w.Context(), w.sentMessageID, m)
 
 	if err != nil {
 		w.sendStreamEvent(errorEvent, err)
+	} else {
+		w.call.request(w.Context(), m)
 	}
 
 	return err
@@ -195,7 +285,7 @@ const (
 	receiveEndedState
 )
 
-func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc) *clientStream {
+func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc, call *rpcCall, msgEvents *messageEvents) *clientStream {
 	events := make(chan streamEvent)
 	eventsDone := make(chan struct{})
 	finished := make(chan error)
@@ -227,6 +317,8 @@ func wrapClientStream(s grpc.ClientStream, desc *grpc.StreamDesc) *clientStream
 	return &clientStream{
 		ClientStream: s,
 		desc:         desc,
+		call:         call,
+		msgEvents:    msgEvents,
 		events:       events,
 		eventsDone:   eventsDone,
 		finished:     finished,
@@ -241,14 +333,17 @@ func (w *clientStream) sendStreamEvent(eventType streamEventType, err error) {
 }
 
 // StreamClientInterceptor returns a grpc.StreamClientInterceptor suitable
-// for use in a grpc.Dial call.
+// for use in a grpc.Dial call. RPC metrics are recorded using the meter
+// configured with WithMeter, or the global meter by default.
 //
 // For example:
 //     tracer := global.Tracer("client-tracer")
 //     s := grpc.Dial(
 //         grpc.WithStreamInterceptor(grpctrace.StreamClientInterceptor(tracer)),
 //         ...,  // (existing DialOptions))
-func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
+func StreamClientInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamClientInterceptor {
+	c := newInterceptorConfig(opts)
+	metrics := newClientMetrics(c.meter)
 	return func(
 		ctx context.Context,
 		desc *grpc.StreamDesc,
@@ -257,10 +352,14 @@ func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
 		streamer grpc.Streamer,
 		opts ...grpc.CallOption,
 	) (grpc.ClientStream, error) {
+		if !c.traced(method) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
 		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
 		metadataCopy := requestMetadata.Copy()
 
-		name, attr := spanInfo(method, cc.Target())
+		name, attr := c.spanInfo(method, cc.Target())
 		var span trace.Span
 		ctx, span = tracer.Start(
 			ctx,
@@ -269,11 +368,13 @@ func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
 			trace.WithAttributes(attr...),
 		)
 
-		Inject(ctx, &metadataCopy)
+		Inject(ctx, &metadataCopy, WithPropagators(c.propagators))
 		ctx = metadata.NewOutgoingContext(ctx, metadataCopy)
 
+		call := metrics.startCall(ctx, method, true)
+
 		s, err := streamer(ctx, desc, cc, method, opts...)
-		stream := wrapClientStream(s, desc)
+		stream := wrapClientStream(s, desc, call, newMessageEvents(c))
 
 		go func() {
 			if err == nil {
@@ -284,6 +385,7 @@ func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
 				s, _ := status.FromError(err)
 				span.SetStatus(s.Code(), s.Message())
 			}
+			call.end(ctx, err)
 
 			span.End()
 		}()
@@ -293,29 +395,36 @@ func StreamClientInterceptor(tracer trace.Tracer) grpc.StreamClientInterceptor {
 }
 
 // UnaryServerInterceptor returns a grpc.UnaryServerInterceptor suitable
-// for use in a grpc.NewServer call.
+// for use in a grpc.NewServer call. RPC metrics are recorded using the meter
+// configured with WithMeter, or the global meter by default.
 //
 // For example:
 //     tracer := global.Tracer("client-tracer")
 //     s := grpc.Dial(
 //         grpc.UnaryInterceptor(grpctrace.UnaryServerInterceptor(tracer)),
 //         ...,  // (existing ServerOptions))
-func UnaryServerInterceptor(tracer trace.Tracer) grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.UnaryServerInterceptor {
+	c := newInterceptorConfig(opts)
+	metrics := newServerMetrics(c.meter)
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
+		if !c.traced(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
 		metadataCopy := requestMetadata.Copy()
 
-		entries, spanCtx := Extract(ctx, &metadataCopy)
+		entries, spanCtx := Extract(ctx, &metadataCopy, WithPropagators(c.propagators))
 		ctx = correlation.ContextWithMap(ctx, correlation.NewMap(correlation.MapUpdate{
 			MultiKV: entries,
 		}))
 
-		name, attr := spanInfo(info.FullMethod, peerFromCtx(ctx))
+		name, attr := c.spanInfo(info.FullMethod, peerFromCtx(ctx))
 		ctx, span := tracer.Start(
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
 			name,
@@ -324,16 +433,23 @@ func UnaryServerInterceptor(tracer trace.Tracer) grpc.UnaryServerInterceptor {
 		)
 		defer span.End()
 
-		messageReceived.Event(ctx, 1, req)
+		call := metrics.startCall(ctx, info.FullMethod, false)
+		defer c.handlePanic(ctx, span, call)
+		events := newMessageEvents(c)
+
+		events.received(ctx, 1, req)
+		call.request(ctx, req)
 
 		resp, err := handler(ctx, req)
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
-			messageSent.Event(ctx, 1, s.Proto())
+			events.sent(ctx, 1, s.Proto())
 		} else {
-			messageSent.Event(ctx, 1, resp)
+			events.sent(ctx, 1, resp)
+			call.response(ctx, resp)
 		}
+		call.end(ctx, err)
 
 		return resp, err
 	}
@@ -343,7 +459,9 @@ func UnaryServerInterceptor(tracer trace.Tracer) grpc.UnaryServerInterceptor {
 // SendMsg method call.
 type serverStream struct {
 	grpc.ServerStream
-	ctx context.Context
+	ctx       context.Context
+	call      *rpcCall
+	msgEvents *messageEvents
 
 	receivedMessageID int
 	sentMessageID     int
@@ -358,7 +476,8 @@ func (w *serverStream) RecvMsg(m interface{}) error {
 
 	if err == nil {
 		w.receivedMessageID++
-		messageReceived.Event(w.Context(), w.receivedMessageID, m)
+		w.msgEvents.received(w.Context(), w.receivedMessageID, m)
+		w.call.request(w.Context(), m)
 	}
 
 	return err
@@ -368,44 +487,57 @@ func (w *serverStream) SendMsg(m interface{}) error {
 	err := w.ServerStream.SendMsg(m)
 
 	w.sentMessageID++
-	messageSent.Event(w.Context(), w.sentMessageID, m)
+	w.msgEvents.sent(w.Context(), w.sentMessageID, m)
+
+	if err == nil {
+		w.call.response(w.Context(), m)
+	}
 
 	return err
 }
 
-func wrapServerStream(ctx context.Context, ss grpc.ServerStream) *serverStream {
+func wrapServerStream(ctx context.Context, ss grpc.ServerStream, call *rpcCall, msgEvents *messageEvents) *serverStream {
 	return &serverStream{
 		ServerStream: ss,
 		ctx:          ctx,
+		call:         call,
+		msgEvents:    msgEvents,
 	}
 }
 
 // StreamServerInterceptor returns a grpc.StreamServerInterceptor suitable
-// for use in a grpc.NewServer call.
+// for use in a grpc.NewServer call. RPC metrics are recorded using the meter
+// configured with WithMeter, or the global meter by default.
 //
 // For example:
 //     tracer := global.Tracer("client-tracer")
 //     s := grpc.Dial(
 //         grpc.StreamInterceptor(grpctrace.StreamServerInterceptor(tracer)),
 //         ...,  // (existing ServerOptions))
-func StreamServerInterceptor(tracer trace.Tracer) grpc.StreamServerInterceptor {
+func StreamServerInterceptor(tracer trace.Tracer, opts ...Option) grpc.StreamServerInterceptor {
+	c := newInterceptorConfig(opts)
+	metrics := newServerMetrics(c.meter)
 	return func(
 		srv interface{},
 		ss grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		if !c.traced(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
 		ctx := ss.Context()
 
 		requestMetadata, _ := metadata.FromIncomingContext(ctx)
 		metadataCopy := requestMetadata.Copy()
 
-		entries, spanCtx := Extract(ctx, &metadataCopy)
+		entries, spanCtx := Extract(ctx, &metadataCopy, WithPropagators(c.propagators))
 		ctx = correlation.ContextWithMap(ctx, correlation.NewMap(correlation.MapUpdate{
 			MultiKV: entries,
 		}))
 
-		name, attr := spanInfo(info.FullMethod, peerFromCtx(ctx))
+		name, attr := c.spanInfo(info.FullMethod, peerFromCtx(ctx))
 		ctx, span := tracer.Start(
 			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
 			name,
@@ -414,17 +546,50 @@ func StreamServerInterceptor(tracer trace.Tracer) grpc.StreamServerInterceptor {
 		)
 		defer span.End()
 
-		err := handler(srv, wrapServerStream(ctx, ss))
+		call := metrics.startCall(ctx, info.FullMethod, true)
+		defer c.handlePanic(ctx, span, call)
+
+		err := handler(srv, wrapServerStream(ctx, ss, call, newMessageEvents(c)))
 
 		if err != nil {
 			s, _ := status.FromError(err)
 			span.SetStatus(s.Code(), s.Message())
 		}
+		call.end(ctx, err)
 
 		return err
 	}
 }
 
+// errPanic is the error a call whose server handler panicked ends with.
+var errPanic = status.Error(codes.Internal, "panic")
+
+// handlePanic ends call with an Internal status if its server handler
+// panicked, records the panic on span if panic recording is enabled,
+// and propagates it. It must be deferred.
+func (c *config) handlePanic(ctx context.Context, span trace.Span, call *rpcCall) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	call.end(ctx, errPanic)
+	if c.recordPanics {
+		panics.Record(ctx, span, r)
+	}
+	panic(r)
+}
+
+// spanInfo returns a span name and all appropriate attributes from the gRPC
+// method and peer address, naming the span with the configured span name
+// formatter if any.
+func (c *config) spanInfo(fullMethod, peerAddress string) (string, []kv.KeyValue) {
+	name, attrs := spanInfo(fullMethod, peerAddress)
+	if c.spanNameFormatter != nil {
+		name = c.spanNameFormatter(fullMethod)
+	}
+	return name, attrs
+}
+
 // spanInfo returns a span name and all appropriate attributes from the gRPC
 // method and peer address.
 func spanInfo(fullMethod, peerAddress string) (string, []kv.KeyValue) {
@@ -15,6 +15,7 @@ package grpctrace
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -31,8 +32,12 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/kv/value"
+	"github.com/Ch1f/otel/api/propagation"
+	"github.com/Ch1f/otel/api/trace"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
 	export "github.com/Ch1f/otel/sdk/export/trace"
 	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
@@ -496,3 +501,193 @@ func TestParseFullMethod(t *testing.T) {
 		assert.Equal(t, test.attr, a)
 	}
 }
+
+func newTestProvider(t *testing.T) (*testExporter, *sdktrace.Provider) {
+	exp := &testExporter{spanMap: make(map[string]*export.SpanData)}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithConfig(sdktrace.Config{
+			DefaultSampler: sdktrace.AlwaysSample(),
+		}),
+	)
+	require.NoError(t, err)
+	return exp, tp
+}
+
+func TestInterceptorFilter(t *testing.T) {
+	exp, tp := newTestProvider(t)
+	impl, meter := mockmeter.NewMeter()
+
+	usi := UnaryServerInterceptor(tp.Tracer("grpctrace/Server"),
+		WithMeter(meter),
+		WithFilter(func(fullMethod string) bool {
+			return !strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/")
+		}),
+	)
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return &mockProtoMessage{}, nil
+	}
+
+	for _, method := range []string{"/grpc.health.v1.Health/Check", "/github.com.serviceName/bar"} {
+		_, err := usi(context.Background(), &mockProtoMessage{}, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, exp.spanMap, 1)
+	assert.Contains(t, exp.spanMap, "github.com.serviceName/bar")
+	for _, batch := range impl.MeasurementBatches {
+		assert.NotContains(t, batch.Labels, standard.RPCServiceKey.String("grpc.health.v1.Health"))
+	}
+}
+
+func TestSpanNameFormatter(t *testing.T) {
+	exp, tp := newTestProvider(t)
+	clientConn, err := grpc.Dial("fake:connection", grpc.WithInsecure())
+	require.NoError(t, err)
+
+	uci := UnaryClientInterceptor(tp.Tracer("grpctrace/client"),
+		WithSpanNameFormatter(func(fullMethod string) string {
+			return "rpc " + fullMethod
+		}),
+	)
+	invoker := &mockUICInvoker{}
+	err = uci(context.Background(), "/github.com.serviceName/bar",
+		&mockProtoMessage{}, &mockProtoMessage{}, clientConn, invoker.invoker)
+	require.NoError(t, err)
+
+	assert.Contains(t, exp.spanMap, "rpc /github.com.serviceName/bar")
+}
+
+func TestInterceptorGlobalPropagators(t *testing.T) {
+	_, tp := newTestProvider(t)
+	clientConn, err := grpc.Dial("fake:connection", grpc.WithInsecure())
+	require.NoError(t, err)
+
+	// The interceptor is built before the global propagators are set.
+	uci := UnaryClientInterceptor(tp.Tracer("grpctrace/client"))
+
+	defer global.SetPropagators(global.Propagators())
+	b3 := trace.B3{InjectEncoding: trace.B3SingleHeader}
+	global.SetPropagators(propagation.New(propagation.WithInjectors(b3)))
+
+	invoker := &mockUICInvoker{}
+	err = uci(context.Background(), "/github.com.serviceName/bar",
+		&mockProtoMessage{}, &mockProtoMessage{}, clientConn, invoker.invoker)
+	require.NoError(t, err)
+
+	md, ok := metadata.FromOutgoingContext(invoker.ctx)
+	require.True(t, ok)
+	assert.NotEmpty(t, md.Get("b3"))
+}
+
+func TestMessageEventOptions(t *testing.T) {
+	messageIDs := func(events []export.Event, typ kv.KeyValue) []int {
+		var ids []int
+		for _, e := range events {
+			var id int
+			var matches bool
+			for _, attr := range e.Attributes {
+				switch attr.Key {
+				case standard.RPCMessageIDKey:
+					id = int(attr.Value.AsInt32())
+				case standard.RPCMessageTypeKey:
+					matches = attr.Value == typ.Value
+				}
+			}
+			if matches {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		sent     []int
+		received []int
+	}{
+		{
+			name:     "default",
+			sent:     []int{1, 2, 3, 4, 5},
+			received: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name: "disabled",
+			opts: []Option{WithMessageEvents()},
+		},
+		{
+			name:     "received only",
+			opts:     []Option{WithMessageEvents(ReceivedEvents)},
+			received: []int{1, 2, 3, 4, 5},
+		},
+		{
+			name:     "sampled",
+			opts:     []Option{WithMessageEventSampling(2)},
+			sent:     []int{1, 3, 5},
+			received: []int{1, 3, 5},
+		},
+		{
+			name:     "capped",
+			opts:     []Option{WithMaxMessageEvents(3)},
+			sent:     []int{1},
+			received: []int{1, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exp, tp := newTestProvider(t)
+			ssi := StreamServerInterceptor(tp.Tracer("grpctrace/Server"), test.opts...)
+			err := ssi(nil, mockServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{FullMethod: "/github.com.serviceName/bar"},
+				func(srv interface{}, stream grpc.ServerStream) error {
+					for i := 0; i < 5; i++ {
+						if err := stream.RecvMsg(&mockProtoMessage{}); err != nil {
+							return err
+						}
+						if err := stream.SendMsg(&mockProtoMessage{}); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			require.NoError(t, err)
+
+			span, ok := exp.spanMap["github.com.serviceName/bar"]
+			require.True(t, ok)
+			assert.Equal(t, test.sent, messageIDs(span.MessageEvents, standard.RPCMessageTypeSent))
+			assert.Equal(t, test.received, messageIDs(span.MessageEvents, standard.RPCMessageTypeReceived))
+		})
+	}
+}
+
+func TestServerInterceptorPanicRecording(t *testing.T) {
+	exp, tp := newTestProvider(t)
+	tracer := tp.Tracer("grpctrace/Server")
+
+	usi := UnaryServerInterceptor(tracer, WithPanicRecording())
+	assert.PanicsWithValue(t, "boom", func() {
+		_, _ = usi(context.Background(), &mockProtoMessage{},
+			&grpc.UnaryServerInfo{FullMethod: "/github.com.serviceName/unary"},
+			func(context.Context, interface{}) (interface{}, error) { panic("boom") })
+	})
+
+	ssi := StreamServerInterceptor(tracer, WithPanicRecording())
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = ssi(nil, mockServerStream{ctx: context.Background()},
+			&grpc.StreamServerInfo{FullMethod: "/github.com.serviceName/stream"},
+			func(interface{}, grpc.ServerStream) error { panic("boom") })
+	})
+
+	for _, name := range []string{"github.com.serviceName/unary", "github.com.serviceName/stream"} {
+		span, ok := exp.spanMap[name]
+		require.True(t, ok, "missing span %s", name)
+		assert.Equal(t, codes.Internal, span.StatusCode)
+		assert.Equal(t, "panic: boom", span.StatusMessage)
+		require.NotEmpty(t, span.MessageEvents)
+		event := span.MessageEvents[len(span.MessageEvents)-1]
+		assert.Equal(t, "panic", event.Name)
+		assert.Contains(t, event.Attributes, standard.ExceptionMessageKey.String("boom"))
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpctrace
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/api/unit"
+)
+
+// Client RPC metrics
+const (
+	ClientDuration        = "rpc.client.duration"          // Outgoing RPC duration, milliseconds
+	ClientRequestSize     = "rpc.client.request.size"      // Outgoing request message size, bytes
+	ClientResponseSize    = "rpc.client.response.size"     // Incoming response message size, bytes
+	ClientRequestsPerRPC  = "rpc.client.requests_per_rpc"  // Number of request messages per RPC
+	ClientResponsesPerRPC = "rpc.client.responses_per_rpc" // Number of response messages per RPC
+	ClientActiveStreams   = "rpc.client.active_streams"    // Number of in-flight client streams
+)
+
+// Server RPC metrics
+const (
+	ServerDuration        = "rpc.server.duration"          // Incoming RPC duration, milliseconds
+	ServerRequestSize     = "rpc.server.request.size"      // Incoming request message size, bytes
+	ServerResponseSize    = "rpc.server.response.size"     // Outgoing response message size, bytes
+	ServerRequestsPerRPC  = "rpc.server.requests_per_rpc"  // Number of request messages per RPC
+	ServerResponsesPerRPC = "rpc.server.responses_per_rpc" // Number of response messages per RPC
+	ServerActiveStreams   = "rpc.server.active_streams"    // Number of in-flight server streams
+)
+
+// rpcMetrics holds the instruments recording one side, client or
+// server, of RPCs.
+type rpcMetrics struct {
+	duration        metric.Float64ValueRecorder
+	requestSize     metric.Int64ValueRecorder
+	responseSize    metric.Int64ValueRecorder
+	requestsPerRPC  metric.Int64ValueRecorder
+	responsesPerRPC metric.Int64ValueRecorder
+	activeStreams   metric.Int64UpDownCounter
+}
+
+func handleErr(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+func newClientMetrics(meter metric.Meter) *rpcMetrics {
+	return newRPCMetrics(meter,
+		ClientDuration,
+		ClientRequestSize,
+		ClientResponseSize,
+		ClientRequestsPerRPC,
+		ClientResponsesPerRPC,
+		ClientActiveStreams,
+	)
+}
+
+func newServerMetrics(meter metric.Meter) *rpcMetrics {
+	return newRPCMetrics(meter,
+		ServerDuration,
+		ServerRequestSize,
+		ServerResponseSize,
+		ServerRequestsPerRPC,
+		ServerResponsesPerRPC,
+		ServerActiveStreams,
+	)
+}
+
+func newRPCMetrics(meter metric.Meter, duration, requestSize, responseSize, requestsPerRPC, responsesPerRPC, activeStreams string) *rpcMetrics {
+	m := &rpcMetrics{}
+	var err error
+
+	m.duration, err = meter.NewFloat64ValueRecorder(duration,
+		metric.WithUnit(unit.Milliseconds))
+	handleErr(err)
+
+	m.requestSize, err = meter.NewInt64ValueRecorder(requestSize,
+		metric.WithUnit(unit.Bytes))
+	handleErr(err)
+
+	m.responseSize, err = meter.NewInt64ValueRecorder(responseSize,
+		metric.WithUnit(unit.Bytes))
+	handleErr(err)
+
+	m.requestsPerRPC, err = meter.NewInt64ValueRecorder(requestsPerRPC,
+		metric.WithUnit(unit.Dimensionless))
+	handleErr(err)
+
+	m.responsesPerRPC, err = meter.NewInt64ValueRecorder(responsesPerRPC,
+		metric.WithUnit(unit.Dimensionless))
+	handleErr(err)
+
+	m.activeStreams, err = meter.NewInt64UpDownCounter(activeStreams,
+		metric.WithUnit(unit.Dimensionless))
+	handleErr(err)
+
+	return m
+}
+
+// rpcCall records the metrics of a single RPC from its start until
+// end is called.
+type rpcCall struct {
+	// requests and responses need to be aligned for 64-bit atomic
+	// operations.
+	requests  int64
+	responses int64
+
+	metrics *rpcMetrics
+	start   time.Time
+	labels  []kv.KeyValue
+	stream  bool
+}
+
+// startCall starts recording the RPC to fullMethod. Streaming RPCs are
+// counted as in-flight until end is called.
+func (m *rpcMetrics) startCall(ctx context.Context, fullMethod string, stream bool) *rpcCall {
+	_, mAttrs := parseFullMethod(fullMethod)
+	c := &rpcCall{
+		metrics: m,
+		start:   time.Now(),
+		labels:  append([]kv.KeyValue{standard.RPCSystemGRPC}, mAttrs...),
+		stream:  stream,
+	}
+	if stream {
+		m.activeStreams.Add(ctx, 1, c.labels...)
+	}
+	return c
+}
+
+// request records a request message of the call.
+func (c *rpcCall) request(ctx context.Context, message interface{}) {
+	atomic.AddInt64(&c.requests, 1)
+	if p, ok := message.(proto.Message); ok {
+		c.metrics.requestSize.Record(ctx, int64(proto.Size(p)), c.labels...)
+	}
+}
+
+// response records a response message of the call.
+func (c *rpcCall) response(ctx context.Context, message interface{}) {
+	atomic.AddInt64(&c.responses, 1)
+	if p, ok := message.(proto.Message); ok {
+		c.metrics.responseSize.Record(ctx, int64(proto.Size(p)), c.labels...)
+	}
+}
+
+// requestBytes records a request message of the call of n bytes.
+func (c *rpcCall) requestBytes(ctx context.Context, n int) {
+	atomic.AddInt64(&c.requests, 1)
+	c.metrics.requestSize.Record(ctx, int64(n), c.labels...)
+}
+
+// responseBytes records a response message of the call of n bytes.
+func (c *rpcCall) responseBytes(ctx context.Context, n int) {
+	atomic.AddInt64(&c.responses, 1)
+	c.metrics.responseSize.Record(ctx, int64(n), c.labels...)
+}
+
+// end records the duration and message counts of the call labeled with
+// the status code resulting from err.
+func (c *rpcCall) end(ctx context.Context, err error) {
+	elapsed := float64(time.Since(c.start)) / float64(time.Millisecond)
+
+	code := codes.OK
+	if err != nil {
+		code = status.Code(err)
+	}
+	labels := append(c.labels[:len(c.labels):len(c.labels)],
+		standard.RPCGRPCStatusCodeKey.Int(int(code)))
+
+	c.metrics.duration.Record(ctx, elapsed, labels...)
+	c.metrics.requestsPerRPC.Record(ctx, atomic.LoadInt64(&c.requests), labels...)
+	c.metrics.responsesPerRPC.Record(ctx, atomic.LoadInt64(&c.responses), labels...)
+	if c.stream {
+		c.metrics.activeStreams.Add(ctx, -1, c.labels...)
+	}
+}
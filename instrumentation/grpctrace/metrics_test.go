@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpctrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/standard"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+	mocktrace "github.com/Ch1f/otel/internal/trace"
+)
+
+type measurement struct {
+	number metric.Number
+	labels []kv.KeyValue
+}
+
+// measurementsByName groups the recorded measurements by instrument name.
+func measurementsByName(impl *mockmeter.MeterImpl) map[string][]measurement {
+	out := map[string][]measurement{}
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			out[name] = append(out[name], measurement{number: m.Number, labels: batch.Labels})
+		}
+	}
+	return out
+}
+
+func newMockTracer() *mocktrace.MockTracer {
+	var id uint64
+	return &mocktrace.MockTracer{StartSpanID: &id}
+}
+
+var methodLabels = []kv.KeyValue{
+	standard.RPCSystemGRPC,
+	standard.RPCServiceKey.String("github.com.serviceName"),
+	standard.RPCMethodKey.String("bar"),
+}
+
+func withStatus(code codes.Code) []kv.KeyValue {
+	return append(methodLabels[:len(methodLabels):len(methodLabels)],
+		standard.RPCGRPCStatusCodeKey.Int(int(code)))
+}
+
+func TestUnaryClientInterceptorMetrics(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	clientConn, err := grpc.Dial("fake:connection", grpc.WithInsecure())
+	require.NoError(t, err)
+
+	interceptor := UnaryClientInterceptor(newMockTracer(), WithMeter(meter))
+	invoker := &mockUICInvoker{}
+	err = interceptor(context.Background(), "/github.com.serviceName/bar",
+		&mockProtoMessage{}, &mockProtoMessage{}, clientConn, invoker.invoker)
+	require.NoError(t, err)
+
+	got := measurementsByName(impl)
+	require.Len(t, got[ClientDuration], 1)
+	assert.ElementsMatch(t, withStatus(codes.OK), got[ClientDuration][0].labels)
+	assert.True(t, got[ClientDuration][0].number.AsFloat64() >= 0)
+
+	require.Len(t, got[ClientRequestsPerRPC], 1)
+	assert.Equal(t, int64(1), got[ClientRequestsPerRPC][0].number.AsInt64())
+	require.Len(t, got[ClientResponsesPerRPC], 1)
+	assert.Equal(t, int64(1), got[ClientResponsesPerRPC][0].number.AsInt64())
+
+	require.Len(t, got[ClientRequestSize], 1)
+	assert.ElementsMatch(t, methodLabels, got[ClientRequestSize][0].labels)
+	require.Len(t, got[ClientResponseSize], 1)
+
+	assert.Empty(t, got[ClientActiveStreams], "unary calls are not streams")
+}
+
+func TestUnaryServerInterceptorMetricsError(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+
+	interceptor := UnaryServerInterceptor(newMockTracer(), WithMeter(meter))
+	deniedErr := status.Error(codes.PermissionDenied, "denied")
+	_, err := interceptor(context.Background(), &mockProtoMessage{},
+		&grpc.UnaryServerInfo{FullMethod: "/github.com.serviceName/bar"},
+		func(context.Context, interface{}) (interface{}, error) {
+			return nil, deniedErr
+		})
+	require.Equal(t, deniedErr, err)
+
+	got := measurementsByName(impl)
+	require.Len(t, got[ServerDuration], 1)
+	assert.ElementsMatch(t, withStatus(codes.PermissionDenied), got[ServerDuration][0].labels)
+	require.Len(t, got[ServerRequestsPerRPC], 1)
+	assert.Equal(t, int64(1), got[ServerRequestsPerRPC][0].number.AsInt64())
+	require.Len(t, got[ServerResponsesPerRPC], 1)
+	assert.Equal(t, int64(0), got[ServerResponsesPerRPC][0].number.AsInt64())
+	assert.Empty(t, got[ServerResponseSize])
+}
+
+type mockServerStream struct {
+	ctx context.Context
+}
+
+func (mockServerStream) SetHeader(metadata.MD) error  { return nil }
+func (mockServerStream) SendHeader(metadata.MD) error { return nil }
+func (mockServerStream) SetTrailer(metadata.MD)       {}
+func (s mockServerStream) Context() context.Context   { return s.ctx }
+func (mockServerStream) SendMsg(m interface{}) error  { return nil }
+func (mockServerStream) RecvMsg(m interface{}) error  { return nil }
+
+func TestStreamServerInterceptorMetrics(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+
+	interceptor := StreamServerInterceptor(newMockTracer(), WithMeter(meter))
+	err := interceptor(nil, mockServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: "/github.com.serviceName/bar"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			got := measurementsByName(impl)
+			require.Len(t, got[ServerActiveStreams], 1)
+			assert.Equal(t, int64(1), got[ServerActiveStreams][0].number.AsInt64())
+
+			for i := 0; i < 3; i++ {
+				if err := stream.RecvMsg(&mockProtoMessage{}); err != nil {
+					return err
+				}
+			}
+			return stream.SendMsg(&mockProtoMessage{})
+		})
+	require.NoError(t, err)
+
+	got := measurementsByName(impl)
+	require.Len(t, got[ServerActiveStreams], 2)
+	assert.Equal(t, int64(-1), got[ServerActiveStreams][1].number.AsInt64())
+	assert.ElementsMatch(t, methodLabels, got[ServerActiveStreams][1].labels)
+
+	require.Len(t, got[ServerRequestsPerRPC], 1)
+	assert.Equal(t, int64(3), got[ServerRequestsPerRPC][0].number.AsInt64())
+	require.Len(t, got[ServerResponsesPerRPC], 1)
+	assert.Equal(t, int64(1), got[ServerResponsesPerRPC][0].number.AsInt64())
+	assert.Len(t, got[ServerRequestSize], 3)
+	assert.Len(t, got[ServerResponseSize], 1)
+	require.Len(t, got[ServerDuration], 1)
+	assert.ElementsMatch(t, withStatus(codes.OK), got[ServerDuration][0].labels)
+}
+
+func TestServerInterceptorMetricsPanic(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+
+	usi := UnaryServerInterceptor(newMockTracer(), WithMeter(meter), WithPanicRecording())
+	assert.PanicsWithValue(t, "boom", func() {
+		_, _ = usi(context.Background(), &mockProtoMessage{},
+			&grpc.UnaryServerInfo{FullMethod: "/github.com.serviceName/bar"},
+			func(context.Context, interface{}) (interface{}, error) { panic("boom") })
+	})
+
+	ssi := StreamServerInterceptor(newMockTracer(), WithMeter(meter), WithPanicRecording())
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = ssi(nil, mockServerStream{ctx: context.Background()},
+			&grpc.StreamServerInfo{FullMethod: "/github.com.serviceName/bar"},
+			func(interface{}, grpc.ServerStream) error { panic("boom") })
+	})
+
+	got := measurementsByName(impl)
+	require.Len(t, got[ServerDuration], 2)
+	for _, m := range got[ServerDuration] {
+		assert.ElementsMatch(t, withStatus(codes.Internal), m.labels)
+	}
+	var active int64
+	for _, m := range got[ServerActiveStreams] {
+		active += m.number.AsInt64()
+	}
+	assert.Len(t, got[ServerActiveStreams], 2)
+	assert.Equal(t, int64(0), active, "active streams")
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpctrace
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"github.com/Ch1f/otel/api/correlation"
+	"github.com/Ch1f/otel/api/trace"
+)
+
+// rpcStateKey is the context key of the rpcState of an RPC.
+type rpcStateKey struct{}
+
+// rpcState is the instrumentation state of a single RPC, stored in the
+// context returned by TagRPC.
+type rpcState struct {
+	// sentMessageID and receivedMessageID need to be aligned for
+	// 64-bit atomic operations.
+	sentMessageID     int64
+	receivedMessageID int64
+
+	span   trace.Span
+	call   *rpcCall
+	events *messageEvents
+}
+
+// statsHandler implements stats.Handler, creating spans and recording
+// metrics from the RPC events of the gRPC transport.
+type statsHandler struct {
+	tracer  trace.Tracer
+	cfg     *config
+	metrics *rpcMetrics
+	client  bool
+}
+
+var _ stats.Handler = (*statsHandler)(nil)
+
+// NewClientHandler returns a stats.Handler suitable for use in a
+// grpc.Dial call. Compared to the client interceptors, the handler sees
+// the messages as sent over the wire, recording their compressed sizes.
+// gRPC calls the handler for each attempt of an RPC, after the client
+// interceptors have run, so a retried RPC is recorded once per attempt.
+//
+// The stats reported to the handler do not tell streaming RPCs from
+// unary ones: unlike StreamClientInterceptor, the handler does not
+// report the ClientActiveStreams metric.
+//
+// For example:
+//
+//	tracer := global.Tracer("client-tracer")
+//	s := grpc.Dial(
+//	    grpc.WithStatsHandler(grpctrace.NewClientHandler(tracer)),
+//	    ...,  // (existing DialOptions))
+func NewClientHandler(tracer trace.Tracer, opts ...Option) stats.Handler {
+	c := newInterceptorConfig(opts)
+	return &statsHandler{
+		tracer:  tracer,
+		cfg:     c,
+		metrics: newClientMetrics(c.meter),
+		client:  true,
+	}
+}
+
+// NewServerHandler returns a stats.Handler suitable for use in a
+// grpc.NewServer call. Compared to the server interceptors, the handler
+// sees the messages as received over the wire, recording their
+// compressed sizes, and also measures the time spent before the handler
+// runs.
+//
+// The stats reported to the handler do not tell streaming RPCs from
+// unary ones: unlike StreamServerInterceptor, the handler does not
+// report the ServerActiveStreams metric.
+//
+// For example:
+//
+//	tracer := global.Tracer("server-tracer")
+//	s := grpc.NewServer(
+//	    grpc.StatsHandler(grpctrace.NewServerHandler(tracer)),
+//	    ...,  // (existing ServerOptions))
+func NewServerHandler(tracer trace.Tracer, opts ...Option) stats.Handler {
+	c := newInterceptorConfig(opts)
+	return &statsHandler{
+		tracer:  tracer,
+		cfg:     c,
+		metrics: newServerMetrics(c.meter),
+	}
+}
+
+// TagRPC starts the span of the RPC and, for clients, injects its
+// context into the outgoing metadata.
+func (h *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if !h.cfg.traced(info.FullMethodName) {
+		return ctx
+	}
+
+	var span trace.Span
+	if h.client {
+		name, attr := h.cfg.spanInfo(info.FullMethodName, "")
+		ctx, span = h.tracer.Start(
+			ctx,
+			name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attr...),
+		)
+
+		requestMetadata, _ := metadata.FromOutgoingContext(ctx)
+		metadataCopy := requestMetadata.Copy()
+		Inject(ctx, &metadataCopy, WithPropagators(h.cfg.propagators))
+		ctx = metadata.NewOutgoingContext(ctx, metadataCopy)
+	} else {
+		requestMetadata, _ := metadata.FromIncomingContext(ctx)
+		metadataCopy := requestMetadata.Copy()
+
+		entries, spanCtx := Extract(ctx, &metadataCopy, WithPropagators(h.cfg.propagators))
+		ctx = correlation.ContextWithMap(ctx, correlation.NewMap(correlation.MapUpdate{
+			MultiKV: entries,
+		}))
+
+		name, attr := h.cfg.spanInfo(info.FullMethodName, peerFromCtx(ctx))
+		ctx, span = h.tracer.Start(
+			trace.ContextWithRemoteSpanContext(ctx, spanCtx),
+			name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attr...),
+		)
+	}
+
+	return context.WithValue(ctx, rpcStateKey{}, &rpcState{
+		span:   span,
+		call:   h.metrics.startCall(ctx, info.FullMethodName, false),
+		events: newMessageEvents(h.cfg),
+	})
+}
+
+// HandleRPC records the RPC stats on the span and metrics of the RPC.
+func (h *statsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	state, ok := ctx.Value(rpcStateKey{}).(*rpcState)
+	if !ok {
+		return
+	}
+
+	switch rs := rs.(type) {
+	case *stats.OutHeader:
+		if h.client && rs.RemoteAddr != nil {
+			state.span.SetAttributes(peerAttr(rs.RemoteAddr.String())...)
+		}
+	case *stats.InPayload:
+		id := int(atomic.AddInt64(&state.receivedMessageID, 1))
+		if state.events.sampleReceived(id) {
+			messageReceived.PayloadEvent(ctx, id, rs.Length, rs.WireLength)
+		}
+		if h.client {
+			state.call.responseBytes(ctx, rs.WireLength)
+		} else {
+			state.call.requestBytes(ctx, rs.WireLength)
+		}
+	case *stats.OutPayload:
+		id := int(atomic.AddInt64(&state.sentMessageID, 1))
+		if state.events.sampleSent(id) {
+			messageSent.PayloadEvent(ctx, id, rs.Length, rs.WireLength)
+		}
+		if h.client {
+			state.call.requestBytes(ctx, rs.WireLength)
+		} else {
+			state.call.responseBytes(ctx, rs.WireLength)
+		}
+	case *stats.End:
+		if rs.Error != nil {
+			s, _ := status.FromError(rs.Error)
+			state.span.SetStatus(s.Code(), s.Message())
+		}
+		state.call.end(ctx, rs.Error)
+		state.span.End(trace.WithEndTime(rs.EndTime))
+	}
+}
+
+// TagConn returns ctx unchanged, connections are not instrumented.
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn does nothing, connections are not instrumented.
+func (h *statsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpctrace
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Ch1f/otel/api/standard"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+// dialBufconn starts a health server with the server stats handler on an
+// in-memory listener and returns a client connection to it using the
+// client stats handler.
+func dialBufconn(t *testing.T, client grpc.DialOption, serverOpt grpc.ServerOption) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(serverOpt)
+	hs := health.NewServer()
+	hs.SetServingStatus(testService, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, hs)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithInsecure(),
+		client,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+const (
+	testService         = "grpctrace.test"
+	healthCheckSpanName = "grpc.health.v1.Health/Check"
+)
+
+// waitForSpan returns the span named name once exported. Server spans
+// end after the response has been sent to the client.
+func waitForSpan(t *testing.T, exp *testExporter, name string) *export.SpanData {
+	var span *export.SpanData
+	require.Eventually(t, func() bool {
+		exp.mu.Lock()
+		defer exp.mu.Unlock()
+		span = exp.spanMap[name]
+		return span != nil
+	}, time.Second, 10*time.Millisecond, "missing span %q", name)
+	return span
+}
+
+func TestStatsHandler(t *testing.T) {
+	clientExp, clientTP := newTestProvider(t)
+	serverExp, serverTP := newTestProvider(t)
+	clientMeter, meter := mockmeter.NewMeter()
+
+	conn := dialBufconn(t,
+		grpc.WithStatsHandler(NewClientHandler(clientTP.Tracer("grpctrace/client"), WithMeter(meter))),
+		grpc.StatsHandler(NewServerHandler(serverTP.Tracer("grpctrace/server"))),
+	)
+
+	req := &healthpb.HealthCheckRequest{Service: testService}
+	_, err := healthpb.NewHealthClient(conn).Check(context.Background(), req)
+	require.NoError(t, err)
+
+	serverSpan := waitForSpan(t, serverExp, healthCheckSpanName)
+	clientSpan := waitForSpan(t, clientExp, healthCheckSpanName)
+
+	assert.Equal(t, clientSpan.SpanContext.TraceID, serverSpan.SpanContext.TraceID)
+	assert.Equal(t, clientSpan.SpanContext.SpanID, serverSpan.ParentSpanID)
+	assert.Equal(t, codes.OK, clientSpan.StatusCode)
+	assert.Contains(t, clientSpan.Attributes, standard.RPCServiceKey.String("grpc.health.v1.Health"))
+
+	require.Len(t, serverSpan.MessageEvents, 2)
+	received := serverSpan.MessageEvents[0].Attributes
+	assert.Contains(t, received, standard.RPCMessageTypeReceived)
+	assert.Contains(t, received, standard.RPCMessageIDKey.Int(1))
+	assert.Contains(t, received, standard.RPCMessageUncompressedSizeKey.Int(proto.Size(req)))
+	assert.Contains(t, received, standard.RPCMessageCompressedSizeKey.Int(proto.Size(req)))
+	assert.Contains(t, serverSpan.MessageEvents[1].Attributes, standard.RPCMessageTypeSent)
+
+	got := measurementsByName(clientMeter)
+	require.Len(t, got[ClientDuration], 1)
+	assert.Contains(t, got[ClientDuration][0].labels, standard.RPCGRPCStatusCodeKey.Int(int(codes.OK)))
+	require.Len(t, got[ClientRequestSize], 1)
+	// The sent wire size includes the 5 byte gRPC message header.
+	assert.Equal(t, int64(proto.Size(req)+5), got[ClientRequestSize][0].number.AsInt64())
+	require.Len(t, got[ClientResponsesPerRPC], 1)
+	assert.Equal(t, int64(1), got[ClientResponsesPerRPC][0].number.AsInt64())
+}
+
+func TestStatsHandlerError(t *testing.T) {
+	clientExp, clientTP := newTestProvider(t)
+	serverExp, serverTP := newTestProvider(t)
+
+	conn := dialBufconn(t,
+		grpc.WithStatsHandler(NewClientHandler(clientTP.Tracer("grpctrace/client"))),
+		grpc.StatsHandler(NewServerHandler(serverTP.Tracer("grpctrace/server"))),
+	)
+
+	_, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: "unknown"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	clientSpan := waitForSpan(t, clientExp, healthCheckSpanName)
+	assert.Equal(t, codes.NotFound, clientSpan.StatusCode)
+
+	serverSpan := waitForSpan(t, serverExp, healthCheckSpanName)
+	assert.Equal(t, codes.NotFound, serverSpan.StatusCode)
+}
+
+func TestStatsHandlerFilter(t *testing.T) {
+	_, serverTP := newTestProvider(t)
+	clientExp, clientTP := newTestProvider(t)
+	filter := WithFilter(func(fullMethod string) bool {
+		return fullMethod != "/"+healthCheckSpanName
+	})
+
+	conn := dialBufconn(t,
+		grpc.WithStatsHandler(NewClientHandler(clientTP.Tracer("grpctrace/client"), filter)),
+		grpc.StatsHandler(NewServerHandler(serverTP.Tracer("grpctrace/server"), filter)),
+	)
+
+	_, err := healthpb.NewHealthClient(conn).Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: testService})
+	require.NoError(t, err)
+
+	clientExp.mu.Lock()
+	defer clientExp.mu.Unlock()
+	assert.Empty(t, clientExp.spanMap)
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package host provides metrics of the host and of the current process
+// read from the Linux proc filesystem: CPU time, memory usage, network
+// and disk I/O.
+//
+// The metrics are reported by asynchronous instruments of a meter, so
+// they are collected by the push or pull controller of the meter's
+// provider:
+//
+//	if err := host.Start(); err != nil {
+//	    log.Fatal(err)
+//	}
+package host // import "github.com/Ch1f/otel/instrumentation/host"
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"context"
+	"os"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/unit"
+)
+
+// instrumentationName is the name of this instrumentation package.
+const instrumentationName = "github.com/Ch1f/otel/instrumentation/host"
+
+// DefaultProcRoot is the default mount point of the proc filesystem.
+const DefaultProcRoot = "/proc"
+
+// Host and process metrics
+const (
+	HostCPUTime           = "host.cpu.time"           // Seconds spent by all CPUs, by state
+	HostMemoryUsage       = "host.memory.usage"       // Bytes of memory, by state
+	HostMemoryUtilization = "host.memory.utilization" // Fraction of memory in use
+	HostNetworkIO         = "host.network.io"         // Bytes received and transmitted, by device and direction
+	HostNetworkPackets    = "host.network.packets"    // Packets received and transmitted, by device and direction
+	HostNetworkErrors     = "host.network.errors"     // Errors receiving and transmitting, by device and direction
+	HostDiskIO            = "host.disk.io"            // Bytes read and written, by device and direction
+	HostDiskOperations    = "host.disk.operations"    // Completed reads and writes, by device and direction
+	ProcessCPUTime        = "process.cpu.time"        // Seconds spent by the process, by state
+	ProcessMemoryUsage    = "process.memory.usage"    // Bytes of resident memory of the process
+	ProcessMemoryVirtual  = "process.memory.virtual"  // Bytes of virtual memory of the process
+)
+
+// Metric label keys
+const (
+	StateKey     = kv.Key("state")
+	DirectionKey = kv.Key("direction")
+	DeviceKey    = kv.Key("device")
+)
+
+// Option is a function that allows configuration of the host metrics.
+type Option func(*config)
+
+type config struct {
+	meter    metric.Meter
+	procRoot string
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		meter:    global.Meter(instrumentationName),
+		procRoot: DefaultProcRoot,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// WithMeter configures a specific meter. If this option isn't specified
+// then the global meter is used.
+func WithMeter(meter metric.Meter) Option {
+	return func(c *config) {
+		c.meter = meter
+	}
+}
+
+// WithProcRoot sets the directory where the proc filesystem is mounted.
+// If this option isn't specified then DefaultProcRoot is used.
+func WithProcRoot(root string) Option {
+	return func(c *config) {
+		c.procRoot = root
+	}
+}
+
+// host holds the instruments.
+type host struct {
+	cfg  *config
+	proc procFS
+
+	cpuTime           metric.Float64SumObserver
+	memoryUsage       metric.Int64UpDownSumObserver
+	memoryUtilization metric.Float64ValueObserver
+	networkIO         metric.Int64SumObserver
+	networkPackets    metric.Int64SumObserver
+	networkErrors     metric.Int64SumObserver
+	diskIO            metric.Int64SumObserver
+	diskOperations    metric.Int64SumObserver
+	processCPUTime    metric.Float64SumObserver
+	processMemory     metric.Int64UpDownSumObserver
+	processVirtual    metric.Int64UpDownSumObserver
+}
+
+// Start registers the host metrics with the configured meter. The
+// metrics are reported each time the meter's provider collects them.
+// An error is returned if the proc filesystem root does not exist.
+func Start(opts ...Option) error {
+	cfg := newConfig(opts)
+	if _, err := os.Stat(cfg.procRoot); err != nil {
+		return err
+	}
+	h := &host{
+		cfg:  cfg,
+		proc: procFS{root: cfg.procRoot},
+	}
+	return h.register()
+}
+
+func (h *host) register() error {
+	batch := h.cfg.meter.NewBatchObserver(h.observe)
+
+	var err error
+	if h.cpuTime, err = batch.NewFloat64SumObserver(HostCPUTime, metric.WithUnit(unit.Seconds)); err != nil {
+		return err
+	}
+	if h.processCPUTime, err = batch.NewFloat64SumObserver(ProcessCPUTime, metric.WithUnit(unit.Seconds)); err != nil {
+		return err
+	}
+	if h.memoryUtilization, err = batch.NewFloat64ValueObserver(HostMemoryUtilization, metric.WithUnit(unit.Dimensionless)); err != nil {
+		return err
+	}
+	for _, inst := range []struct {
+		name      string
+		unit      unit.Unit
+		sum       *metric.Int64SumObserver
+		upDownSum *metric.Int64UpDownSumObserver
+	}{
+		{name: HostMemoryUsage, unit: unit.Bytes, upDownSum: &h.memoryUsage},
+		{name: HostNetworkIO, unit: unit.Bytes, sum: &h.networkIO},
+		{name: HostNetworkPackets, unit: unit.Dimensionless, sum: &h.networkPackets},
+		{name: HostNetworkErrors, unit: unit.Dimensionless, sum: &h.networkErrors},
+		{name: HostDiskIO, unit: unit.Bytes, sum: &h.diskIO},
+		{name: HostDiskOperations, unit: unit.Dimensionless, sum: &h.diskOperations},
+		{name: ProcessMemoryUsage, unit: unit.Bytes, upDownSum: &h.processMemory},
+		{name: ProcessMemoryVirtual, unit: unit.Bytes, upDownSum: &h.processVirtual},
+	} {
+		if inst.sum != nil {
+			*inst.sum, err = batch.NewInt64SumObserver(inst.name, metric.WithUnit(inst.unit))
+		} else {
+			*inst.upDownSum, err = batch.NewInt64UpDownSumObserver(inst.name, metric.WithUnit(inst.unit))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// observe reports the statistics of each proc file. A file that cannot
+// be read or parsed is reported to the global error handler and its
+// metrics are skipped.
+func (h *host) observe(_ context.Context, result metric.BatchObserverResult) {
+	if cpu, err := h.proc.cpuTimes(); err != nil {
+		global.Handle(err)
+	} else {
+		for _, s := range []struct {
+			state   string
+			seconds float64
+		}{
+			{"user", cpu.user},
+			{"nice", cpu.nice},
+			{"system", cpu.system},
+			{"idle", cpu.idle},
+			{"iowait", cpu.iowait},
+			{"irq", cpu.irq},
+			{"softirq", cpu.softirq},
+			{"steal", cpu.steal},
+		} {
+			result.Observe([]kv.KeyValue{StateKey.String(s.state)}, h.cpuTime.Observation(s.seconds))
+		}
+	}
+
+	if mem, err := h.proc.memInfo(); err != nil {
+		global.Handle(err)
+	} else {
+		used := mem.total - mem.free - mem.buffers - mem.cached
+		for _, s := range []struct {
+			state string
+			bytes int64
+		}{
+			{"used", used},
+			{"free", mem.free},
+			{"buffered", mem.buffers},
+			{"cached", mem.cached},
+		} {
+			result.Observe([]kv.KeyValue{StateKey.String(s.state)}, h.memoryUsage.Observation(s.bytes))
+		}
+		if mem.total > 0 {
+			result.Observe(nil, h.memoryUtilization.Observation(float64(used)/float64(mem.total)))
+		}
+	}
+
+	if devs, err := h.proc.netDevStats(); err != nil {
+		global.Handle(err)
+	} else {
+		for _, dev := range devs {
+			result.Observe(
+				[]kv.KeyValue{DeviceKey.String(dev.device), DirectionKey.String("receive")},
+				h.networkIO.Observation(dev.rxBytes),
+				h.networkPackets.Observation(dev.rxPackets),
+				h.networkErrors.Observation(dev.rxErrors),
+			)
+			result.Observe(
+				[]kv.KeyValue{DeviceKey.String(dev.device), DirectionKey.String("transmit")},
+				h.networkIO.Observation(dev.txBytes),
+				h.networkPackets.Observation(dev.txPackets),
+				h.networkErrors.Observation(dev.txErrors),
+			)
+		}
+	}
+
+	if disks, err := h.proc.diskStats(); err != nil {
+		global.Handle(err)
+	} else {
+		for _, disk := range disks {
+			result.Observe(
+				[]kv.KeyValue{DeviceKey.String(disk.device), DirectionKey.String("read")},
+				h.diskIO.Observation(disk.readSectors*sectorSize),
+				h.diskOperations.Observation(disk.reads),
+			)
+			result.Observe(
+				[]kv.KeyValue{DeviceKey.String(disk.device), DirectionKey.String("write")},
+				h.diskIO.Observation(disk.writtenSectors*sectorSize),
+				h.diskOperations.Observation(disk.writes),
+			)
+		}
+	}
+
+	if proc, err := h.proc.procStat(); err != nil {
+		global.Handle(err)
+	} else {
+		result.Observe([]kv.KeyValue{StateKey.String("user")}, h.processCPUTime.Observation(proc.utime))
+		result.Observe([]kv.KeyValue{StateKey.String("system")}, h.processCPUTime.Observation(proc.stime))
+		result.Observe(nil,
+			h.processMemory.Observation(proc.rss),
+			h.processVirtual.Observation(proc.vsize),
+		)
+	}
+}
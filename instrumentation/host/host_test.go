@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/label"
+	"github.com/Ch1f/otel/instrumentation/host"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+)
+
+// collect runs the asynchronous instruments and returns the observed
+// values keyed by instrument name and encoded labels.
+func collect(impl *mockmeter.MeterImpl) map[string]float64 {
+	impl.MeasurementBatches = nil
+	impl.RunAsyncInstruments()
+
+	got := map[string]float64{}
+	for _, batch := range impl.MeasurementBatches {
+		labels := label.NewSet(batch.Labels...)
+		for _, m := range batch.Measurements {
+			desc := m.Instrument.Descriptor()
+			key := desc.Name()
+			if labels.Len() > 0 {
+				key += "{" + labels.Encoded(label.DefaultEncoder()) + "}"
+			}
+			got[key] = m.Number.CoerceToFloat64(desc.NumberKind())
+		}
+	}
+	return got
+}
+
+func TestHostMetrics(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	require.NoError(t, host.Start(
+		host.WithMeter(meter),
+		host.WithProcRoot(filepath.Join("testdata", "proc")),
+	))
+
+	got := collect(impl)
+
+	// /proc/stat, in clock ticks.
+	assert.Equal(t, 101321.53, got[host.HostCPUTime+"{state=user}"])
+	assert.Equal(t, 2906.96, got[host.HostCPUTime+"{state=nice}"])
+	assert.Equal(t, 30847.19, got[host.HostCPUTime+"{state=system}"])
+	assert.Equal(t, 468284.83, got[host.HostCPUTime+"{state=idle}"])
+	assert.Equal(t, 166.83, got[host.HostCPUTime+"{state=iowait}"])
+	assert.Equal(t, 251.95, got[host.HostCPUTime+"{state=softirq}"])
+	assert.Contains(t, got, host.HostCPUTime+"{state=steal}")
+
+	// /proc/meminfo, in kB.
+	assert.Equal(t, 4000000.*1024, got[host.HostMemoryUsage+"{state=used}"])
+	assert.Equal(t, 2000000.*1024, got[host.HostMemoryUsage+"{state=free}"])
+	assert.Equal(t, 500000.*1024, got[host.HostMemoryUsage+"{state=buffered}"])
+	assert.Equal(t, 1500000.*1024, got[host.HostMemoryUsage+"{state=cached}"])
+	assert.Equal(t, 0.5, got[host.HostMemoryUtilization])
+
+	// /proc/net/dev
+	assert.Equal(t, 9876543., got[host.HostNetworkIO+"{device=eth0,direction=receive}"])
+	assert.Equal(t, 1234567., got[host.HostNetworkIO+"{device=eth0,direction=transmit}"])
+	assert.Equal(t, 8000., got[host.HostNetworkPackets+"{device=eth0,direction=receive}"])
+	assert.Equal(t, 4000., got[host.HostNetworkPackets+"{device=eth0,direction=transmit}"])
+	assert.Equal(t, 2., got[host.HostNetworkErrors+"{device=eth0,direction=receive}"])
+	assert.Equal(t, 3., got[host.HostNetworkErrors+"{device=eth0,direction=transmit}"])
+	assert.Equal(t, 12345., got[host.HostNetworkIO+"{device=lo,direction=receive}"])
+
+	// /proc/diskstats, in 512 byte sectors.
+	assert.Equal(t, 20000.*512, got[host.HostDiskIO+"{device=sda,direction=read}"])
+	assert.Equal(t, 40000.*512, got[host.HostDiskIO+"{device=sda,direction=write}"])
+	assert.Equal(t, 1000., got[host.HostDiskOperations+"{device=sda,direction=read}"])
+	assert.Equal(t, 2000., got[host.HostDiskOperations+"{device=sda,direction=write}"])
+	assert.Equal(t, 900., got[host.HostDiskOperations+"{device=sda1,direction=read}"])
+
+	// /proc/self/stat, in clock ticks and pages.
+	assert.Equal(t, 2.5, got[host.ProcessCPUTime+"{state=user}"])
+	assert.Equal(t, 1.5, got[host.ProcessCPUTime+"{state=system}"])
+	assert.Equal(t, float64(2560*os.Getpagesize()), got[host.ProcessMemoryUsage])
+	assert.Equal(t, 104857600., got[host.ProcessMemoryVirtual])
+}
+
+func TestMissingProcRoot(t *testing.T) {
+	_, meter := mockmeter.NewMeter()
+	err := host.Start(
+		host.WithMeter(meter),
+		host.WithProcRoot(filepath.Join("testdata", "missing")),
+	)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMissingProcFile(t *testing.T) {
+	// Only /proc/meminfo exists, the other metrics are skipped.
+	dir, err := ioutil.TempDir("", "proc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "proc", "meminfo"))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "meminfo"), data, 0600))
+
+	impl, meter := mockmeter.NewMeter()
+	require.NoError(t, host.Start(host.WithMeter(meter), host.WithProcRoot(dir)))
+
+	for name := range collect(impl) {
+		assert.True(t, strings.HasPrefix(name, "host.memory."), name)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package host
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// userHZ is the number of clock ticks per second the kernel uses
+	// for the times reported in /proc/stat and /proc/[pid]/stat.
+	userHZ = 100
+
+	// sectorSize is the size in bytes of the sectors counted in
+	// /proc/diskstats, independently of the device.
+	sectorSize = 512
+)
+
+// cpuTimes holds the seconds spent by the CPUs in each state.
+type cpuTimes struct {
+	user, nice, system, idle, iowait, irq, softirq, steal float64
+}
+
+// memInfo holds the memory statistics, in bytes.
+type memInfo struct {
+	total, free, buffers, cached int64
+}
+
+// netDevStats holds the counters of a network interface.
+type netDevStats struct {
+	device                       string
+	rxBytes, rxPackets, rxErrors int64
+	txBytes, txPackets, txErrors int64
+}
+
+// diskStats holds the counters of a block device.
+type diskStats struct {
+	device                 string
+	reads, readSectors     int64
+	writes, writtenSectors int64
+}
+
+// procStat holds the statistics of a process.
+type procStat struct {
+	utime, stime float64
+	vsize, rss   int64
+}
+
+// procFS reads the files of a proc filesystem mounted at root.
+type procFS struct {
+	root string
+}
+
+func (p procFS) path(elem ...string) string {
+	return filepath.Join(append([]string{p.root}, elem...)...)
+}
+
+// lines returns the lines of the named file.
+func (p procFS) lines(elem ...string) ([]string, error) {
+	f, err := os.Open(p.path(elem...))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// parseInts parses the fields as base 10 integers.
+func parseInts(fields []string) ([]int64, error) {
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		v, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// cpuTimes reads the time spent by all CPUs from /proc/stat.
+func (p procFS) cpuTimes() (cpuTimes, error) {
+	lines, err := p.lines("stat")
+	if err != nil {
+		return cpuTimes{}, err
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 9 || fields[0] != "cpu" {
+			continue
+		}
+		ticks, err := parseInts(fields[1:9])
+		if err != nil {
+			return cpuTimes{}, fmt.Errorf("host: invalid cpu line in %s: %w", p.path("stat"), err)
+		}
+		seconds := func(i int) float64 { return float64(ticks[i]) / userHZ }
+		return cpuTimes{
+			user:    seconds(0),
+			nice:    seconds(1),
+			system:  seconds(2),
+			idle:    seconds(3),
+			iowait:  seconds(4),
+			irq:     seconds(5),
+			softirq: seconds(6),
+			steal:   seconds(7),
+		}, nil
+	}
+	return cpuTimes{}, fmt.Errorf("host: no cpu line in %s", p.path("stat"))
+}
+
+// memInfo reads the memory statistics from /proc/meminfo.
+func (p procFS) memInfo() (memInfo, error) {
+	lines, err := p.lines("meminfo")
+	if err != nil {
+		return memInfo{}, err
+	}
+	var m memInfo
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		var dst *int64
+		switch fields[0] {
+		case "MemTotal:":
+			dst = &m.total
+		case "MemFree:":
+			dst = &m.free
+		case "Buffers:":
+			dst = &m.buffers
+		case "Cached:":
+			dst = &m.cached
+		default:
+			continue
+		}
+		v, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return memInfo{}, fmt.Errorf("host: invalid %s in %s: %w", fields[0], p.path("meminfo"), err)
+		}
+		if len(fields) > 2 && fields[2] == "kB" {
+			v *= 1024
+		}
+		*dst = v
+	}
+	return m, nil
+}
+
+// netDevStats reads the counters of the network interfaces from
+// /proc/net/dev.
+func (p procFS) netDevStats() ([]netDevStats, error) {
+	lines, err := p.lines("net", "dev")
+	if err != nil {
+		return nil, err
+	}
+	var stats []netDevStats
+	for _, line := range lines {
+		// The first two lines are headers without a colon.
+		idx := strings.IndexByte(line, ':')
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(line[idx+1:])
+		if len(fields) < 16 {
+			return nil, fmt.Errorf("host: invalid line in %s: %q", p.path("net", "dev"), line)
+		}
+		values, err := parseInts(fields[:16])
+		if err != nil {
+			return nil, fmt.Errorf("host: invalid line in %s: %w", p.path("net", "dev"), err)
+		}
+		stats = append(stats, netDevStats{
+			device:    strings.TrimSpace(line[:idx]),
+			rxBytes:   values[0],
+			rxPackets: values[1],
+			rxErrors:  values[2],
+			txBytes:   values[8],
+			txPackets: values[9],
+			txErrors:  values[10],
+		})
+	}
+	return stats, nil
+}
+
+// diskStats reads the counters of the block devices from
+// /proc/diskstats.
+func (p procFS) diskStats() ([]diskStats, error) {
+	lines, err := p.lines("diskstats")
+	if err != nil {
+		return nil, err
+	}
+	var stats []diskStats
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 10 {
+			return nil, fmt.Errorf("host: invalid line in %s: %q", p.path("diskstats"), line)
+		}
+		values, err := parseInts(fields[3:10])
+		if err != nil {
+			return nil, fmt.Errorf("host: invalid line in %s: %w", p.path("diskstats"), err)
+		}
+		stats = append(stats, diskStats{
+			device:         fields[2],
+			reads:          values[0],
+			readSectors:    values[2],
+			writes:         values[4],
+			writtenSectors: values[6],
+		})
+	}
+	return stats, nil
+}
+
+// procStat reads the statistics of the current process from
+// /proc/self/stat.
+func (p procFS) procStat() (procStat, error) {
+	data, err := ioutil.ReadFile(p.path("self", "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+	// The command name is in parentheses and may contain spaces, the
+	// fields following it start with the process state.
+	line := string(data)
+	idx := strings.LastIndexByte(line, ')')
+	if idx < 0 {
+		return procStat{}, fmt.Errorf("host: invalid %s", p.path("self", "stat"))
+	}
+	fields := strings.Fields(line[idx+1:])
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("host: invalid %s", p.path("self", "stat"))
+	}
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("host: invalid utime in %s: %w", p.path("self", "stat"), err)
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("host: invalid stime in %s: %w", p.path("self", "stat"), err)
+	}
+	vsize, err := strconv.ParseInt(fields[20], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("host: invalid vsize in %s: %w", p.path("self", "stat"), err)
+	}
+	rss, err := strconv.ParseInt(fields[21], 10, 64)
+	if err != nil {
+		return procStat{}, fmt.Errorf("host: invalid rss in %s: %w", p.path("self", "stat"), err)
+	}
+	return procStat{
+		utime: float64(utime) / userHZ,
+		stime: float64(stime) / userHZ,
+		vsize: vsize,
+		rss:   rss * int64(os.Getpagesize()),
+	}, nil
+}
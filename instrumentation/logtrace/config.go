@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtrace
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/Ch1f/otel/api/correlation"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/trace"
+)
+
+const (
+	// TraceIDKey is the field holding the hex encoded trace ID.
+	TraceIDKey = kv.Key("trace_id")
+	// SpanIDKey is the field holding the hex encoded span ID.
+	SpanIDKey = kv.Key("span_id")
+	// TraceFlagsKey is the field holding the hex encoded trace flags.
+	TraceFlagsKey = kv.Key("trace_flags")
+
+	// EventName is the name of the span events mirroring log lines.
+	EventName = "log"
+	// MessageKey is the span event attribute holding the log line.
+	MessageKey = kv.Key("log.message")
+)
+
+// Option is a function that allows configuration of the bridges.
+type Option func(*config)
+
+type config struct {
+	correlationKeys []kv.Key
+	spanEvents      bool
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// WithCorrelationKeys appends the correlation entries with the given
+// keys, if present in the context, to every log line. By default no
+// correlation entries are added.
+func WithCorrelationKeys(keys ...kv.Key) Option {
+	return func(c *config) {
+		c.correlationKeys = append(c.correlationKeys, keys...)
+	}
+}
+
+// WithSpanEvents additionally adds every log line to the span active in
+// the context as an event named EventName.
+func WithSpanEvents() Option {
+	return func(c *config) {
+		c.spanEvents = true
+	}
+}
+
+// fields returns the trace context and selected correlation entries of
+// ctx, in that order. The trace context is omitted if ctx carries no
+// valid span context.
+func (c *config) fields(ctx context.Context) []kv.KeyValue {
+	var fields []kv.KeyValue
+	if sc := trace.SpanFromContext(ctx).SpanContext(); sc.IsValid() {
+		fields = append(fields,
+			TraceIDKey.String(sc.TraceID.String()),
+			SpanIDKey.String(sc.SpanID.String()),
+			TraceFlagsKey.String(hex.EncodeToString([]byte{sc.TraceFlags})),
+		)
+	}
+	if len(c.correlationKeys) > 0 {
+		m := correlation.MapFromContext(ctx)
+		for _, k := range c.correlationKeys {
+			if v, ok := m.Value(k); ok {
+				fields = append(fields, kv.KeyValue{Key: k, Value: v})
+			}
+		}
+	}
+	return fields
+}
+
+// event mirrors line as an event of the span active in ctx.
+func (c *config) event(ctx context.Context, line string) {
+	if !c.spanEvents {
+		return
+	}
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.AddEvent(ctx, EventName, MessageKey.String(line))
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logtrace bridges existing loggers with trace context, so that
+// log lines can be joined with the traces they were written in.
+//
+// A *log.Logger is wrapped with NewLogger. Its Print methods take a
+// context and append the trace_id, span_id and trace_flags of the span
+// active in it, followed by the selected correlation entries, as
+// key=value pairs:
+//
+//	logger := logtrace.NewLogger(log.New(os.Stderr, "", log.LstdFlags),
+//	    logtrace.WithCorrelationKeys("user.id"),
+//	)
+//	logger.Printf(ctx, "fetched %d rows", n)
+//	// 2020/06/01 12:00:00 fetched 3 rows trace_id=0102... span_id=0102... trace_flags=01 user.id=42
+//
+// Structured loggers writing one JSON object per line to an io.Writer
+// are bridged with NewJSONWriter. The writer returned by WithContext
+// adds the same fields to every JSON object written through it:
+//
+//	w := logtrace.NewJSONWriter(os.Stderr)
+//	json.NewEncoder(w.WithContext(ctx)).Encode(entry)
+//	// {"msg":"fetched rows","trace_id":"0102...","span_id":"0102...","trace_flags":"01"}
+//
+// With WithSpanEvents each line is also added to the active span as an
+// event.
+package logtrace // import "github.com/Ch1f/otel/instrumentation/logtrace"
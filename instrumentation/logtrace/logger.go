@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtrace
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/Ch1f/otel/api/kv"
+)
+
+// Logger wraps a *log.Logger, appending trace context to every line.
+type Logger struct {
+	l *log.Logger
+	c *config
+}
+
+// NewLogger returns a Logger writing to l.
+func NewLogger(l *log.Logger, opts ...Option) *Logger {
+	return &Logger{l: l, c: newConfig(opts)}
+}
+
+// Logger returns the wrapped *log.Logger.
+func (l *Logger) Logger() *log.Logger {
+	return l.l
+}
+
+// Print calls Output to print to the logger. Arguments are handled in
+// the manner of fmt.Print.
+func (l *Logger) Print(ctx context.Context, v ...interface{}) {
+	l.output(ctx, fmt.Sprint(v...))
+}
+
+// Printf calls Output to print to the logger. Arguments are handled in
+// the manner of fmt.Printf.
+func (l *Logger) Printf(ctx context.Context, format string, v ...interface{}) {
+	l.output(ctx, fmt.Sprintf(format, v...))
+}
+
+// Println calls Output to print to the logger. Arguments are handled in
+// the manner of fmt.Println.
+func (l *Logger) Println(ctx context.Context, v ...interface{}) {
+	l.output(ctx, fmt.Sprintln(v...))
+}
+
+// Output writes msg followed by the trace context of ctx. calldepth is
+// as for log.Logger.Output, counting from the caller of Output.
+func (l *Logger) Output(ctx context.Context, calldepth int, msg string) error {
+	return l.outputDepth(ctx, calldepth+1, msg)
+}
+
+func (l *Logger) output(ctx context.Context, msg string) {
+	// Print*, output, outputDepth.
+	_ = l.outputDepth(ctx, 3, msg)
+}
+
+func (l *Logger) outputDepth(ctx context.Context, calldepth int, msg string) error {
+	msg = strings.TrimSuffix(msg, "\n")
+	l.c.event(ctx, msg)
+
+	var b strings.Builder
+	b.WriteString(msg)
+	for _, f := range l.c.fields(ctx) {
+		b.WriteByte(' ')
+		b.WriteString(string(f.Key))
+		b.WriteByte('=')
+		b.WriteString(formatValue(f))
+	}
+	return l.l.Output(calldepth+1, b.String())
+}
+
+// formatValue emits the value of f, quoted if it would otherwise be
+// ambiguous in a key=value list.
+func formatValue(f kv.KeyValue) string {
+	s := f.Value.Emit()
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
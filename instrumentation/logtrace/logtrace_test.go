@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtrace_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/correlation"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/instrumentation/logtrace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+	"github.com/Ch1f/otel/sdk/trace/tracetest"
+)
+
+func newSpan(t *testing.T) (context.Context, trace.Span, *tracetest.SpanRecorder) {
+	sr := tracetest.NewSpanRecorder()
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	tp.RegisterSpanProcessor(sr)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	return ctx, span, sr
+}
+
+func traceFields(span trace.Span) string {
+	sc := span.SpanContext()
+	return "trace_id=" + sc.TraceID.String() + " span_id=" + sc.SpanID.String() + " trace_flags=01"
+}
+
+func TestLogger(t *testing.T) {
+	ctx, span, _ := newSpan(t)
+	defer span.End()
+	ctx = correlation.NewContext(ctx,
+		kv.String("user.id", "42"),
+		kv.String("tenant", "a b"),
+		kv.String("ignored", "x"),
+	)
+
+	var buf bytes.Buffer
+	logger := logtrace.NewLogger(log.New(&buf, "", 0),
+		logtrace.WithCorrelationKeys("user.id", "tenant", "missing"),
+	)
+
+	logger.Printf(ctx, "fetched %d rows", 3)
+	logger.Println(ctx, "done")
+	logger.Print(context.Background(), "no span")
+
+	assert.Equal(t,
+		"fetched 3 rows "+traceFields(span)+" user.id=42 tenant=\"a b\"\n"+
+			"done "+traceFields(span)+" user.id=42 tenant=\"a b\"\n"+
+			"no span\n",
+		buf.String())
+}
+
+func TestLoggerCallDepth(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logtrace.NewLogger(log.New(&buf, "", log.Lshortfile))
+
+	logger.Print(context.Background(), "print")
+	require.NoError(t, logger.Output(context.Background(), 1, "output"))
+
+	assert.Regexp(t, `^logtrace_test.go:\d+: print\nlogtrace_test.go:\d+: output\n$`, buf.String())
+}
+
+func TestLoggerSpanEvents(t *testing.T) {
+	ctx, span, sr := newSpan(t)
+	logger := logtrace.NewLogger(log.New(&bytes.Buffer{}, "", 0), logtrace.WithSpanEvents())
+
+	logger.Println(ctx, "hello")
+	span.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].MessageEvents, 1)
+	assert.Equal(t, logtrace.EventName, spans[0].MessageEvents[0].Name)
+	assert.Equal(t, []kv.KeyValue{logtrace.MessageKey.String("hello")}, spans[0].MessageEvents[0].Attributes)
+}
+
+func TestJSONWriter(t *testing.T) {
+	ctx, span, _ := newSpan(t)
+	defer span.End()
+	ctx = correlation.NewContext(ctx, kv.Int64("user.id", 42))
+	sc := span.SpanContext()
+	ids := `"trace_id":"` + sc.TraceID.String() + `","span_id":"` + sc.SpanID.String() + `","trace_flags":"01"`
+
+	var buf bytes.Buffer
+	jw := logtrace.NewJSONWriter(&buf, logtrace.WithCorrelationKeys("user.id"))
+	w := jw.WithContext(ctx)
+
+	require.NoError(t, json.NewEncoder(w).Encode(map[string]string{"msg": "hi"}))
+	p := []byte("{}\nplain text\n{ \"a\": 1 }  \n")
+	n, err := w.Write(p)
+	require.NoError(t, err)
+	assert.Equal(t, len(p), n)
+
+	assert.Equal(t,
+		`{"msg":"hi",`+ids+`,"user.id":42}`+"\n"+
+			`{`+ids+`,"user.id":42}`+"\n"+
+			"plain text\n"+
+			`{ "a": 1,`+ids+`,"user.id":42}  `+"\n",
+		buf.String())
+}
+
+func TestJSONWriterWithoutSpan(t *testing.T) {
+	var buf bytes.Buffer
+	w := logtrace.NewJSONWriter(&buf).WithContext(context.Background())
+
+	_, err := w.Write([]byte(`{"msg":"hi"}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"msg":"hi"}`+"\n", buf.String())
+}
+
+func TestJSONWriterSpanEvents(t *testing.T) {
+	ctx, span, sr := newSpan(t)
+	w := logtrace.NewJSONWriter(&bytes.Buffer{}, logtrace.WithSpanEvents()).WithContext(ctx)
+
+	_, err := w.Write([]byte(`{"msg":"hi"}` + "\n\n"))
+	require.NoError(t, err)
+	span.End()
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Len(t, spans[0].MessageEvents, 1)
+	assert.Equal(t, []kv.KeyValue{logtrace.MessageKey.String(`{"msg":"hi"}`)}, spans[0].MessageEvents[0].Attributes)
+}
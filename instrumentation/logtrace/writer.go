@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logtrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/Ch1f/otel/api/kv"
+)
+
+// JSONWriter adds trace context to structured loggers writing JSON
+// lines to an io.Writer.
+type JSONWriter struct {
+	w io.Writer
+	c *config
+}
+
+// NewJSONWriter returns a JSONWriter writing to w.
+func NewJSONWriter(w io.Writer, opts ...Option) *JSONWriter {
+	return &JSONWriter{w: w, c: newConfig(opts)}
+}
+
+// WithContext returns an io.Writer that adds the trace context of ctx
+// to every JSON object it is given and writes it to the underlying
+// writer. Each Write is expected to hold whole lines, which is the case
+// for json.Encoder and the common structured loggers. Lines that are
+// not JSON objects are written unchanged.
+func (jw *JSONWriter) WithContext(ctx context.Context) io.Writer {
+	return &contextWriter{jw: jw, ctx: ctx}
+}
+
+type contextWriter struct {
+	jw  *JSONWriter
+	ctx context.Context
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	fields := jsonFields(cw.jw.c.fields(cw.ctx))
+
+	out := make([]byte, 0, len(p)+128)
+	rest := p
+	for len(rest) > 0 {
+		var line []byte
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i+1], rest[i+1:]
+		} else {
+			line, rest = rest, nil
+		}
+		out = appendLine(out, line, fields)
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			cw.jw.c.event(cw.ctx, string(trimmed))
+		}
+	}
+	if _, err := cw.jw.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// appendLine appends line to out, inserting fields before the closing
+// brace if line is a JSON object.
+func appendLine(out, line []byte, fields []jsonField) []byte {
+	trimmed := bytes.TrimRight(line, " \t\r\n")
+	if len(fields) == 0 || !isObject(trimmed) {
+		return append(out, line...)
+	}
+	body := bytes.TrimRight(trimmed[:len(trimmed)-1], " \t\r\n")
+	out = append(out, body...)
+	empty := len(bytes.TrimSpace(body)) == 1
+	for _, f := range fields {
+		if !empty {
+			out = append(out, ',')
+		}
+		empty = false
+		out = append(out, f.key...)
+		out = append(out, ':')
+		out = append(out, f.value...)
+	}
+	out = append(out, '}')
+	return append(out, line[len(trimmed):]...)
+}
+
+func isObject(b []byte) bool {
+	b = bytes.TrimLeft(b, " \t")
+	return len(b) >= 2 && b[0] == '{' && b[len(b)-1] == '}'
+}
+
+// jsonField is a field encoded as a JSON object member.
+type jsonField struct {
+	key, value []byte
+}
+
+func jsonFields(fields []kv.KeyValue) []jsonField {
+	jfs := make([]jsonField, 0, len(fields))
+	for _, f := range fields {
+		key, _ := json.Marshal(string(f.Key))
+		value, err := json.Marshal(f.Value.AsInterface())
+		if err != nil {
+			// E.g. NaN, which JSON cannot represent.
+			value, _ = json.Marshal(f.Value.Emit())
+		}
+		jfs = append(jfs, jsonField{key: key, value: value})
+	}
+	return jfs
+}
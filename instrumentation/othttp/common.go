@@ -34,6 +34,15 @@ const (
 	RequestContentLength  = "http.server.request_content_length"  // Incoming request bytes total
 	ResponseContentLength = "http.server.response_content_length" // Incoming response bytes total
 	ServerLatency         = "http.server.duration"                // Incoming end to end duration, microseconds
+	ServerActiveRequests  = "http.server.active_requests"         // Number of incoming requests in flight
+)
+
+// Client HTTP metrics
+const (
+	ClientRequestContentLength  = "http.client.request_content_length"  // Outgoing request bytes total
+	ClientResponseContentLength = "http.client.response_content_length" // Incoming response bytes total, when known
+	ClientLatency               = "http.client.duration"                // Outgoing duration until the response headers are received, microseconds
+	ClientActiveRequests        = "http.client.active_requests"         // Number of outgoing requests in flight
 )
 
 // Filter is a predicate used to determine whether a given http.request should
@@ -33,6 +33,7 @@ type Config struct {
 	WriteEvent        bool
 	Filters           []Filter
 	SpanNameFormatter func(string, *http.Request) string
+	RecordPanics      bool
 }
 
 // Option Interface used for setting *optional* Config properties
@@ -148,3 +149,12 @@ func WithSpanNameFormatter(f func(operation string, r *http.Request) string) Opt
 		c.SpanNameFormatter = f
 	})
 }
+
+// WithPanicRecording configures the Handler to record a panic of the
+// wrapped handler as a span event with its stack trace and an error
+// status. The panic is then propagated to the server.
+func WithPanicRecording() Option {
+	return OptionFunc(func(c *Config) {
+		c.RecordPanics = true
+	})
+}
@@ -15,6 +15,7 @@
 package othttp
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/Ch1f/otel/api/propagation"
 	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/internal/trace/panics"
 )
 
 var _ http.Handler = &Handler{}
@@ -45,8 +47,10 @@ type Handler struct {
 	writeEvent        bool
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
+	recordPanics      bool
 	counters          map[string]metric.Int64Counter
 	valueRecorders    map[string]metric.Int64ValueRecorder
+	upDownCounters    map[string]metric.Int64UpDownCounter
 }
 
 func defaultHandlerFormatter(operation string, _ *http.Request) string {
@@ -87,6 +91,7 @@ func (h *Handler) configure(c *Config) {
 	h.writeEvent = c.WriteEvent
 	h.filters = c.Filters
 	h.spanNameFormatter = c.SpanNameFormatter
+	h.recordPanics = c.RecordPanics
 }
 
 func handleErr(err error) {
@@ -98,6 +103,7 @@ func handleErr(err error) {
 func (h *Handler) createMeasures() {
 	h.counters = make(map[string]metric.Int64Counter)
 	h.valueRecorders = make(map[string]metric.Int64ValueRecorder)
+	h.upDownCounters = make(map[string]metric.Int64UpDownCounter)
 
 	requestBytesCounter, err := h.meter.NewInt64Counter(RequestContentLength)
 	handleErr(err)
@@ -108,9 +114,13 @@ func (h *Handler) createMeasures() {
 	serverLatencyMeasure, err := h.meter.NewInt64ValueRecorder(ServerLatency)
 	handleErr(err)
 
+	activeRequestsCounter, err := h.meter.NewInt64UpDownCounter(ServerActiveRequests)
+	handleErr(err)
+
 	h.counters[RequestContentLength] = requestBytesCounter
 	h.counters[ResponseContentLength] = responseBytesCounter
 	h.valueRecorders[ServerLatency] = serverLatencyMeasure
+	h.upDownCounters[ServerActiveRequests] = activeRequestsCounter
 }
 
 // ServeHTTP serves HTTP requests (http.Handler)
@@ -134,6 +144,27 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := h.tracer.Start(ctx, h.spanNameFormatter(h.operation, r), opts...)
 	defer span.End()
 
+	labels := standard.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)
+	h.upDownCounters[ServerActiveRequests].Add(ctx, 1, labels...)
+	defer h.upDownCounters[ServerActiveRequests].Add(ctx, -1, labels...)
+
+	if h.recordPanics {
+		defer func() {
+			// http.ErrAbortHandler aborts the response and is not an
+			// error of the handler.
+			if r := recover(); r != nil {
+				if r != http.ErrAbortHandler {
+					panics.Record(ctx, span, r)
+				}
+				panic(r)
+			}
+		}()
+	}
+
+	// WithRouteTag records the route of the request in its state.
+	state := &requestState{}
+	ctx = context.WithValue(ctx, requestStateKey{}, state)
+
 	readRecordFunc := func(int64) {}
 	if h.readEvent {
 		readRecordFunc = func(n int64) {
@@ -158,7 +189,15 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Add request metrics
 
-	labels := standard.HTTPServerMetricAttributesFromHTTPRequest(h.operation, r)
+	if state.route != "" {
+		labels = append(labels, standard.HTTPRouteKey.String(state.route))
+	}
+	statusCode := rww.statusCode
+	if statusCode == 0 {
+		// The server replies with 200 when the handler writes nothing.
+		statusCode = http.StatusOK
+	}
+	labels = append(labels, standard.HTTPStatusCodeKey.Int(statusCode))
 
 	h.counters[RequestContentLength].Add(ctx, bw.read, labels...)
 	h.counters[ResponseContentLength].Add(ctx, rww.written, labels...)
@@ -192,12 +231,26 @@ func setAfterServeAttributes(span trace.Span, read, wrote int64, statusCode int,
 	span.SetAttributes(kv...)
 }
 
+// requestStateKey is the context key of the requestState of a request
+// served by a Handler.
+type requestStateKey struct{}
+
+// requestState holds the properties of a request determined while it is
+// served, which are added as labels to the request metrics.
+type requestState struct {
+	route string
+}
+
 // WithRouteTag annotates a span with the provided route name using the
-// RouteKey Tag.
+// RouteKey Tag. The route is also added as a label to the metrics of the
+// enclosing Handler.
 func WithRouteTag(route string, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		span := trace.SpanFromContext(r.Context())
 		span.SetAttributes(standard.HTTPRouteKey.String(route))
+		if state, ok := r.Context().Value(requestStateKey{}).(*requestState); ok {
+			state.route = route
+		}
 		h.ServeHTTP(w, r)
 	})
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 
 	"github.com/Ch1f/otel/api/kv"
@@ -38,6 +39,20 @@ func assertMetricLabels(t *testing.T, expectedLabels []kv.KeyValue, measurementB
 	}
 }
 
+// batchesOf returns the measurement batches of the instrument name.
+func batchesOf(name string, measurementBatches []mockmeter.Batch) []mockmeter.Batch {
+	var batches []mockmeter.Batch
+	for _, batch := range measurementBatches {
+		for _, m := range batch.Measurements {
+			if m.Instrument.Descriptor().Name() == name {
+				batches = append(batches, batch)
+				break
+			}
+		}
+	}
+	return batches
+}
+
 func TestHandlerBasics(t *testing.T) {
 	rr := httptest.NewRecorder()
 
@@ -68,14 +83,22 @@ func TestHandlerBasics(t *testing.T) {
 	}
 
 	labelsToVerify := []kv.KeyValue{
+		standard.HTTPMethodKey.String("GET"),
 		standard.HTTPServerNameKey.String(operation),
 		standard.HTTPSchemeHTTP,
 		standard.HTTPHostKey.String(r.Host),
 		standard.HTTPFlavorKey.String(fmt.Sprintf("1.%d", r.ProtoMinor)),
-		standard.HTTPRequestContentLengthKey.Int64(3),
 	}
 
-	assertMetricLabels(t, labelsToVerify, meterimpl.MeasurementBatches)
+	activeRequests := batchesOf(ServerActiveRequests, meterimpl.MeasurementBatches)
+	require.Len(t, activeRequests, 2)
+	assert.Equal(t, int64(1), activeRequests[0].Measurements[0].Number.AsInt64())
+	assert.Equal(t, int64(-1), activeRequests[1].Measurements[0].Number.AsInt64())
+	assertMetricLabels(t, labelsToVerify, activeRequests)
+
+	labelsToVerify = append(labelsToVerify, standard.HTTPStatusCodeKey.Int(http.StatusOK))
+	assertMetricLabels(t, labelsToVerify, batchesOf(ServerLatency, meterimpl.MeasurementBatches))
+	assertMetricLabels(t, labelsToVerify, batchesOf(RequestContentLength, meterimpl.MeasurementBatches))
 
 	if got, expected := rr.Result().StatusCode, http.StatusOK; got != expected {
 		t.Fatalf("got %d, expected %d", got, expected)
@@ -134,3 +157,65 @@ func TestHandlerNoWrite(t *testing.T) {
 		t.Fatalf("Expected *moctrace.MockSpan, got %T", span)
 	}
 }
+
+func TestHandlerRouteAndStatusMetricLabels(t *testing.T) {
+	var id uint64
+	tracer := mocktrace.MockTracer{StartSpanID: &id}
+	meterimpl, meter := mockmeter.NewMeter()
+
+	h := NewHandler(
+		WithRouteTag("/users/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})), "test_handler",
+		WithTracer(&tracer),
+		WithMeter(meter),
+	)
+
+	r, err := http.NewRequest(http.MethodPost, "http://localhost/users/123", nil)
+	require.NoError(t, err)
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	latency := batchesOf(ServerLatency, meterimpl.MeasurementBatches)
+	require.Len(t, latency, 1)
+	assert.Contains(t, latency[0].Labels, standard.HTTPMethodKey.String(http.MethodPost))
+	assert.Contains(t, latency[0].Labels, standard.HTTPRouteKey.String("/users/{id}"))
+	assert.Contains(t, latency[0].Labels, standard.HTTPStatusCodeKey.Int(http.StatusNotFound))
+}
+
+func TestHandlerPanicRecording(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		value      interface{}
+		wantStatus codes.Code
+		wantMsg    string
+	}{
+		{name: "panic", value: "boom", wantStatus: codes.Internal, wantMsg: "panic: boom"},
+		{name: "abort", value: http.ErrAbortHandler, wantStatus: codes.OK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var id uint64
+			tracer := mocktrace.MockTracer{StartSpanID: &id}
+
+			var span trace.Span
+			h := NewHandler(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					span = trace.SpanFromContext(r.Context())
+					panic(tc.value)
+				}), "test_handler",
+				WithTracer(&tracer),
+				WithPanicRecording(),
+			)
+
+			r, err := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+			require.NoError(t, err)
+			assert.PanicsWithValue(t, tc.value, func() {
+				h.ServeHTTP(httptest.NewRecorder(), r)
+			})
+
+			mockSpan, ok := span.(*mocktrace.MockSpan)
+			require.True(t, ok, "expected *mocktrace.MockSpan, got %T", span)
+			assert.Equal(t, tc.wantStatus, mockSpan.Status)
+			assert.Equal(t, tc.wantMsg, mockSpan.StatusMsg)
+		})
+	}
+}
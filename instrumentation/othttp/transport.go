@@ -18,8 +18,11 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
 	"github.com/Ch1f/otel/api/propagation"
 	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/trace"
@@ -33,10 +36,14 @@ type Transport struct {
 	rt http.RoundTripper
 
 	tracer            trace.Tracer
+	meter             metric.Meter
 	propagators       propagation.Propagators
 	spanStartOptions  []trace.StartOption
 	filters           []Filter
 	spanNameFormatter func(string, *http.Request) string
+	counters          map[string]metric.Int64Counter
+	valueRecorders    map[string]metric.Int64ValueRecorder
+	upDownCounters    map[string]metric.Int64UpDownCounter
 }
 
 var _ http.RoundTripper = &Transport{}
@@ -48,8 +55,11 @@ func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
 		rt: base,
 	}
 
+	const domain = "github.com/Ch1f/otel/instrumentation/othttp"
+
 	defaultOpts := []Option{
-		WithTracer(global.Tracer("github.com/Ch1f/otel/instrumentation/othttp")),
+		WithTracer(global.Tracer(domain)),
+		WithMeter(global.Meter(domain)),
 		WithPropagators(global.Propagators()),
 		WithSpanOptions(trace.WithSpanKind(trace.SpanKindClient)),
 		WithSpanNameFormatter(defaultTransportFormatter),
@@ -57,18 +67,43 @@ func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
 
 	c := NewConfig(append(defaultOpts, opts...)...)
 	t.configure(c)
+	t.createMeasures()
 
 	return &t
 }
 
 func (t *Transport) configure(c *Config) {
 	t.tracer = c.Tracer
+	t.meter = c.Meter
 	t.propagators = c.Propagators
 	t.spanStartOptions = c.SpanStartOptions
 	t.filters = c.Filters
 	t.spanNameFormatter = c.SpanNameFormatter
 }
 
+func (t *Transport) createMeasures() {
+	t.counters = make(map[string]metric.Int64Counter)
+	t.valueRecorders = make(map[string]metric.Int64ValueRecorder)
+	t.upDownCounters = make(map[string]metric.Int64UpDownCounter)
+
+	requestBytesCounter, err := t.meter.NewInt64Counter(ClientRequestContentLength)
+	handleErr(err)
+
+	responseBytesCounter, err := t.meter.NewInt64Counter(ClientResponseContentLength)
+	handleErr(err)
+
+	clientLatencyMeasure, err := t.meter.NewInt64ValueRecorder(ClientLatency)
+	handleErr(err)
+
+	activeRequestsCounter, err := t.meter.NewInt64UpDownCounter(ClientActiveRequests)
+	handleErr(err)
+
+	t.counters[ClientRequestContentLength] = requestBytesCounter
+	t.counters[ClientResponseContentLength] = responseBytesCounter
+	t.valueRecorders[ClientLatency] = clientLatencyMeasure
+	t.upDownCounters[ClientActiveRequests] = activeRequestsCounter
+}
+
 func defaultTransportFormatter(_ string, r *http.Request) string {
 	return r.Method
 }
@@ -77,6 +112,7 @@ func defaultTransportFormatter(_ string, r *http.Request) string {
 // before handing the request to the configured base RoundTripper. The created span will
 // end when the response body is closed or when a read from the body returns io.EOF.
 func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	requestStartTime := time.Now()
 	for _, f := range t.filters {
 		if !f(r) {
 			// Simply pass through to the base RoundTripper if a filter rejects the request
@@ -92,7 +128,12 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	span.SetAttributes(standard.HTTPClientAttributesFromHTTPRequest(r)...)
 	propagation.InjectHTTP(ctx, t.propagators, r.Header)
 
+	labels := standard.HTTPClientMetricAttributesFromHTTPRequest(r)
+	t.upDownCounters[ClientActiveRequests].Add(ctx, 1, labels...)
+	defer t.upDownCounters[ClientActiveRequests].Add(ctx, -1, labels...)
+
 	res, err := t.rt.RoundTrip(r)
+	t.recordMetrics(ctx, requestStartTime, labels, r, res)
 	if err != nil {
 		span.RecordError(ctx, err, trace.WithErrorStatus(codes.Internal))
 		span.End()
@@ -106,6 +147,25 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	return res, err
 }
 
+// recordMetrics records the client metrics of the request r and its
+// response res, which is nil if the request failed.
+func (t *Transport) recordMetrics(ctx context.Context, start time.Time, labels []kv.KeyValue, r *http.Request, res *http.Response) {
+	if res != nil {
+		labels = append(labels, standard.HTTPStatusCodeKey.Int(res.StatusCode))
+	}
+
+	if r.ContentLength > 0 {
+		t.counters[ClientRequestContentLength].Add(ctx, r.ContentLength, labels...)
+	}
+	if res != nil && res.ContentLength > 0 {
+		t.counters[ClientResponseContentLength].Add(ctx, res.ContentLength, labels...)
+	}
+
+	elapsedTime := time.Since(start).Microseconds()
+
+	t.valueRecorders[ClientLatency].Record(ctx, elapsedTime, labels...)
+}
+
 type wrappedBody struct {
 	ctx  context.Context
 	span trace.Span
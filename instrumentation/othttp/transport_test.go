@@ -20,11 +20,18 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/propagation"
+	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/trace"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
 	mocktrace "github.com/Ch1f/otel/internal/trace"
 )
 
@@ -74,3 +81,58 @@ func TestTransportBasics(t *testing.T) {
 		t.Fatalf("unexpected content: got %s, expected %s", body, content)
 	}
 }
+
+func TestTransportMetrics(t *testing.T) {
+	var id uint64
+	tracer := mocktrace.MockTracer{StartSpanID: &id}
+	meterimpl, meter := mockmeter.NewMeter()
+	content := []byte("Hello, world!")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer ts.Close()
+
+	r, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader("request"))
+	require.NoError(t, err)
+
+	c := http.Client{Transport: NewTransport(
+		http.DefaultTransport,
+		WithTracer(&tracer),
+		WithMeter(meter),
+	)}
+	res, err := c.Do(r)
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+
+	labels := []kv.KeyValue{
+		standard.HTTPMethodKey.String(http.MethodPost),
+		standard.HTTPSchemeHTTP,
+		standard.HTTPHostKey.String(r.Host),
+		standard.HTTPFlavorKey.String("1.1"),
+	}
+
+	activeRequests := batchesOf(ClientActiveRequests, meterimpl.MeasurementBatches)
+	require.Len(t, activeRequests, 2)
+	assert.Equal(t, int64(1), activeRequests[0].Measurements[0].Number.AsInt64())
+	assert.Equal(t, int64(-1), activeRequests[1].Measurements[0].Number.AsInt64())
+	assertMetricLabels(t, labels, activeRequests)
+
+	labels = append(labels, standard.HTTPStatusCodeKey.Int(http.StatusCreated))
+	latency := batchesOf(ClientLatency, meterimpl.MeasurementBatches)
+	require.Len(t, latency, 1)
+	assertMetricLabels(t, labels, latency)
+
+	requestBytes := batchesOf(ClientRequestContentLength, meterimpl.MeasurementBatches)
+	require.Len(t, requestBytes, 1)
+	assert.Equal(t, int64(len("request")), requestBytes[0].Measurements[0].Number.AsInt64())
+
+	responseBytes := batchesOf(ClientResponseContentLength, meterimpl.MeasurementBatches)
+	require.Len(t, responseBytes, 1)
+	assert.Equal(t, int64(len(content)), responseBytes[0].Measurements[0].Number.AsInt64())
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runtime provides metrics of the Go runtime: memory and
+// garbage collection statistics, goroutines, cgo calls and uptime.
+//
+// The metrics are reported by asynchronous instruments of a meter, so
+// they are collected by the push or pull controller of the meter's
+// provider:
+//
+//	if err := runtime.Start(
+//	    runtime.WithMinimumReadMemStatsInterval(time.Second),
+//	); err != nil {
+//	    log.Fatal(err)
+//	}
+package runtime // import "github.com/Ch1f/otel/instrumentation/runtime"
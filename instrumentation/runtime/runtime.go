@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"context"
+	goruntime "runtime"
+	"sync"
+	"time"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/unit"
+)
+
+// instrumentationName is the name of this instrumentation package.
+const instrumentationName = "github.com/Ch1f/otel/instrumentation/runtime"
+
+// DefaultMinimumReadMemStatsInterval is the default minimum interval
+// between calls to runtime.ReadMemStats, which stops the world.
+const DefaultMinimumReadMemStatsInterval = 15 * time.Second
+
+// Runtime metrics
+const (
+	Uptime       = "runtime.uptime"               // Milliseconds since Start was called
+	Goroutines   = "runtime.go.goroutines"        // Number of goroutines that currently exist
+	CgoCalls     = "runtime.go.cgo.calls"         // Number of cgo calls made by the process
+	HeapAlloc    = "runtime.go.mem.heap_alloc"    // Bytes of allocated heap objects
+	HeapIdle     = "runtime.go.mem.heap_idle"     // Bytes in idle (unused) heap spans
+	HeapInuse    = "runtime.go.mem.heap_inuse"    // Bytes in in-use heap spans
+	HeapObjects  = "runtime.go.mem.heap_objects"  // Number of allocated heap objects
+	HeapReleased = "runtime.go.mem.heap_released" // Bytes of idle heap spans returned to the OS
+	HeapSys      = "runtime.go.mem.heap_sys"      // Bytes of heap memory obtained from the OS
+	Lookups      = "runtime.go.mem.lookups"       // Number of pointer lookups performed by the runtime
+	LiveObjects  = "runtime.go.mem.live_objects"  // Number of live objects, mallocs minus frees
+	GCCount      = "runtime.go.gc.count"          // Number of completed garbage collection cycles
+	GCPauseTotal = "runtime.go.gc.pause_total"    // Cumulative duration of garbage collection pauses
+	GCPause      = "runtime.go.gc.pause"          // Duration of individual garbage collection pauses
+)
+
+// Option is a function that allows configuration of the runtime
+// metrics.
+type Option func(*config)
+
+type config struct {
+	meter                       metric.Meter
+	minimumReadMemStatsInterval time.Duration
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		meter:                       global.Meter(instrumentationName),
+		minimumReadMemStatsInterval: DefaultMinimumReadMemStatsInterval,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// WithMeter configures a specific meter. If this option isn't specified
+// then the global meter is used.
+func WithMeter(meter metric.Meter) Option {
+	return func(c *config) {
+		c.meter = meter
+	}
+}
+
+// WithMinimumReadMemStatsInterval sets the minimum interval between
+// calls to runtime.ReadMemStats. Memory statistics collected more often
+// report the values of the last read. If this option isn't specified
+// then DefaultMinimumReadMemStatsInterval is used.
+func WithMinimumReadMemStatsInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.minimumReadMemStatsInterval = d
+	}
+}
+
+// runtime holds the instruments and the cached memory statistics.
+type runtime struct {
+	cfg   *config
+	start time.Time
+
+	mu        sync.Mutex
+	lastRead  time.Time
+	memStats  goruntime.MemStats
+	lastNumGC uint32
+
+	uptime       metric.Int64SumObserver
+	goroutines   metric.Int64UpDownSumObserver
+	cgoCalls     metric.Int64SumObserver
+	heapAlloc    metric.Int64UpDownSumObserver
+	heapIdle     metric.Int64UpDownSumObserver
+	heapInuse    metric.Int64UpDownSumObserver
+	heapObjects  metric.Int64UpDownSumObserver
+	heapReleased metric.Int64UpDownSumObserver
+	heapSys      metric.Int64UpDownSumObserver
+	lookups      metric.Int64SumObserver
+	liveObjects  metric.Int64UpDownSumObserver
+	gcCount      metric.Int64SumObserver
+	gcPauseTotal metric.Int64SumObserver
+	gcPause      metric.Int64ValueRecorder
+}
+
+// Start registers the runtime metrics with the configured meter. The
+// metrics are reported each time the meter's provider collects them.
+func Start(opts ...Option) error {
+	r := &runtime{
+		cfg:   newConfig(opts),
+		start: time.Now(),
+	}
+	return r.register()
+}
+
+func (r *runtime) register() error {
+	batch := r.cfg.meter.NewBatchObserver(r.observe)
+
+	var err error
+	for _, inst := range []struct {
+		name      string
+		unit      unit.Unit
+		sum       *metric.Int64SumObserver
+		upDownSum *metric.Int64UpDownSumObserver
+	}{
+		{name: Uptime, unit: unit.Milliseconds, sum: &r.uptime},
+		{name: Goroutines, unit: unit.Dimensionless, upDownSum: &r.goroutines},
+		{name: CgoCalls, unit: unit.Dimensionless, sum: &r.cgoCalls},
+		{name: HeapAlloc, unit: unit.Bytes, upDownSum: &r.heapAlloc},
+		{name: HeapIdle, unit: unit.Bytes, upDownSum: &r.heapIdle},
+		{name: HeapInuse, unit: unit.Bytes, upDownSum: &r.heapInuse},
+		{name: HeapObjects, unit: unit.Dimensionless, upDownSum: &r.heapObjects},
+		{name: HeapReleased, unit: unit.Bytes, upDownSum: &r.heapReleased},
+		{name: HeapSys, unit: unit.Bytes, upDownSum: &r.heapSys},
+		{name: Lookups, unit: unit.Dimensionless, sum: &r.lookups},
+		{name: LiveObjects, unit: unit.Dimensionless, upDownSum: &r.liveObjects},
+		{name: GCCount, unit: unit.Dimensionless, sum: &r.gcCount},
+		{name: GCPauseTotal, unit: unit.Nanoseconds, sum: &r.gcPauseTotal},
+	} {
+		if inst.sum != nil {
+			*inst.sum, err = batch.NewInt64SumObserver(inst.name, metric.WithUnit(inst.unit))
+		} else {
+			*inst.upDownSum, err = batch.NewInt64UpDownSumObserver(inst.name, metric.WithUnit(inst.unit))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	r.gcPause, err = r.cfg.meter.NewInt64ValueRecorder(GCPause, metric.WithUnit(unit.Nanoseconds))
+	return err
+}
+
+// readMemStats reads the memory statistics unless they were read less
+// than the minimum interval ago, returning the pauses of the garbage
+// collections completed since the previous read.
+func (r *runtime) readMemStats(now time.Time) (goruntime.MemStats, []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	first := r.lastRead.IsZero()
+	if !first && now.Sub(r.lastRead) < r.cfg.minimumReadMemStatsInterval {
+		return r.memStats, nil
+	}
+	r.lastRead = now
+	goruntime.ReadMemStats(&r.memStats)
+
+	// Pauses of collections before the first read are only reported in
+	// the pause total.
+	numGC := r.memStats.NumGC
+	if first {
+		r.lastNumGC = numGC
+	}
+
+	// PauseNs is a circular buffer of the most recent pauses.
+	var pauses []uint64
+	if numGC-r.lastNumGC > uint32(len(r.memStats.PauseNs)) {
+		r.lastNumGC = numGC - uint32(len(r.memStats.PauseNs))
+	}
+	for i := r.lastNumGC; i < numGC; i++ {
+		pauses = append(pauses, r.memStats.PauseNs[i%uint32(len(r.memStats.PauseNs))])
+	}
+	r.lastNumGC = numGC
+
+	return r.memStats, pauses
+}
+
+func (r *runtime) observe(ctx context.Context, result metric.BatchObserverResult) {
+	now := time.Now()
+	ms, pauses := r.readMemStats(now)
+
+	result.Observe(nil,
+		r.uptime.Observation(now.Sub(r.start).Milliseconds()),
+		r.goroutines.Observation(int64(goruntime.NumGoroutine())),
+		r.cgoCalls.Observation(goruntime.NumCgoCall()),
+		r.heapAlloc.Observation(int64(ms.HeapAlloc)),
+		r.heapIdle.Observation(int64(ms.HeapIdle)),
+		r.heapInuse.Observation(int64(ms.HeapInuse)),
+		r.heapObjects.Observation(int64(ms.HeapObjects)),
+		r.heapReleased.Observation(int64(ms.HeapReleased)),
+		r.heapSys.Observation(int64(ms.HeapSys)),
+		r.lookups.Observation(int64(ms.Lookups)),
+		r.liveObjects.Observation(int64(ms.Mallocs-ms.Frees)),
+		r.gcCount.Observation(int64(ms.NumGC)),
+		r.gcPauseTotal.Observation(int64(ms.PauseTotalNs)),
+	)
+
+	for _, pause := range pauses {
+		r.gcPause.Record(ctx, int64(pause))
+	}
+}
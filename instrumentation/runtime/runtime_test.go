@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime_test
+
+import (
+	goruntime "runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/unit"
+	"github.com/Ch1f/otel/instrumentation/runtime"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+)
+
+// collect runs the asynchronous instruments and returns the last
+// measurement and the number of measurements of each instrument.
+func collect(impl *mockmeter.MeterImpl) (map[string]int64, map[string]int) {
+	impl.MeasurementBatches = nil
+	impl.RunAsyncInstruments()
+
+	last := map[string]int64{}
+	count := map[string]int{}
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			last[name] = m.Number.AsInt64()
+			count[name]++
+		}
+	}
+	return last, count
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	require.NoError(t, runtime.Start(runtime.WithMeter(meter)))
+
+	got, _ := collect(impl)
+	for _, name := range []string{
+		runtime.Uptime,
+		runtime.Goroutines,
+		runtime.CgoCalls,
+		runtime.HeapAlloc,
+		runtime.HeapIdle,
+		runtime.HeapInuse,
+		runtime.HeapObjects,
+		runtime.HeapReleased,
+		runtime.HeapSys,
+		runtime.Lookups,
+		runtime.LiveObjects,
+		runtime.GCCount,
+		runtime.GCPauseTotal,
+	} {
+		assert.Contains(t, got, name)
+	}
+	assert.True(t, got[runtime.Goroutines] > 0)
+	assert.True(t, got[runtime.HeapAlloc] > 0)
+}
+
+func TestMinimumReadMemStatsInterval(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	require.NoError(t, runtime.Start(
+		runtime.WithMeter(meter),
+		runtime.WithMinimumReadMemStatsInterval(time.Hour),
+	))
+
+	before, _ := collect(impl)
+	goruntime.GC()
+	after, count := collect(impl)
+
+	assert.Equal(t, before[runtime.GCCount], after[runtime.GCCount], "memory statistics were read again")
+	assert.Zero(t, count[runtime.GCPause])
+}
+
+func TestGCPauses(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	require.NoError(t, runtime.Start(
+		runtime.WithMeter(meter),
+		runtime.WithMinimumReadMemStatsInterval(0),
+	))
+
+	before, _ := collect(impl)
+	goruntime.GC()
+	goruntime.GC()
+	after, count := collect(impl)
+
+	gcs := after[runtime.GCCount] - before[runtime.GCCount]
+	assert.True(t, gcs >= 2)
+	assert.Equal(t, int(gcs), count[runtime.GCPause])
+
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			switch d := m.Instrument.Descriptor(); d.Name() {
+			case runtime.GCPause, runtime.GCPauseTotal:
+				assert.Equal(t, unit.Nanoseconds, d.Unit(), d.Name())
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/api/trace"
+)
+
+// instrumentationName is the name of this instrumentation package.
+const instrumentationName = "github.com/Ch1f/otel/instrumentation/sqltrace"
+
+// Option is a function that allows configuration of the wrapped driver
+// and of RecordStats.
+type Option func(*config)
+
+type config struct {
+	tracer    trace.Tracer
+	meter     metric.Meter
+	attrs     []kv.KeyValue
+	sanitizer func(query string) string
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		tracer: global.Tracer(instrumentationName),
+		meter:  global.Meter(instrumentationName),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// WithTracer configures a specific tracer. If this option isn't
+// specified then the global tracer is used.
+func WithTracer(tracer trace.Tracer) Option {
+	return func(c *config) {
+		c.tracer = tracer
+	}
+}
+
+// WithMeter configures a specific meter used by RecordStats. If this
+// option isn't specified then the global meter is used.
+func WithMeter(meter metric.Meter) Option {
+	return func(c *config) {
+		c.meter = meter
+	}
+}
+
+// WithAttributes adds attributes to all spans and metrics, typically
+// describing the database, e.g., standard.DBSystemPostgres and
+// standard.DBNameKey.
+func WithAttributes(attrs ...kv.KeyValue) Option {
+	return func(c *config) {
+		c.attrs = append(c.attrs, attrs...)
+	}
+}
+
+// WithStatementSanitizer configures a function applied to every SQL
+// statement before it is recorded as the db.statement attribute, e.g.,
+// Sanitize. The attribute is omitted if the function returns an empty
+// string. By default statements are recorded as is.
+func WithStatementSanitizer(f func(query string) string) Option {
+	return func(c *config) {
+		c.sanitizer = f
+	}
+}
+
+// start starts a client span named name for the statement query, which
+// may be empty for operations without a statement.
+func (c *config) start(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	attrs := append([]kv.KeyValue{}, c.attrs...)
+	if query != "" {
+		if op := operation(query); op != "" {
+			attrs = append(attrs, standard.DBOperationKey.String(op))
+		}
+		statement := query
+		if c.sanitizer != nil {
+			statement = c.sanitizer(query)
+		}
+		if statement != "" {
+			attrs = append(attrs, standard.DBStatementKey.String(statement))
+		}
+	}
+	return c.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// end ends span, recording err unless it only signals database/sql to
+// use another code path.
+func end(ctx context.Context, span trace.Span, err error) {
+	if err != nil && err != driver.ErrSkip {
+		span.RecordError(ctx, err, trace.WithErrorStatus(codes.Unknown))
+	}
+	span.End()
+}
+
+// operation returns the leading keyword of the SQL statement query in
+// upper case, e.g., SELECT.
+func operation(query string) string {
+	query = strings.TrimLeftFunc(query, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '('
+	})
+	end := strings.IndexFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if end < 0 {
+		end = len(query)
+	}
+	return strings.ToUpper(query[:end])
+}
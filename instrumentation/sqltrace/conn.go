@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+)
+
+// tracedConn wraps a driver.Conn, tracing its operations.
+//
+// The optional interfaces of the wrapped connection are all implemented,
+// falling back to the behavior database/sql has for connections not
+// implementing them.
+type tracedConn struct {
+	conn driver.Conn
+	cfg  *config
+}
+
+var (
+	_ driver.Conn               = (*tracedConn)(nil)
+	_ driver.ConnPrepareContext = (*tracedConn)(nil)
+	_ driver.ConnBeginTx        = (*tracedConn)(nil)
+	_ driver.ExecerContext      = (*tracedConn)(nil)
+	_ driver.QueryerContext     = (*tracedConn)(nil)
+	_ driver.Pinger             = (*tracedConn)(nil)
+	_ driver.SessionResetter    = (*tracedConn)(nil)
+	_ driver.NamedValueChecker  = (*tracedConn)(nil)
+)
+
+func (c *tracedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, span := c.cfg.start(ctx, prepareSpanName, query)
+
+	var stmt driver.Stmt
+	var err error
+	if cp, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = cp.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+		if err == nil {
+			select {
+			case <-ctx.Done():
+				_ = stmt.Close()
+				stmt, err = nil, ctx.Err()
+			default:
+			}
+		}
+	}
+
+	end(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{stmt: stmt, conn: c.conn, query: query, cfg: c.cfg}, nil
+}
+
+func (c *tracedConn) Close() error {
+	return c.conn.Close()
+}
+
+// Begin is deprecated by BeginTx, which database/sql always calls.
+func (c *tracedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	txCtx := ctx
+	ctx, span := c.cfg.start(ctx, beginSpanName, "")
+
+	var tx driver.Tx
+	var err error
+	if cb, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = cb.BeginTx(ctx, opts)
+	} else if opts.Isolation != driver.IsolationLevel(0) || opts.ReadOnly {
+		err = errors.New("sqltrace: driver does not support transaction options")
+	} else {
+		tx, err = c.conn.Begin() //nolint:staticcheck
+	}
+
+	end(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{tx: tx, ctx: txCtx, cfg: c.cfg}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	var exec func() (driver.Result, error)
+	if e, ok := c.conn.(driver.ExecerContext); ok {
+		exec = func() (driver.Result, error) { return e.ExecContext(ctx, query, args) }
+	} else if e, ok := c.conn.(driver.Execer); ok { //nolint:staticcheck
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		exec = func() (driver.Result, error) { return e.Exec(query, values) }
+	} else {
+		// database/sql prepares a statement instead.
+		return nil, driver.ErrSkip
+	}
+
+	ctx, span := c.cfg.start(ctx, execSpanName, query)
+	res, err := exec()
+	end(ctx, span, err)
+	return res, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	var run func(context.Context) (driver.Rows, error)
+	if q, ok := c.conn.(driver.QueryerContext); ok {
+		run = func(ctx context.Context) (driver.Rows, error) { return q.QueryContext(ctx, query, args) }
+	} else if q, ok := c.conn.(driver.Queryer); ok { //nolint:staticcheck
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		run = func(context.Context) (driver.Rows, error) { return q.Query(query, values) }
+	} else {
+		// database/sql prepares a statement instead.
+		return nil, driver.ErrSkip
+	}
+	return traceQuery(ctx, c.cfg, query, run)
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	p, ok := c.conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	ctx, span := c.cfg.start(ctx, pingSpanName, "")
+	err := p.Ping(ctx)
+	end(ctx, span, err)
+	return err
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := c.conn.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	// database/sql applies its default conversion.
+	return driver.ErrSkip
+}
+
+// traceQuery runs query in a span named after the query operation, the
+// returned rows are traced until closed.
+func traceQuery(ctx context.Context, cfg *config, query string, run func(context.Context) (driver.Rows, error)) (driver.Rows, error) {
+	queryCtx, span := cfg.start(ctx, querySpanName, query)
+	rows, err := run(queryCtx)
+	end(queryCtx, span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsCtx, rowsSpan := cfg.start(ctx, rowsSpanName, "")
+	return &tracedRows{rows: rows, ctx: rowsCtx, span: rowsSpan}, nil
+}
+
+// namedValuesToValues converts args for the deprecated interfaces not
+// supporting named arguments.
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("sqltrace: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
+
+// tracedTx wraps a driver.Tx, tracing its commit or rollback as part of
+// the context the transaction began in.
+type tracedTx struct {
+	tx  driver.Tx
+	ctx context.Context
+	cfg *config
+}
+
+var _ driver.Tx = (*tracedTx)(nil)
+
+func (t *tracedTx) Commit() error {
+	ctx, span := t.cfg.start(t.ctx, commitSpanName, "")
+	err := t.tx.Commit()
+	end(ctx, span, err)
+	return err
+}
+
+func (t *tracedTx) Rollback() error {
+	ctx, span := t.cfg.start(t.ctx, rollbackSpanName, "")
+	err := t.tx.Rollback()
+	end(ctx, span, err)
+	return err
+}
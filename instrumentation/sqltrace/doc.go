@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqltrace provides tracing and metrics for database/sql by
+// wrapping a database driver.
+//
+// A driver.Connector is wrapped with WrapConnector and opened with
+// sql.OpenDB, a driver.Driver is wrapped with WrapDriver and registered
+// with sql.Register:
+//
+//	db := sql.OpenDB(sqltrace.WrapConnector(connector,
+//	    sqltrace.WithAttributes(standard.DBSystemPostgres),
+//	    sqltrace.WithStatementSanitizer(sqltrace.Sanitize),
+//	))
+//
+// Every Query, Exec, Prepare, Begin, Commit and Rollback of the
+// wrapped driver is traced with a client span carrying the db.*
+// semantic convention attributes, the iteration of the returned rows
+// is traced with a span ending when the rows are closed.
+//
+// The connection pool statistics of a sql.DB are recorded with
+// RecordStats.
+package sqltrace // import "github.com/Ch1f/otel/instrumentation/sqltrace"
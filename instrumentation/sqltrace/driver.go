@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Span names of the traced operations.
+const (
+	connectSpanName  = "sql.connect"
+	pingSpanName     = "sql.ping"
+	prepareSpanName  = "sql.prepare"
+	execSpanName     = "sql.exec"
+	querySpanName    = "sql.query"
+	rowsSpanName     = "sql.rows"
+	beginSpanName    = "sql.begin"
+	commitSpanName   = "sql.commit"
+	rollbackSpanName = "sql.rollback"
+)
+
+// tracedDriver wraps a driver.Driver, tracing the connections it opens.
+type tracedDriver struct {
+	driver driver.Driver
+	cfg    *config
+}
+
+var (
+	_ driver.Driver        = (*tracedDriver)(nil)
+	_ driver.DriverContext = (*tracedDriver)(nil)
+)
+
+// WrapDriver returns a driver.Driver tracing the operations of d. The
+// returned driver can be registered with sql.Register to be opened by
+// name.
+func WrapDriver(d driver.Driver, opts ...Option) driver.Driver {
+	return &tracedDriver{driver: d, cfg: newConfig(opts)}
+}
+
+// Open returns a traced connection opened by the wrapped driver.
+func (d *tracedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn: c, cfg: d.cfg}, nil
+}
+
+// OpenConnector returns a traced connector of the wrapped driver.
+func (d *tracedDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &tracedConnector{connector: c, driver: d, cfg: d.cfg}, nil
+	}
+	return &tracedConnector{
+		connector: dsnConnector{name: name, driver: d.driver},
+		driver:    d,
+		cfg:       d.cfg,
+	}, nil
+}
+
+// dsnConnector is a driver.Connector opening connections of a driver
+// without its own connector by name.
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// tracedConnector wraps a driver.Connector, tracing the connections it
+// opens.
+type tracedConnector struct {
+	connector driver.Connector
+	driver    driver.Driver
+	cfg       *config
+}
+
+var _ driver.Connector = (*tracedConnector)(nil)
+
+// WrapConnector returns a driver.Connector tracing the operations of c.
+// The returned connector is opened with sql.OpenDB.
+func WrapConnector(c driver.Connector, opts ...Option) driver.Connector {
+	cfg := newConfig(opts)
+	return &tracedConnector{
+		connector: c,
+		driver:    &tracedDriver{driver: c.Driver(), cfg: cfg},
+		cfg:       cfg,
+	}
+}
+
+// Connect returns a traced connection of the wrapped connector.
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	ctx, span := c.cfg.start(ctx, connectSpanName, "")
+	conn, err := c.connector.Connect(ctx)
+	end(ctx, span, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{conn: conn, cfg: c.cfg}, nil
+}
+
+// Driver returns the traced driver of the wrapped connector.
+func (c *tracedConnector) Driver() driver.Driver {
+	return c.driver
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+)
+
+// errFake is returned by the fake driver for statements containing FAIL.
+var errFake = errors.New("fake failure")
+
+// fakeDriver is an in-memory driver.Driver. Its queries return two rows
+// of a single column and its statements fail if they contain FAIL.
+type fakeDriver struct {
+	// basic makes the driver open connections implementing only the
+	// required driver.Conn methods.
+	basic bool
+	// invalid makes the connections report that they cannot be
+	// reused.
+	invalid bool
+	// badSession makes the connections fail to reset their session.
+	badSession bool
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) {
+	if d.basic {
+		return &basicConn{}, nil
+	}
+	return &fakeConn{invalid: d.invalid, badSession: d.badSession}, nil
+}
+
+type fakeConnector struct {
+	driver fakeDriver
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open("")
+}
+
+func (c fakeConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// basicConn implements only the required methods of driver.Conn.
+type basicConn struct{}
+
+func (c *basicConn) Prepare(query string) (driver.Stmt, error) {
+	if strings.Contains(query, "FAIL PREPARE") {
+		return nil, errFake
+	}
+	return &fakeStmt{query: query}, nil
+}
+
+func (c *basicConn) Close() error { return nil }
+
+func (c *basicConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+// fakeConn implements the context aware optional interfaces.
+type fakeConn struct {
+	basicConn
+	invalid    bool
+	badSession bool
+}
+
+func (c *fakeConn) IsValid() bool { return !c.invalid }
+
+func (c *fakeConn) ResetSession(context.Context) error {
+	if c.badSession {
+		return driver.ErrBadConn
+	}
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return exec(query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	return queryRows(query)
+}
+
+func exec(query string) (driver.Result, error) {
+	if strings.Contains(query, "FAIL") {
+		return nil, errFake
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func queryRows(query string) (driver.Rows, error) {
+	if strings.Contains(query, "FAIL") {
+		return nil, errFake
+	}
+	return &fakeRows{values: []string{"a", "b"}}, nil
+}
+
+type fakeStmt struct {
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return exec(s.query)
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return queryRows(s.query)
+}
+
+type fakeRows struct {
+	values []string
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"strings"
+)
+
+// Sanitize returns the SQL statement query with its string and numeric
+// literals replaced by a ? placeholder and its comments removed, so it
+// can be recorded without exposing the values it contains.
+//
+// Quoted identifiers are kept as is. For example
+//
+//	SELECT * FROM "users" WHERE name = 'alice' AND age > 30 -- adults
+//
+// is sanitized to
+//
+//	SELECT * FROM "users" WHERE name = ? AND age > ?
+func Sanitize(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'':
+			// String literal, quotes are escaped by doubling them.
+			i++
+			for i < len(query) {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+			b.WriteByte('?')
+		case c == '"' || c == '`':
+			// Quoted identifier.
+			end := len(query)
+			if j := strings.IndexByte(query[i+1:], c); j >= 0 {
+				end = i + j + 2
+			}
+			b.WriteString(query[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			j := strings.IndexByte(query[i:], '\n')
+			if j < 0 {
+				j = len(query) - i
+			}
+			i += j
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			j := strings.Index(query[i+2:], "*/")
+			if j < 0 {
+				i = len(query)
+			} else {
+				i += j + 4
+			}
+		case isDigit(c) && (i == 0 || !isIdentifier(query[i-1])):
+			// Numeric literal, including decimal, exponent and hex forms.
+			for i < len(query) && (isIdentifier(query[i]) || query[i] == '.') {
+				i++
+			}
+			b.WriteByte('?')
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifier(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
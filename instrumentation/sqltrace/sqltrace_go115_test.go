@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.15
+// +build go1.15
+
+package sqltrace_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/instrumentation/sqltrace"
+)
+
+func TestInvalidConnectionsAreNotReused(t *testing.T) {
+	for _, invalid := range []bool{false, true} {
+		exp, tracer := newTracer(t)
+		db := sql.OpenDB(sqltrace.WrapConnector(fakeConnector{driver: fakeDriver{invalid: invalid}},
+			sqltrace.WithTracer(tracer),
+		))
+
+		for i := 0; i < 2; i++ {
+			_, err := db.Exec("INSERT INTO t VALUES (1)")
+			require.NoError(t, err)
+		}
+		require.NoError(t, db.Close())
+
+		connects := countSpans(exp, "sql.connect")
+		if invalid {
+			assert.Equal(t, 2, connects, "invalid connections are reused")
+		} else {
+			assert.Equal(t, 1, connects, "valid connections are not reused")
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/instrumentation/sqltrace"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+type testExporter struct {
+	mu    sync.Mutex
+	spans []*export.SpanData
+}
+
+func (e *testExporter) ExportSpan(_ context.Context, s *export.SpanData) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, s)
+}
+
+func (e *testExporter) names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var names []string
+	for _, s := range e.spans {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func (e *testExporter) span(t *testing.T, name string) *export.SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range e.spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("no span named %q", name)
+	return nil
+}
+
+func newTracer(t *testing.T) (*testExporter, trace.Tracer) {
+	exp := &testExporter{}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+	)
+	require.NoError(t, err)
+	return exp, tp.Tracer("sqltrace-test")
+}
+
+var registerOnce sync.Once
+
+func TestWrapDriverQuery(t *testing.T) {
+	exp, tracer := newTracer(t)
+	registerOnce.Do(func() {
+		sql.Register("sqltrace-fake", sqltrace.WrapDriver(fakeDriver{},
+			sqltrace.WithTracer(tracer),
+			sqltrace.WithAttributes(standard.DBSystemSqlite),
+		))
+	})
+	db, err := sql.Open("sqltrace-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	rows, err := db.QueryContext(ctx, "SELECT value FROM t WHERE id = ?", 1)
+	require.NoError(t, err)
+	var values []string
+	for rows.Next() {
+		var v string
+		require.NoError(t, rows.Scan(&v))
+		values = append(values, v)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	parent.End()
+
+	assert.Equal(t, []string{"a", "b"}, values)
+
+	query := exp.span(t, "sql.query")
+	assert.Equal(t, trace.SpanKindClient, query.SpanKind)
+	assert.Equal(t, parent.SpanContext().SpanID, query.ParentSpanID)
+	assert.Contains(t, query.Attributes, standard.DBSystemSqlite)
+	assert.Contains(t, query.Attributes, standard.DBOperationKey.String("SELECT"))
+	assert.Contains(t, query.Attributes, standard.DBStatementKey.String("SELECT value FROM t WHERE id = ?"))
+
+	rowsSpan := exp.span(t, "sql.rows")
+	assert.Equal(t, parent.SpanContext().SpanID, rowsSpan.ParentSpanID)
+	assert.Contains(t, rowsSpan.Attributes, sqltrace.RowsKey.Int64(2))
+}
+
+func TestWrapConnectorExecAndTx(t *testing.T) {
+	exp, tracer := newTracer(t)
+	db := sql.OpenDB(sqltrace.WrapConnector(fakeConnector{},
+		sqltrace.WithTracer(tracer),
+		sqltrace.WithStatementSanitizer(sqltrace.Sanitize),
+	))
+	defer db.Close()
+	ctx := context.Background()
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, "INSERT INTO t VALUES ('secret', 42)")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	stmt, err := db.PrepareContext(ctx, "UPDATE t SET v = 1")
+	require.NoError(t, err)
+	_, err = stmt.ExecContext(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Close())
+
+	assert.Equal(t, []string{"sql.connect", "sql.begin", "sql.exec", "sql.commit", "sql.prepare", "sql.exec"}, exp.names())
+
+	insert := exp.span(t, "sql.exec")
+	assert.Contains(t, insert.Attributes, standard.DBOperationKey.String("INSERT"))
+	assert.Contains(t, insert.Attributes, standard.DBStatementKey.String("INSERT INTO t VALUES (?, ?)"))
+}
+
+func TestFallbackToPrepare(t *testing.T) {
+	exp, tracer := newTracer(t)
+	db := sql.OpenDB(sqltrace.WrapConnector(fakeConnector{driver: fakeDriver{basic: true}},
+		sqltrace.WithTracer(tracer),
+	))
+	defer db.Close()
+
+	rows, err := db.Query("SELECT value FROM t")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	assert.Equal(t, []string{"sql.connect", "sql.prepare", "sql.query", "sql.rows"}, exp.names())
+}
+
+// countSpans returns the number of exported spans named name.
+func countSpans(exp *testExporter, name string) int {
+	n := 0
+	for _, s := range exp.names() {
+		if s == name {
+			n++
+		}
+	}
+	return n
+}
+
+func TestResetSession(t *testing.T) {
+	for _, bad := range []bool{false, true} {
+		exp, tracer := newTracer(t)
+		db := sql.OpenDB(sqltrace.WrapConnector(fakeConnector{driver: fakeDriver{badSession: bad}},
+			sqltrace.WithTracer(tracer),
+		))
+
+		for i := 0; i < 2; i++ {
+			_, err := db.Exec("INSERT INTO t VALUES (1)")
+			require.NoError(t, err)
+		}
+		require.NoError(t, db.Close())
+
+		want := 1
+		if bad {
+			// The connection failing to reset is replaced.
+			want = 2
+		}
+		assert.Equal(t, want, countSpans(exp, "sql.connect"))
+	}
+}
+
+func TestErrors(t *testing.T) {
+	exp, tracer := newTracer(t)
+	db := sql.OpenDB(sqltrace.WrapConnector(fakeConnector{}, sqltrace.WithTracer(tracer)))
+	defer db.Close()
+
+	_, err := db.Exec("FAIL")
+	require.Error(t, err)
+
+	exec := exp.span(t, "sql.exec")
+	assert.Equal(t, codes.Unknown, exec.StatusCode)
+	require.Len(t, exec.MessageEvents, 1)
+	assert.Equal(t, "error", exec.MessageEvents[0].Name)
+}
+
+func TestSanitize(t *testing.T) {
+	for _, test := range []struct {
+		query, want string
+	}{
+		{
+			query: "SELECT * FROM \"users\" WHERE name = 'alice' AND age > 30 -- adults",
+			want:  "SELECT * FROM \"users\" WHERE name = ? AND age > ?",
+		},
+		{
+			query: "INSERT INTO t2 (a, b) VALUES ('it''s', 1.5e3), (0x1F, -2)",
+			want:  "INSERT INTO t2 (a, b) VALUES (?, ?), (?, -?)",
+		},
+		{
+			query: "SELECT /* hint 42 */ `col1` FROM t WHERE id = $1",
+			want:  "SELECT  `col1` FROM t WHERE id = $1",
+		},
+	} {
+		assert.Equal(t, test.want, sqltrace.Sanitize(test.query), test.query)
+	}
+}
+
+func TestRecordStats(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	db := sql.OpenDB(fakeConnector{})
+	defer db.Close()
+	db.SetMaxOpenConns(3)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, sqltrace.RecordStats(db,
+		sqltrace.WithMeter(meter),
+		sqltrace.WithAttributes(standard.DBNameKey.String("test")),
+	))
+	impl.RunAsyncInstruments()
+
+	observed := map[string]map[kv.KeyValue]int64{}
+	for _, batch := range impl.MeasurementBatches {
+		assert.Contains(t, batch.Labels, standard.DBNameKey.String("test"))
+		for _, m := range batch.Measurements {
+			name := m.Instrument.Descriptor().Name()
+			if observed[name] == nil {
+				observed[name] = map[kv.KeyValue]int64{}
+			}
+			label := kv.KeyValue{}
+			if len(batch.Labels) > 1 {
+				label = batch.Labels[1]
+			}
+			observed[name][label] = m.Number.AsInt64()
+		}
+	}
+
+	assert.Equal(t, int64(3), observed[sqltrace.ConnectionsMaxOpen][kv.KeyValue{}])
+	assert.Equal(t, int64(1), observed[sqltrace.ConnectionsOpen][sqltrace.StateKey.String("in_use")])
+	assert.Equal(t, int64(0), observed[sqltrace.ConnectionsOpen][sqltrace.StateKey.String("idle")])
+	assert.Equal(t, int64(0), observed[sqltrace.ConnectionsClosed][sqltrace.ReasonKey.String("max_lifetime")])
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/unit"
+)
+
+// Connection pool metrics
+const (
+	ConnectionsMaxOpen      = "db.sql.connections.max_open"      // Maximum number of open connections
+	ConnectionsOpen         = "db.sql.connections.open"          // Number of open connections, by state
+	ConnectionsWaitCount    = "db.sql.connections.wait_count"    // Total number of waits for a connection
+	ConnectionsWaitDuration = "db.sql.connections.wait_duration" // Total time waited for a connection, milliseconds
+	ConnectionsClosed       = "db.sql.connections.closed"        // Total number of connections closed, by reason
+)
+
+// Label keys of the connection pool metrics.
+const (
+	StateKey  = kv.Key("state")
+	ReasonKey = kv.Key("reason")
+)
+
+// RecordStats records the connection pool statistics of db, as returned
+// by db.Stats, through asynchronous instruments of the meter configured
+// with WithMeter. The statistics are read once per collection.
+func RecordStats(db *sql.DB, opts ...Option) error {
+	c := newConfig(opts)
+
+	var (
+		maxOpen      metric.Int64ValueObserver
+		open         metric.Int64UpDownSumObserver
+		waitCount    metric.Int64SumObserver
+		waitDuration metric.Int64SumObserver
+		closed       metric.Int64SumObserver
+	)
+
+	batch := c.meter.NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		stats := db.Stats()
+
+		result.Observe(c.attrs,
+			maxOpen.Observation(int64(stats.MaxOpenConnections)),
+			waitCount.Observation(stats.WaitCount),
+			waitDuration.Observation(stats.WaitDuration.Milliseconds()),
+		)
+		result.Observe(labels(c.attrs, StateKey.String("in_use")),
+			open.Observation(int64(stats.InUse)))
+		result.Observe(labels(c.attrs, StateKey.String("idle")),
+			open.Observation(int64(stats.Idle)))
+		result.Observe(labels(c.attrs, ReasonKey.String("max_idle")),
+			closed.Observation(stats.MaxIdleClosed))
+		result.Observe(labels(c.attrs, ReasonKey.String("max_lifetime")),
+			closed.Observation(stats.MaxLifetimeClosed))
+	})
+
+	var err error
+	if maxOpen, err = batch.NewInt64ValueObserver(ConnectionsMaxOpen,
+		metric.WithUnit(unit.Dimensionless)); err != nil {
+		return err
+	}
+	if open, err = batch.NewInt64UpDownSumObserver(ConnectionsOpen,
+		metric.WithUnit(unit.Dimensionless)); err != nil {
+		return err
+	}
+	if waitCount, err = batch.NewInt64SumObserver(ConnectionsWaitCount,
+		metric.WithUnit(unit.Dimensionless)); err != nil {
+		return err
+	}
+	if waitDuration, err = batch.NewInt64SumObserver(ConnectionsWaitDuration,
+		metric.WithUnit(unit.Milliseconds)); err != nil {
+		return err
+	}
+	if closed, err = batch.NewInt64SumObserver(ConnectionsClosed,
+		metric.WithUnit(unit.Dimensionless)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// labels returns attrs extended with extra, without modifying attrs.
+func labels(attrs []kv.KeyValue, extra ...kv.KeyValue) []kv.KeyValue {
+	return append(attrs[:len(attrs):len(attrs)], extra...)
+}
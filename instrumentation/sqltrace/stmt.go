@@ -0,0 +1,211 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqltrace
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"reflect"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/trace"
+)
+
+// RowsKey is the attribute key of the number of rows iterated, recorded
+// on the span of the rows returned by a query.
+const RowsKey = kv.Key("db.sql.rows")
+
+// tracedStmt wraps a driver.Stmt, tracing its executions.
+type tracedStmt struct {
+	stmt  driver.Stmt
+	conn  driver.Conn
+	query string
+	cfg   *config
+}
+
+var (
+	_ driver.Stmt              = (*tracedStmt)(nil)
+	_ driver.StmtExecContext   = (*tracedStmt)(nil)
+	_ driver.StmtQueryContext  = (*tracedStmt)(nil)
+	_ driver.NamedValueChecker = (*tracedStmt)(nil)
+)
+
+func (s *tracedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *tracedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+// Exec is deprecated by ExecContext, which database/sql always calls.
+func (s *tracedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+// Query is deprecated by QueryContext, which database/sql always calls.
+func (s *tracedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	ctx, span := s.cfg.start(ctx, execSpanName, s.query)
+
+	var res driver.Result
+	var err error
+	if e, ok := s.stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			res, err = s.stmt.Exec(values) //nolint:staticcheck
+		}
+	}
+
+	end(ctx, span, err)
+	return res, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return traceQuery(ctx, s.cfg, s.query, func(ctx context.Context) (driver.Rows, error) {
+		if q, ok := s.stmt.(driver.StmtQueryContext); ok {
+			return q.QueryContext(ctx, args)
+		}
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return s.stmt.Query(values) //nolint:staticcheck
+	})
+}
+
+func (s *tracedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nvc, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	if nvc, ok := s.conn.(driver.NamedValueChecker); ok {
+		return nvc.CheckNamedValue(nv)
+	}
+	// database/sql applies its default conversion.
+	return driver.ErrSkip
+}
+
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// tracedRows wraps driver.Rows, counting the rows iterated until the
+// rows are closed and span ends.
+//
+// The optional interfaces of the wrapped rows are all implemented,
+// falling back to the behavior database/sql has for rows not
+// implementing them.
+type tracedRows struct {
+	rows  driver.Rows
+	ctx   context.Context
+	span  trace.Span
+	count int64
+	err   error
+}
+
+var (
+	_ driver.Rows                           = (*tracedRows)(nil)
+	_ driver.RowsNextResultSet              = (*tracedRows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*tracedRows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*tracedRows)(nil)
+	_ driver.RowsColumnTypeLength           = (*tracedRows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*tracedRows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*tracedRows)(nil)
+)
+
+func (r *tracedRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *tracedRows) Close() error {
+	err := r.rows.Close()
+	r.span.SetAttributes(RowsKey.Int64(r.count))
+	if err == nil {
+		err = r.err
+	}
+	end(r.ctx, r.span, err)
+	return err
+}
+
+func (r *tracedRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	switch err {
+	case nil:
+		r.count++
+	case io.EOF:
+	default:
+		r.err = err
+	}
+	return err
+}
+
+func (r *tracedRows) HasNextResultSet() bool {
+	if rs, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return rs.HasNextResultSet()
+	}
+	return false
+}
+
+func (r *tracedRows) NextResultSet() error {
+	if rs, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return rs.NextResultSet()
+	}
+	return io.EOF
+}
+
+func (r *tracedRows) ColumnTypeScanType(index int) reflect.Type {
+	if ct, ok := r.rows.(driver.RowsColumnTypeScanType); ok {
+		return ct.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(interface{})).Elem()
+}
+
+func (r *tracedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if ct, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return ct.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+func (r *tracedRows) ColumnTypeLength(index int) (int64, bool) {
+	if ct, ok := r.rows.(driver.RowsColumnTypeLength); ok {
+		return ct.ColumnTypeLength(index)
+	}
+	return 0, false
+}
+
+func (r *tracedRows) ColumnTypeNullable(index int) (bool, bool) {
+	if ct, ok := r.rows.(driver.RowsColumnTypeNullable); ok {
+		return ct.ColumnTypeNullable(index)
+	}
+	return false, false
+}
+
+func (r *tracedRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	if ct, ok := r.rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return ct.ColumnTypePrecisionScale(index)
+	}
+	return 0, 0, false
+}
@@ -51,8 +51,13 @@ type AsyncInstrumentState struct {
 	// instrument, ensuring that when a singleton callback is used
 	// repeatedly, it is excuted repeatedly in the interval, while
 	// when a batch callback is used repeatedly, it only executes
-	// once per interval.
-	runnerMap map[asyncRunnerPair]struct{}
+	// once per interval.  The value counts the instruments
+	// registered with each runner.
+	runnerMap map[asyncRunnerPair]int
+
+	// instrumentMap maps each registered instrument to its entry
+	// in runnerMap, to support Unregister.
+	instrumentMap map[metric.AsyncImpl]asyncRunnerPair
 
 	// runners maintains the set of runners in the order they were
 	// registered.
@@ -79,7 +84,8 @@ type asyncRunnerPair struct {
 // the correct order.
 func NewAsyncInstrumentState() *AsyncInstrumentState {
 	return &AsyncInstrumentState{
-		runnerMap: map[asyncRunnerPair]struct{}{},
+		runnerMap:     map[asyncRunnerPair]int{},
+		instrumentMap: map[metric.AsyncImpl]asyncRunnerPair{},
 	}
 }
 
@@ -115,9 +121,51 @@ func (a *AsyncInstrumentState) Register(inst metric.AsyncImpl, runner metric.Asy
 	}
 
 	if _, ok := a.runnerMap[rp]; !ok {
-		a.runnerMap[rp] = struct{}{}
 		a.runners = append(a.runners, rp)
 	}
+	a.runnerMap[rp]++
+	a.instrumentMap[inst] = rp
+}
+
+// Unregister removes an asynchronous instrument from the set managed
+// by this object.  The runner of the instrument is removed once no
+// other instrument refers to it.  Returns false if the instrument was
+// not registered.
+func (a *AsyncInstrumentState) Unregister(inst metric.AsyncImpl) bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	rp, ok := a.instrumentMap[inst]
+	if !ok {
+		return false
+	}
+	delete(a.instrumentMap, inst)
+
+	// The slices are copied rather than modified in place because
+	// Instruments() and Run() return them to callers that do not
+	// hold the lock.
+	instruments := make([]metric.AsyncImpl, 0, len(a.instruments)-1)
+	for _, i := range a.instruments {
+		if i != inst {
+			instruments = append(instruments, i)
+		}
+	}
+	a.instruments = instruments
+
+	a.runnerMap[rp]--
+	if a.runnerMap[rp] > 0 {
+		return true
+	}
+	delete(a.runnerMap, rp)
+
+	runners := make([]asyncRunnerPair, 0, len(a.runners)-1)
+	for _, r := range a.runners {
+		if r != rp {
+			runners = append(runners, r)
+		}
+	}
+	a.runners = runners
+	return true
 }
 
 // Run executes the complete set of observer callbacks.
@@ -69,10 +69,11 @@ type (
 )
 
 var (
-	_ apimetric.SyncImpl      = &Sync{}
-	_ apimetric.BoundSyncImpl = &Handle{}
-	_ apimetric.MeterImpl     = &MeterImpl{}
-	_ apimetric.AsyncImpl     = &Async{}
+	_ apimetric.SyncImpl          = &Sync{}
+	_ apimetric.BoundSyncImpl     = &Handle{}
+	_ apimetric.MeterImpl         = &MeterImpl{}
+	_ apimetric.AsyncUnregisterer = &MeterImpl{}
+	_ apimetric.AsyncImpl         = &Async{}
 )
 
 func (i Instrument) Descriptor() apimetric.Descriptor {
@@ -151,6 +152,11 @@ func (m *MeterImpl) NewAsyncInstrument(descriptor metric.Descriptor, runner metr
 	return a, nil
 }
 
+func (m *MeterImpl) UnregisterAsyncInstrument(inst apimetric.AsyncImpl) error {
+	m.asyncInstruments.Unregister(inst)
+	return nil
+}
+
 func (m *MeterImpl) RecordBatch(ctx context.Context, labels []kv.KeyValue, measurements ...apimetric.Measurement) {
 	mm := make([]Measurement, len(measurements))
 	for i := 0; i < len(measurements); i++ {
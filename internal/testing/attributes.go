@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+)
+
+// CompositeAttributes returns attributes holding an ARRAY value of each
+// element type and a nested MAP value, used to check how exporters
+// encode them.
+func CompositeAttributes() []kv.KeyValue {
+	return []kv.KeyValue{
+		kv.Array("bools", []bool{true, false}),
+		kv.Array("ints", []int{1, -2}),
+		kv.Array("floats", []float64{1.5, -2}),
+		kv.Array("strings", []string{"a", "b,c"}),
+		kv.Map("map", map[string]value.Value{
+			"string": value.String("d"),
+			"nested": value.Map(map[string]value.Value{
+				"ints": value.Int64Array([]int64{3}),
+			}),
+		}),
+	}
+}
+
+// CompositeAttributesJSON is the JSON encoding of the values of
+// CompositeAttributes, by key, for exporters without composite types.
+var CompositeAttributesJSON = map[string]string{
+	"bools":   `[true,false]`,
+	"ints":    `[1,-2]`,
+	"floats":  `[1.5,-2]`,
+	"strings": `["a","b,c"]`,
+	"map":     `{"nested":{"ints":[3]},"string":"d"}`,
+}
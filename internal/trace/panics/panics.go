@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package panics records recovered panics on spans.
+package panics // import "github.com/Ch1f/otel/internal/trace/panics"
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/api/trace"
+
+	"google.golang.org/grpc/codes"
+)
+
+// EventName is the name of the span event recording a panic.
+const EventName = "panic"
+
+// Record adds a panic event with the recovered value and the stack
+// trace of the panicking goroutine to span, and sets its status to
+// codes.Internal. It must be called from the deferred function that
+// recovered the value so the stack trace includes the panic site.
+func Record(ctx context.Context, span trace.Span, recovered interface{}) {
+	msg := fmt.Sprint(recovered)
+	span.AddEvent(ctx, EventName,
+		standard.ExceptionMessageKey.String(msg),
+		standard.ExceptionStacktraceKey.String(string(debug.Stack())),
+	)
+	span.SetStatus(codes.Internal, "panic: "+msg)
+}
@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoconfig
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/propagation"
+	"github.com/Ch1f/otel/api/standard"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/exporters/metric/prometheus"
+	metricstdout "github.com/Ch1f/otel/exporters/metric/stdout"
+	"github.com/Ch1f/otel/exporters/otlp"
+	"github.com/Ch1f/otel/exporters/trace/jaeger"
+	tracestdout "github.com/Ch1f/otel/exporters/trace/stdout"
+	"github.com/Ch1f/otel/exporters/trace/zipkin"
+	"github.com/Ch1f/otel/sdk/metric/controller/pull"
+	"github.com/Ch1f/otel/sdk/metric/controller/push"
+	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+	"github.com/Ch1f/otel/sdk/resource"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// Pipeline holds the trace provider, meter provider and propagators
+// built from a Config, along with the exporters backing them.
+type Pipeline struct {
+	traceProvider apitrace.Provider
+	meterProvider metric.Provider
+	propagators   propagation.Propagators
+
+	otlp *otlp.Exporter
+
+	// shutdown holds the functions releasing the pipeline
+	// components, in the order the components were created.
+	shutdown []func()
+}
+
+// Install reads the configuration from the environment, builds a
+// Pipeline and registers its providers and propagators globally.  The
+// returned function flushes and stops the pipeline; it should be called
+// before the program exits.
+//
+// If OTEL_SDK_DISABLED is true the global no-op implementations are left
+// in place.
+func Install() (func(), error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Disabled {
+		return func() {}, nil
+	}
+	p, err := NewPipeline(cfg)
+	if err != nil {
+		return nil, err
+	}
+	global.SetTraceProvider(p.TraceProvider())
+	global.SetMeterProvider(p.MeterProvider())
+	global.SetPropagators(p.Propagators())
+	return p.Shutdown, nil
+}
+
+// NewPipeline builds a Pipeline from cfg.  The pipeline is not
+// registered globally.
+func NewPipeline(cfg Config) (*Pipeline, error) {
+	p := &Pipeline{
+		propagators: cfg.Propagators,
+	}
+	if p.propagators == nil {
+		p.propagators = propagation.New()
+	}
+	if err := p.setupTraces(cfg); err != nil {
+		p.Shutdown()
+		return nil, err
+	}
+	if err := p.setupMetrics(cfg); err != nil {
+		p.Shutdown()
+		return nil, err
+	}
+	return p, nil
+}
+
+// TraceProvider returns the trace provider of the pipeline.
+func (p *Pipeline) TraceProvider() apitrace.Provider {
+	return p.traceProvider
+}
+
+// MeterProvider returns the meter provider of the pipeline.
+func (p *Pipeline) MeterProvider() metric.Provider {
+	return p.meterProvider
+}
+
+// Propagators returns the propagators of the pipeline.
+func (p *Pipeline) Propagators() propagation.Propagators {
+	return p.propagators
+}
+
+// Shutdown flushes any buffered telemetry and stops the exporters.
+func (p *Pipeline) Shutdown() {
+	for i := len(p.shutdown) - 1; i >= 0; i-- {
+		p.shutdown[i]()
+	}
+	p.shutdown = nil
+}
+
+func (p *Pipeline) onShutdown(f func()) {
+	p.shutdown = append(p.shutdown, f)
+}
+
+// otlpExporter returns the OTLP exporter shared by the trace and metric
+// pipelines, creating it on first use.
+func (p *Pipeline) otlpExporter(cfg Config) (*otlp.Exporter, error) {
+	if p.otlp != nil {
+		return p.otlp, nil
+	}
+	var opts []otlp.ExporterOption
+	if cfg.OTLPEndpoint != "" {
+		opts = append(opts, otlp.WithAddress(cfg.OTLPEndpoint))
+	}
+	if len(cfg.OTLPHeaders) > 0 {
+		opts = append(opts, otlp.WithHeaders(cfg.OTLPHeaders))
+	}
+	if cfg.OTLPInsecure {
+		opts = append(opts, otlp.WithInsecure())
+	}
+	exp, err := otlp.NewExporter(opts...)
+	if err != nil {
+		return nil, err
+	}
+	p.otlp = exp
+	p.onShutdown(func() {
+		if err := exp.Stop(); err != nil {
+			global.Handle(err)
+		}
+	})
+	return exp, nil
+}
+
+func (p *Pipeline) setupTraces(cfg Config) error {
+	var ssp sdktrace.SpanProcessor
+	switch cfg.TracesExporter {
+	case ExporterNone:
+		p.traceProvider = apitrace.NoopProvider{}
+		return nil
+	case ExporterStdout:
+		exp, err := tracestdout.NewExporter(tracestdout.Options{})
+		if err != nil {
+			return err
+		}
+		ssp = sdktrace.NewSimpleSpanProcessor(exp)
+	case ExporterOTLP:
+		exp, err := p.otlpExporter(cfg)
+		if err != nil {
+			return err
+		}
+		if ssp, err = sdktrace.NewBatchSpanProcessor(exp, bspOptions(cfg)...); err != nil {
+			return err
+		}
+	case ExporterJaeger:
+		endpoint := jaeger.WithAgentEndpoint(net.JoinHostPort(cfg.JaegerAgentHost, strconv.Itoa(cfg.JaegerAgentPort)))
+		if cfg.JaegerEndpoint != "" {
+			endpoint = jaeger.WithCollectorEndpoint(cfg.JaegerEndpoint)
+		}
+		exp, err := jaeger.NewRawExporter(endpoint, jaeger.WithProcess(jaeger.Process{
+			ServiceName: serviceName(cfg.Resource),
+		}))
+		if err != nil {
+			return err
+		}
+		p.onShutdown(exp.Flush)
+		ssp = sdktrace.NewSimpleSpanProcessor(exp)
+	case ExporterZipkin:
+		exp, err := zipkin.NewExporter(cfg.ZipkinEndpoint, serviceName(cfg.Resource))
+		if err != nil {
+			return err
+		}
+		if ssp, err = sdktrace.NewBatchSpanProcessor(exp, bspOptions(cfg)...); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported traces exporter %q", cfg.TracesExporter)
+	}
+
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: cfg.Sampler}),
+		sdktrace.WithResource(cfg.Resource),
+	)
+	if err != nil {
+		return err
+	}
+	tp.RegisterSpanProcessor(ssp)
+	p.onShutdown(func() { tp.UnregisterSpanProcessor(ssp) })
+	p.traceProvider = tp
+	return nil
+}
+
+func bspOptions(cfg Config) []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if cfg.BSPScheduleDelay > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(cfg.BSPScheduleDelay))
+	}
+	if cfg.BSPMaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(cfg.BSPMaxQueueSize))
+	}
+	if cfg.BSPMaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(cfg.BSPMaxExportBatchSize))
+	}
+	return opts
+}
+
+func (p *Pipeline) setupMetrics(cfg Config) error {
+	switch cfg.MetricsExporter {
+	case ExporterNone:
+		p.meterProvider = metric.NoopProvider{}
+		return nil
+	case ExporterStdout:
+		exp, err := metricstdout.NewRawExporter(metricstdout.Config{})
+		if err != nil {
+			return err
+		}
+		p.startPushController(push.New(simple.NewWithExactDistribution(), exp, pushOptions(cfg)...))
+	case ExporterOTLP:
+		exp, err := p.otlpExporter(cfg)
+		if err != nil {
+			return err
+		}
+		p.startPushController(push.New(simple.NewWithExactDistribution(), exp, pushOptions(cfg)...))
+	case ExporterPrometheus:
+		exp, err := prometheus.NewExportPipeline(prometheus.Config{}, pull.WithResource(cfg.Resource))
+		if err != nil {
+			return err
+		}
+		l, err := net.Listen("tcp", net.JoinHostPort(cfg.PrometheusHost, strconv.Itoa(cfg.PrometheusPort)))
+		if err != nil {
+			return err
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", exp)
+		srv := &http.Server{Handler: mux}
+		go func() {
+			if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+				global.Handle(err)
+			}
+		}()
+		p.onShutdown(func() { _ = srv.Close() })
+		p.meterProvider = exp.Provider()
+	default:
+		return fmt.Errorf("unsupported metrics exporter %q", cfg.MetricsExporter)
+	}
+	return nil
+}
+
+func (p *Pipeline) startPushController(c *push.Controller) {
+	c.Start()
+	p.onShutdown(c.Stop)
+	p.meterProvider = c.Provider()
+}
+
+func pushOptions(cfg Config) []push.Option {
+	opts := []push.Option{push.WithResource(cfg.Resource)}
+	if cfg.MetricExportInterval > 0 {
+		opts = append(opts, push.WithPeriod(cfg.MetricExportInterval))
+	}
+	if cfg.MetricExportTimeout > 0 {
+		opts = append(opts, push.WithTimeout(cfg.MetricExportTimeout))
+	}
+	return opts
+}
+
+// serviceName returns the service.name attribute of res.
+func serviceName(res *resource.Resource) string {
+	if res.Len() == 0 {
+		return DefaultServiceName
+	}
+	if v, ok := res.LabelSet().Value(standard.ServiceNameKey); ok {
+		return v.AsString()
+	}
+	return DefaultServiceName
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoconfig
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func TestNewPipelineNone(t *testing.T) {
+	p, err := NewPipeline(Config{
+		TracesExporter:  ExporterNone,
+		MetricsExporter: ExporterNone,
+	})
+	require.NoError(t, err)
+	defer p.Shutdown()
+
+	assert.IsType(t, apitrace.NoopProvider{}, p.TraceProvider())
+	assert.IsType(t, metric.NoopProvider{}, p.MeterProvider())
+	assert.NotNil(t, p.Propagators())
+}
+
+func TestNewPipelineUnsupportedExporter(t *testing.T) {
+	_, err := NewPipeline(Config{TracesExporter: "carrier-pigeon", MetricsExporter: ExporterNone})
+	assert.Error(t, err)
+
+	_, err = NewPipeline(Config{TracesExporter: ExporterNone, MetricsExporter: ExporterJaeger})
+	assert.Error(t, err)
+}
+
+func TestNewPipelineZipkin(t *testing.T) {
+	received := make(chan struct{}, 1)
+	srv := http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	})}
+	l := newLocalListener(t)
+	go func() { _ = srv.Serve(l) }()
+	defer srv.Close()
+
+	p, err := NewPipeline(Config{
+		TracesExporter:  ExporterZipkin,
+		MetricsExporter: ExporterNone,
+		Sampler:         sdktrace.AlwaysSample(),
+		ZipkinEndpoint:  fmt.Sprintf("http://%s/api/v2/spans", l.Addr()),
+	})
+	require.NoError(t, err)
+
+	_, span := p.TraceProvider().Tracer("test").Start(context.Background(), "span")
+	span.End()
+	p.Shutdown()
+
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("span not exported on shutdown")
+	}
+}
+
+func TestNewPipelinePrometheus(t *testing.T) {
+	l := newLocalListener(t)
+	addr := l.Addr().(*net.TCPAddr)
+	require.NoError(t, l.Close())
+
+	p, err := NewPipeline(Config{
+		TracesExporter:  ExporterNone,
+		MetricsExporter: ExporterPrometheus,
+		PrometheusHost:  "127.0.0.1",
+		PrometheusPort:  addr.Port,
+	})
+	require.NoError(t, err)
+	defer p.Shutdown()
+
+	counter := metric.Must(p.MeterProvider().Meter("test")).NewInt64Counter("requests")
+	counter.Add(context.Background(), 3, kv.String("route", "/"))
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", addr))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `requests{route="/"} 3`)
+}
+
+func TestInstallDisabled(t *testing.T) {
+	store := setEnv(t, map[string]string{envDisabled: "true"})
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	tp := global.TraceProvider()
+	shutdown, err := Install()
+	require.NoError(t, err)
+	shutdown()
+	assert.Equal(t, tp, global.TraceProvider())
+}
+
+func TestInstallInvalidEnv(t *testing.T) {
+	store := setEnv(t, map[string]string{envTracesExporter: "unknown"})
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err := Install()
+	assert.Error(t, err)
+}
@@ -0,0 +1,363 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ch1f/otel/api/correlation"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/propagation"
+	"github.com/Ch1f/otel/api/standard"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	opentelemetry "github.com/Ch1f/otel/sdk"
+	"github.com/Ch1f/otel/sdk/resource"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// Environment variable names.
+const (
+	envDisabled             = "OTEL_SDK_DISABLED"
+	envTracesExporter       = "OTEL_TRACES_EXPORTER"
+	envMetricsExporter      = "OTEL_METRICS_EXPORTER"
+	envSampler              = "OTEL_TRACES_SAMPLER"
+	envSamplerArg           = "OTEL_TRACES_SAMPLER_ARG"
+	envBSPScheduleDelay     = "OTEL_BSP_SCHEDULE_DELAY"
+	envBSPMaxQueueSize      = "OTEL_BSP_MAX_QUEUE_SIZE"
+	envBSPMaxExportBatch    = "OTEL_BSP_MAX_EXPORT_BATCH_SIZE"
+	envMetricExportInterval = "OTEL_METRIC_EXPORT_INTERVAL"
+	envMetricExportTimeout  = "OTEL_METRIC_EXPORT_TIMEOUT"
+	envServiceName          = "OTEL_SERVICE_NAME"
+	envPropagators          = "OTEL_PROPAGATORS"
+	envOTLPEndpoint         = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envOTLPHeaders          = "OTEL_EXPORTER_OTLP_HEADERS"
+	envOTLPInsecure         = "OTEL_EXPORTER_OTLP_INSECURE"
+	envJaegerEndpoint       = "OTEL_EXPORTER_JAEGER_ENDPOINT"
+	envJaegerAgentHost      = "OTEL_EXPORTER_JAEGER_AGENT_HOST"
+	envJaegerAgentPort      = "OTEL_EXPORTER_JAEGER_AGENT_PORT"
+	envZipkinEndpoint       = "OTEL_EXPORTER_ZIPKIN_ENDPOINT"
+	envPrometheusHost       = "OTEL_EXPORTER_PROMETHEUS_HOST"
+	envPrometheusPort       = "OTEL_EXPORTER_PROMETHEUS_PORT"
+)
+
+// Exporter names accepted by OTEL_TRACES_EXPORTER and
+// OTEL_METRICS_EXPORTER.
+const (
+	ExporterOTLP       = "otlp"
+	ExporterJaeger     = "jaeger"
+	ExporterZipkin     = "zipkin"
+	ExporterPrometheus = "prometheus"
+	ExporterStdout     = "stdout"
+	ExporterNone       = "none"
+)
+
+// Default configuration values.
+const (
+	DefaultJaegerAgentHost = "localhost"
+	DefaultJaegerAgentPort = 6831
+	DefaultZipkinEndpoint  = "http://localhost:9411/api/v2/spans"
+	DefaultPrometheusHost  = "0.0.0.0"
+	DefaultPrometheusPort  = 9464
+	DefaultServiceName     = "unknown_service"
+)
+
+// Config is the SDK configuration read from the environment.  The zero
+// value of the optional fields leaves the defaults of the underlying
+// SDK components in place.
+type Config struct {
+	// Disabled disables the SDK entirely.
+	Disabled bool
+
+	// TracesExporter is the name of the span exporter.
+	TracesExporter string
+	// MetricsExporter is the name of the metric exporter.
+	MetricsExporter string
+
+	// Sampler is the default sampler of the trace provider.
+	Sampler sdktrace.Sampler
+
+	// BSPScheduleDelay is the delay between two consecutive exports
+	// of the batch span processor.
+	BSPScheduleDelay time.Duration
+	// BSPMaxQueueSize is the maximum queue size of the batch span
+	// processor.
+	BSPMaxQueueSize int
+	// BSPMaxExportBatchSize is the maximum batch size of the batch span
+	// processor.
+	BSPMaxExportBatchSize int
+
+	// MetricExportInterval is the period of the push controller.
+	MetricExportInterval time.Duration
+	// MetricExportTimeout is the export timeout of the push controller.
+	MetricExportTimeout time.Duration
+
+	// Resource is associated with all the telemetry produced.
+	Resource *resource.Resource
+
+	// Propagators are the global propagators.
+	Propagators propagation.Propagators
+
+	// OTLPEndpoint is the address of the OTLP collector.
+	OTLPEndpoint string
+	// OTLPHeaders are sent with every OTLP request.
+	OTLPHeaders map[string]string
+	// OTLPInsecure disables client transport security.
+	OTLPInsecure bool
+
+	// JaegerEndpoint is the Jaeger collector HTTP endpoint.  When empty
+	// spans are sent to the Jaeger agent instead.
+	JaegerEndpoint string
+	// JaegerAgentHost is the host of the Jaeger agent.
+	JaegerAgentHost string
+	// JaegerAgentPort is the port of the Jaeger agent.
+	JaegerAgentPort int
+
+	// ZipkinEndpoint is the Zipkin collector URL.
+	ZipkinEndpoint string
+
+	// PrometheusHost is the host the Prometheus scrape endpoint
+	// listens on.
+	PrometheusHost string
+	// PrometheusPort is the port the Prometheus scrape endpoint listens
+	// on.
+	PrometheusPort int
+}
+
+// ConfigFromEnv reads the SDK configuration from the environment.  An
+// error is returned if any variable holds an invalid value.
+func ConfigFromEnv() (Config, error) {
+	p := &envParser{}
+	cfg := Config{
+		Disabled:              p.bool(envDisabled),
+		TracesExporter:        strings.ToLower(p.string(envTracesExporter, ExporterOTLP)),
+		MetricsExporter:       strings.ToLower(p.string(envMetricsExporter, ExporterOTLP)),
+		BSPScheduleDelay:      p.millis(envBSPScheduleDelay),
+		BSPMaxQueueSize:       p.int(envBSPMaxQueueSize, 0),
+		BSPMaxExportBatchSize: p.int(envBSPMaxExportBatch, 0),
+		MetricExportInterval:  p.millis(envMetricExportInterval),
+		MetricExportTimeout:   p.millis(envMetricExportTimeout),
+		OTLPEndpoint:          p.get(envOTLPEndpoint),
+		OTLPInsecure:          p.bool(envOTLPInsecure),
+		JaegerEndpoint:        p.get(envJaegerEndpoint),
+		JaegerAgentHost:       p.string(envJaegerAgentHost, DefaultJaegerAgentHost),
+		JaegerAgentPort:       p.int(envJaegerAgentPort, DefaultJaegerAgentPort),
+		ZipkinEndpoint:        p.string(envZipkinEndpoint, DefaultZipkinEndpoint),
+		PrometheusHost:        p.string(envPrometheusHost, DefaultPrometheusHost),
+		PrometheusPort:        p.int(envPrometheusPort, DefaultPrometheusPort),
+	}
+
+	if v := p.get(envOTLPHeaders); v != "" {
+		headers, err := parseKeyValues(v)
+		if err != nil {
+			p.fail(envOTLPHeaders, v, err)
+		}
+		cfg.OTLPHeaders = headers
+	}
+
+	sampler, arg := p.string(envSampler, "parentbased_always_on"), p.get(envSamplerArg)
+	s, err := samplerFromName(sampler, arg)
+	if err == errUnknownSampler {
+		p.fail(envSampler, sampler, err)
+	} else if err != nil {
+		p.fail(envSamplerArg, arg, err)
+	}
+	cfg.Sampler = s
+
+	propagators := p.string(envPropagators, "tracecontext,baggage")
+	props, err := PropagatorsByName(strings.Split(propagators, ",")...)
+	if err != nil {
+		p.fail(envPropagators, propagators, err)
+	}
+	cfg.Propagators = props
+
+	res, err := resourceFromEnv(p.get(envServiceName))
+	if err != nil && p.err == nil {
+		p.err = err
+	}
+	cfg.Resource = res
+
+	return cfg, p.err
+}
+
+// envParser reads typed values from the environment and records the
+// first failure.
+type envParser struct {
+	err error
+}
+
+func (p *envParser) get(key string) string {
+	return strings.TrimSpace(os.Getenv(key))
+}
+
+func (p *envParser) fail(key, value string, err error) {
+	if p.err == nil {
+		p.err = fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+}
+
+func (p *envParser) bool(key string) bool {
+	v := p.get(key)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		p.fail(key, v, err)
+	}
+	return b
+}
+
+func (p *envParser) int(key string, def int) int {
+	v := p.get(key)
+	if v == "" {
+		return def
+	}
+	i, err := strconv.Atoi(v)
+	if err == nil && i < 0 {
+		err = fmt.Errorf("negative value")
+	}
+	if err != nil {
+		p.fail(key, v, err)
+	}
+	return i
+}
+
+func (p *envParser) millis(key string) time.Duration {
+	return time.Duration(p.int(key, 0)) * time.Millisecond
+}
+
+func (p *envParser) string(key, def string) string {
+	if v := p.get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// parseKeyValues parses a comma separated list of key=value pairs.
+func parseKeyValues(s string) (map[string]string, error) {
+	m := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		field := strings.SplitN(pair, "=", 2)
+		if len(field) != 2 || strings.TrimSpace(field[0]) == "" {
+			return nil, fmt.Errorf("missing value in %q", pair)
+		}
+		m[strings.TrimSpace(field[0])] = strings.TrimSpace(field[1])
+	}
+	return m, nil
+}
+
+var errUnknownSampler = errors.New("unknown sampler")
+
+// samplerFromName returns the sampler named name, configured with arg
+// for the ratio based samplers.
+func samplerFromName(name, arg string) (sdktrace.Sampler, error) {
+	ratio := func() (float64, error) {
+		if arg == "" {
+			return 1, nil
+		}
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return 0, err
+		}
+		if f < 0 || f > 1 {
+			return 0, fmt.Errorf("sampling probability %v out of range [0, 1]", f)
+		}
+		return f, nil
+	}
+
+	switch strings.ToLower(name) {
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "traceidratio":
+		f, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ProbabilitySampler(f), nil
+	case "parentbased_always_on":
+		return sdktrace.ParentSample(sdktrace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentSample(sdktrace.NeverSample()), nil
+	case "parentbased_traceidratio":
+		f, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentSample(sdktrace.ProbabilitySampler(f)), nil
+	}
+	return nil, errUnknownSampler
+}
+
+// PropagatorsByName returns the propagators injecting and extracting
+// with each of the named propagators, in order.  The supported names are
+// tracecontext, baggage, b3 and b3multi; "none" and empty names are
+// ignored.
+func PropagatorsByName(names ...string) (propagation.Propagators, error) {
+	var props []propagation.HTTPPropagator
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "tracecontext":
+			props = append(props, apitrace.TraceContext{})
+		case "baggage":
+			props = append(props, correlation.CorrelationContext{})
+		case "b3":
+			props = append(props, apitrace.B3{InjectEncoding: apitrace.B3SingleHeader})
+		case "b3multi":
+			props = append(props, apitrace.B3{InjectEncoding: apitrace.B3MultipleHeader})
+		case "none", "":
+		default:
+			return nil, fmt.Errorf("unknown propagator %q", name)
+		}
+	}
+
+	injectors := make([]propagation.HTTPInjector, len(props))
+	extractors := make([]propagation.HTTPExtractor, len(props))
+	for i, p := range props {
+		injectors[i], extractors[i] = p, p
+	}
+	return propagation.New(
+		propagation.WithInjectors(injectors...),
+		propagation.WithExtractors(extractors...),
+	), nil
+}
+
+// resourceFromEnv returns the resource described by
+// OTEL_RESOURCE_ATTRIBUTES, along with the telemetry SDK attributes.
+// The service name, if not empty, takes precedence over the service.name
+// attribute.
+func resourceFromEnv(serviceName string) (*resource.Resource, error) {
+	var labels []kv.KeyValue
+	if serviceName != "" {
+		labels = append(labels, standard.ServiceNameKey.String(serviceName))
+	}
+	res, err := resource.Detect(context.Background(), resource.FromEnv{})
+	res = resource.Merge(resource.New(labels...), res)
+	if _, ok := res.LabelSet().Value(standard.ServiceNameKey); !ok {
+		res = resource.Merge(res, resource.New(standard.ServiceNameKey.String(DefaultServiceName)))
+	}
+	return resource.Merge(res, resource.New(
+		standard.TelemetrySDKNameKey.String("opentelemetry"),
+		standard.TelemetrySDKLanguageGo,
+		standard.TelemetrySDKVersionKey.String(opentelemetry.Version()),
+	)), err
+}
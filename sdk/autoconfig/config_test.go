@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/standard"
+	ottest "github.com/Ch1f/otel/internal/testing"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// allEnv lists every variable read by ConfigFromEnv so tests start from
+// a clean environment.
+var allEnv = []string{
+	envDisabled, envTracesExporter, envMetricsExporter, envSampler,
+	envSamplerArg, envBSPScheduleDelay, envBSPMaxQueueSize,
+	envBSPMaxExportBatch, envMetricExportInterval, envMetricExportTimeout,
+	"OTEL_RESOURCE_ATTRIBUTES", envServiceName, envPropagators,
+	envOTLPEndpoint, envOTLPHeaders, envOTLPInsecure, envJaegerEndpoint,
+	envJaegerAgentHost, envJaegerAgentPort, envZipkinEndpoint,
+	envPrometheusHost, envPrometheusPort,
+}
+
+func setEnv(t *testing.T, env map[string]string) ottest.EnvStore {
+	all := make(map[string]string, len(allEnv))
+	for _, k := range allEnv {
+		all[k] = ""
+	}
+	for k, v := range env {
+		all[k] = v
+	}
+	store, err := ottest.SetEnvVariables(all)
+	require.NoError(t, err)
+	return store
+}
+
+func TestConfigFromEnvDefaults(t *testing.T) {
+	store := setEnv(t, nil)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+
+	assert.False(t, cfg.Disabled)
+	assert.Equal(t, ExporterOTLP, cfg.TracesExporter)
+	assert.Equal(t, ExporterOTLP, cfg.MetricsExporter)
+	assert.Equal(t, sdktrace.ParentSample(sdktrace.AlwaysSample()).Description(), cfg.Sampler.Description())
+	assert.Len(t, cfg.Propagators.HTTPInjectors(), 2)
+	assert.Len(t, cfg.Propagators.HTTPExtractors(), 2)
+	assert.Equal(t, DefaultJaegerAgentHost, cfg.JaegerAgentHost)
+	assert.Equal(t, DefaultJaegerAgentPort, cfg.JaegerAgentPort)
+	assert.Equal(t, DefaultZipkinEndpoint, cfg.ZipkinEndpoint)
+	assert.Equal(t, DefaultPrometheusPort, cfg.PrometheusPort)
+	assert.Equal(t, DefaultServiceName, serviceName(cfg.Resource))
+
+	lang, ok := cfg.Resource.LabelSet().Value(standard.TelemetrySDKLanguageKey)
+	assert.True(t, ok)
+	assert.Equal(t, "go", lang.AsString())
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	store := setEnv(t, map[string]string{
+		envTracesExporter:          "Zipkin",
+		envMetricsExporter:         "prometheus",
+		envSampler:                 "traceidratio",
+		envSamplerArg:              "0.25",
+		envBSPScheduleDelay:        "500",
+		envBSPMaxQueueSize:         "4096",
+		envBSPMaxExportBatch:       "256",
+		envMetricExportInterval:    "30000",
+		envMetricExportTimeout:     "5000",
+		"OTEL_RESOURCE_ATTRIBUTES": "service.name=from-attributes,team=core",
+		envServiceName:             "checkout",
+		envPropagators:             "b3, tracecontext",
+		envOTLPEndpoint:            "collector:55680",
+		envOTLPHeaders:             "api-key=secret, tenant = a",
+		envOTLPInsecure:            "true",
+	})
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, ExporterZipkin, cfg.TracesExporter)
+	assert.Equal(t, ExporterPrometheus, cfg.MetricsExporter)
+	assert.Equal(t, sdktrace.ProbabilitySampler(0.25).Description(), cfg.Sampler.Description())
+	assert.Equal(t, 500*time.Millisecond, cfg.BSPScheduleDelay)
+	assert.Equal(t, 4096, cfg.BSPMaxQueueSize)
+	assert.Equal(t, 256, cfg.BSPMaxExportBatchSize)
+	assert.Equal(t, 30*time.Second, cfg.MetricExportInterval)
+	assert.Equal(t, 5*time.Second, cfg.MetricExportTimeout)
+	assert.Len(t, cfg.Propagators.HTTPInjectors(), 2)
+	assert.Equal(t, "collector:55680", cfg.OTLPEndpoint)
+	assert.Equal(t, map[string]string{"api-key": "secret", "tenant": "a"}, cfg.OTLPHeaders)
+	assert.True(t, cfg.OTLPInsecure)
+
+	assert.Equal(t, "checkout", serviceName(cfg.Resource))
+	team, ok := cfg.Resource.LabelSet().Value("team")
+	assert.True(t, ok)
+	assert.Equal(t, "core", team.AsString())
+}
+
+func TestConfigFromEnvInvalid(t *testing.T) {
+	for _, env := range []map[string]string{
+		{envDisabled: "maybe"},
+		{envSampler: "sometimes"},
+		{envSampler: "traceidratio", envSamplerArg: "1.5"},
+		{envSampler: "parentbased_traceidratio", envSamplerArg: "half"},
+		{envBSPMaxQueueSize: "-1"},
+		{envMetricExportInterval: "10s"},
+		{envPropagators: "tracecontext,xray"},
+		{envOTLPHeaders: "novalue"},
+		{"OTEL_RESOURCE_ATTRIBUTES": "key"},
+	} {
+		store := setEnv(t, env)
+		_, err := ConfigFromEnv()
+		assert.Error(t, err, "%v", env)
+		require.NoError(t, store.Restore())
+	}
+}
+
+func TestSamplerFromName(t *testing.T) {
+	for name, want := range map[string]sdktrace.Sampler{
+		"always_on":                sdktrace.AlwaysSample(),
+		"always_off":               sdktrace.NeverSample(),
+		"traceidratio":             sdktrace.ProbabilitySampler(0.5),
+		"parentbased_always_on":    sdktrace.ParentSample(sdktrace.AlwaysSample()),
+		"parentbased_always_off":   sdktrace.ParentSample(sdktrace.NeverSample()),
+		"parentbased_traceidratio": sdktrace.ParentSample(sdktrace.ProbabilitySampler(0.5)),
+	} {
+		got, err := samplerFromName(name, "0.5")
+		require.NoError(t, err, name)
+		assert.Equal(t, want.Description(), got.Description(), name)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+/*
+Package autoconfig configures the OpenTelemetry SDK from the standard
+OTEL_* environment variables.
+
+Install reads the environment, builds a trace provider, a meter
+provider and the propagators, and registers them globally:
+
+	shutdown, err := autoconfig.Install()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer shutdown()
+
+The following environment variables are supported:
+
+	OTEL_SDK_DISABLED               disable the SDK, leaving the no-op globals in place
+	OTEL_TRACES_EXPORTER            otlp (default), jaeger, zipkin, stdout or none
+	OTEL_METRICS_EXPORTER           otlp (default), prometheus, stdout or none
+	OTEL_TRACES_SAMPLER             always_on, always_off, traceidratio,
+	                                parentbased_always_on (default),
+	                                parentbased_always_off or parentbased_traceidratio
+	OTEL_TRACES_SAMPLER_ARG         the sampling probability of the ratio samplers
+	OTEL_BSP_SCHEDULE_DELAY         batch span processor delay in milliseconds
+	OTEL_BSP_MAX_QUEUE_SIZE         batch span processor queue size
+	OTEL_BSP_MAX_EXPORT_BATCH_SIZE  batch span processor export batch size
+	OTEL_METRIC_EXPORT_INTERVAL     push controller period in milliseconds
+	OTEL_METRIC_EXPORT_TIMEOUT      push controller export timeout in milliseconds
+	OTEL_RESOURCE_ATTRIBUTES        comma separated key=value resource attributes
+	OTEL_SERVICE_NAME               the service.name resource attribute
+	OTEL_PROPAGATORS                comma separated list of tracecontext (default),
+	                                baggage (default), b3, b3multi or none
+	OTEL_EXPORTER_OTLP_ENDPOINT     OTLP collector address
+	OTEL_EXPORTER_OTLP_HEADERS      comma separated key=value gRPC headers
+	OTEL_EXPORTER_OTLP_INSECURE     disable client transport security
+	OTEL_EXPORTER_JAEGER_ENDPOINT   Jaeger collector HTTP endpoint
+	OTEL_EXPORTER_JAEGER_AGENT_HOST Jaeger agent host, used when no endpoint is set
+	OTEL_EXPORTER_JAEGER_AGENT_PORT Jaeger agent port, used when no endpoint is set
+	OTEL_EXPORTER_ZIPKIN_ENDPOINT   Zipkin collector URL
+	OTEL_EXPORTER_PROMETHEUS_HOST   host the Prometheus scrape endpoint listens on
+	OTEL_EXPORTER_PROMETHEUS_PORT   port the Prometheus scrape endpoint listens on
+*/
+package autoconfig // import "github.com/Ch1f/otel/sdk/autoconfig"
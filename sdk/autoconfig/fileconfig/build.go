@@ -0,0 +1,393 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/propagation"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/exporters/metric/prometheus"
+	metricstdout "github.com/Ch1f/otel/exporters/metric/stdout"
+	"github.com/Ch1f/otel/exporters/otlp"
+	"github.com/Ch1f/otel/exporters/trace/jaeger"
+	tracestdout "github.com/Ch1f/otel/exporters/trace/stdout"
+	"github.com/Ch1f/otel/exporters/trace/zipkin"
+	"github.com/Ch1f/otel/sdk/autoconfig"
+	export "github.com/Ch1f/otel/sdk/export/metric"
+	traceexport "github.com/Ch1f/otel/sdk/export/trace"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/ddsketch"
+	"github.com/Ch1f/otel/sdk/metric/controller/pull"
+	"github.com/Ch1f/otel/sdk/metric/controller/push"
+	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+	"github.com/Ch1f/otel/sdk/resource"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// SDK holds the components built from a Config.
+type SDK struct {
+	// Resource is the resource shared by the providers.
+	Resource *resource.Resource
+	// Propagators are the configured propagators.
+	Propagators propagation.Propagators
+
+	// TracerProvider is the tracer provider, or nil if the
+	// configuration has no tracer_provider.
+	TracerProvider *sdktrace.Provider
+
+	// PushController is the metric controller when the
+	// configuration uses a push controller.
+	PushController *push.Controller
+	// PullController is the metric controller when the
+	// configuration uses a pull controller.
+	PullController *pull.Controller
+	// MetricsHandler serves the Prometheus scrape endpoint when the
+	// configuration uses a pull controller.
+	MetricsHandler http.Handler
+
+	// shutdown holds the functions releasing the components, in the
+	// order the components were created.
+	shutdown []func()
+}
+
+// Build constructs the SDK components described by the configuration.
+// Push controllers are started.  On error, any component already built
+// is shut down.
+func (c *Config) Build() (*SDK, error) {
+	sdk := &SDK{Propagators: propagation.New()}
+	if c.Disabled {
+		return sdk, nil
+	}
+
+	var labels []kv.KeyValue
+	if c.Resource != nil {
+		for k, v := range c.Resource.Attributes {
+			labels = append(labels, kv.Infer(k, v))
+		}
+	}
+	sdk.Resource = resource.New(labels...)
+
+	props, err := autoconfig.PropagatorsByName(c.Propagators...)
+	if err != nil {
+		return nil, err
+	}
+	sdk.Propagators = props
+
+	if c.TracerProvider != nil {
+		if err := sdk.buildTracerProvider(c.TracerProvider); err != nil {
+			sdk.Shutdown()
+			return nil, fmt.Errorf("tracer_provider: %w", err)
+		}
+	}
+	if c.MeterProvider != nil {
+		if err := sdk.buildMeterProvider(c.MeterProvider); err != nil {
+			sdk.Shutdown()
+			return nil, fmt.Errorf("meter_provider: %w", err)
+		}
+	}
+	return sdk, nil
+}
+
+// Install registers the providers and propagators globally.  Providers
+// that were not configured leave the current global in place.
+func (s *SDK) Install() {
+	if s.TracerProvider != nil {
+		global.SetTraceProvider(s.TracerProvider)
+	}
+	if mp := s.MeterProvider(); mp != nil {
+		global.SetMeterProvider(mp)
+	}
+	global.SetPropagators(s.Propagators)
+}
+
+// MeterProvider returns the meter provider of the metric controller, or
+// nil if the configuration has no meter_provider.
+func (s *SDK) MeterProvider() metric.Provider {
+	switch {
+	case s.PushController != nil:
+		return s.PushController.Provider()
+	case s.PullController != nil:
+		return s.PullController.Provider()
+	}
+	return nil
+}
+
+// Shutdown flushes any buffered telemetry and stops the exporters.
+func (s *SDK) Shutdown() {
+	for i := len(s.shutdown) - 1; i >= 0; i-- {
+		s.shutdown[i]()
+	}
+	s.shutdown = nil
+}
+
+func (s *SDK) onShutdown(f func()) {
+	s.shutdown = append(s.shutdown, f)
+}
+
+func (s *SDK) buildTracerProvider(cfg *TracerProvider) error {
+	sampler := sdktrace.ParentSample(sdktrace.AlwaysSample())
+	if cfg.Sampler != nil {
+		sampler = cfg.Sampler.build()
+	}
+	config := sdktrace.Config{DefaultSampler: sampler}
+	if l := cfg.Limits; l != nil {
+		config.MaxAttributesPerSpan = l.AttributeCount
+		config.MaxEventsPerSpan = l.EventCount
+		config.MaxLinksPerSpan = l.LinkCount
+	}
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(config),
+		sdktrace.WithResource(s.Resource),
+	)
+	if err != nil {
+		return err
+	}
+	s.TracerProvider = tp
+
+	for i, p := range cfg.Processors {
+		ssp, err := s.buildSpanProcessor(p)
+		if err != nil {
+			return fmt.Errorf("processors[%d]: %w", i, err)
+		}
+		tp.RegisterSpanProcessor(ssp)
+		s.onShutdown(func() { tp.UnregisterSpanProcessor(ssp) })
+	}
+	return nil
+}
+
+func (s *Sampler) build() sdktrace.Sampler {
+	switch {
+	case s.AlwaysOff != nil:
+		return sdktrace.NeverSample()
+	case s.TraceIDRatioBased != nil:
+		return sdktrace.ProbabilitySampler(s.TraceIDRatioBased.Ratio)
+	case s.ParentBased != nil:
+		root := sdktrace.AlwaysSample()
+		if s.ParentBased.Root != nil {
+			root = s.ParentBased.Root.build()
+		}
+		return sdktrace.ParentSample(root)
+	}
+	return sdktrace.AlwaysSample()
+}
+
+func (s *SDK) buildSpanProcessor(cfg SpanProcessor) (sdktrace.SpanProcessor, error) {
+	if cfg.Simple != nil {
+		exp, err := s.buildSpanExporter(cfg.Simple.Exporter)
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.NewSimpleSpanProcessor(exp), nil
+	}
+
+	b := cfg.Batch
+	exp, err := s.buildSpanExporter(b.Exporter)
+	if err != nil {
+		return nil, err
+	}
+	var batcher traceexport.SpanBatcher
+	switch e := exp.(type) {
+	case batcherSyncer:
+		batcher = e.SpanBatcher
+	case traceexport.SpanBatcher:
+		batcher = e
+	default:
+		batcher = syncerBatcher{exp}
+	}
+	var opts []sdktrace.BatchSpanProcessorOption
+	if b.ScheduleDelay > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(time.Duration(b.ScheduleDelay)))
+	}
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(b.MaxExportBatchSize))
+	}
+	if b.Blocking {
+		opts = append(opts, sdktrace.WithBlocking())
+	}
+	return sdktrace.NewBatchSpanProcessor(batcher, opts...)
+}
+
+func (s *SDK) buildSpanExporter(cfg SpanExporter) (traceexport.SpanSyncer, error) {
+	switch {
+	case cfg.OTLP != nil:
+		return s.buildOTLPExporter(cfg.OTLP)
+	case cfg.Jaeger != nil:
+		endpoint := jaeger.WithAgentEndpoint(cfg.Jaeger.AgentEndpoint)
+		if cfg.Jaeger.Endpoint != "" {
+			endpoint = jaeger.WithCollectorEndpoint(cfg.Jaeger.Endpoint)
+		}
+		exp, err := jaeger.NewRawExporter(endpoint, jaeger.WithProcess(jaeger.Process{
+			ServiceName: s.serviceName(),
+		}))
+		if err != nil {
+			return nil, err
+		}
+		s.onShutdown(exp.Flush)
+		return exp, nil
+	case cfg.Zipkin != nil:
+		exp, err := zipkin.NewExporter(cfg.Zipkin.Endpoint, s.serviceName())
+		if err != nil {
+			return nil, err
+		}
+		return batcherSyncer{exp}, nil
+	}
+	return tracestdout.NewExporter(tracestdout.Options{PrettyPrint: cfg.Stdout.PrettyPrint})
+}
+
+func (s *SDK) buildOTLPExporter(cfg *OTLPExporter) (*otlp.Exporter, error) {
+	var opts []otlp.ExporterOption
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlp.WithAddress(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlp.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, otlp.WithHeaders(cfg.Headers))
+	}
+	if cfg.Compression != "" {
+		opts = append(opts, otlp.WithCompressor(cfg.Compression))
+	}
+	exp, err := otlp.NewExporter(opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.onShutdown(func() {
+		if err := exp.Stop(); err != nil {
+			global.Handle(err)
+		}
+	})
+	return exp, nil
+}
+
+func (s *SDK) serviceName() string {
+	if s.Resource.Len() > 0 {
+		if v, ok := s.Resource.LabelSet().Value(standard.ServiceNameKey); ok {
+			return v.AsString()
+		}
+	}
+	return autoconfig.DefaultServiceName
+}
+
+func (s *SDK) buildMeterProvider(cfg *MeterProvider) error {
+	if p := cfg.Controller.Pull; p != nil {
+		exp, err := prometheus.NewExportPipeline(prometheus.Config{
+			DefaultHistogramBoundaries: cfg.HistogramBoundaries,
+		}, pull.WithResource(s.Resource), pull.WithCachePeriod(s.cachePeriod(p)))
+		if err != nil {
+			return err
+		}
+		s.PullController = exp.Controller()
+		s.MetricsHandler = exp
+		if p.Exporter.Prometheus.Listen != "" {
+			return s.serve(p.Exporter.Prometheus.Listen, exp)
+		}
+		return nil
+	}
+
+	p := cfg.Controller.Push
+	var (
+		exp export.Exporter
+		err error
+	)
+	if p.Exporter.OTLP != nil {
+		exp, err = s.buildOTLPExporter(p.Exporter.OTLP)
+	} else {
+		exp, err = metricstdout.NewRawExporter(metricstdout.Config{PrettyPrint: p.Exporter.Stdout.PrettyPrint})
+	}
+	if err != nil {
+		return err
+	}
+	opts := []push.Option{push.WithResource(s.Resource)}
+	if p.Period > 0 {
+		opts = append(opts, push.WithPeriod(time.Duration(p.Period)))
+	}
+	if p.Timeout > 0 {
+		opts = append(opts, push.WithTimeout(time.Duration(p.Timeout)))
+	}
+	c := push.New(aggregatorSelector(cfg), exp, opts...)
+	c.Start()
+	s.onShutdown(c.Stop)
+	s.PushController = c
+	return nil
+}
+
+func (s *SDK) cachePeriod(p *PullController) time.Duration {
+	if p.CachePeriod > 0 {
+		return time.Duration(p.CachePeriod)
+	}
+	return pull.DefaultCachePeriod
+}
+
+func (s *SDK) serve(addr string, h http.Handler) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", h)
+	srv := &http.Server{Handler: mux}
+	go func() {
+		if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+			global.Handle(err)
+		}
+	}()
+	s.onShutdown(func() { _ = srv.Close() })
+	return nil
+}
+
+func aggregatorSelector(cfg *MeterProvider) export.AggregatorSelector {
+	switch cfg.Aggregation {
+	case "inexpensive":
+		return simple.NewWithInexpensiveDistribution()
+	case "exact":
+		return simple.NewWithExactDistribution()
+	case "sketch":
+		return simple.NewWithSketchDistribution(ddsketch.NewDefaultConfig())
+	}
+	return simple.NewWithHistogramDistribution(cfg.HistogramBoundaries)
+}
+
+// syncerBatcher adapts an export.SpanSyncer to the export.SpanBatcher
+// interface so it can be used with a batch span processor.
+type syncerBatcher struct {
+	traceexport.SpanSyncer
+}
+
+func (b syncerBatcher) ExportSpans(ctx context.Context, sds []*traceexport.SpanData) {
+	for _, sd := range sds {
+		b.ExportSpan(ctx, sd)
+	}
+}
+
+// batcherSyncer adapts an export.SpanBatcher to the export.SpanSyncer
+// interface so it can be used with a simple span processor.
+type batcherSyncer struct {
+	traceexport.SpanBatcher
+}
+
+func (s batcherSyncer) ExportSpan(ctx context.Context, sd *traceexport.SpanData) {
+	s.ExportSpans(ctx, []*traceexport.SpanData{sd})
+}
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/standard"
+)
+
+func TestBuild(t *testing.T) {
+	spans := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		spans <- b
+	}))
+	defer srv.Close()
+
+	cfg, err := Parse([]byte(`
+file_format: "0.1"
+resource:
+  attributes:
+    service.name: checkout
+propagators: [tracecontext, b3multi]
+tracer_provider:
+  sampler:
+    always_on:
+  processors:
+    - simple:
+        exporter:
+          zipkin:
+            endpoint: ` + srv.URL + `
+meter_provider:
+  controller:
+    push:
+      exporter:
+        stdout: {}
+`))
+	require.NoError(t, err)
+
+	sdk, err := cfg.Build()
+	require.NoError(t, err)
+
+	v, ok := sdk.Resource.LabelSet().Value(standard.ServiceNameKey)
+	require.True(t, ok)
+	assert.Equal(t, "checkout", v.AsString())
+	assert.Len(t, sdk.Propagators.HTTPInjectors(), 2)
+	require.NotNil(t, sdk.TracerProvider)
+	require.NotNil(t, sdk.PushController)
+	assert.Nil(t, sdk.PullController)
+	assert.NotNil(t, sdk.MeterProvider())
+
+	_, span := sdk.TracerProvider.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+	sdk.Shutdown()
+
+	require.Len(t, spans, 1)
+	body := <-spans
+	assert.True(t, bytes.Contains(body, []byte(`"name":"operation"`)), string(body))
+	assert.True(t, bytes.Contains(body, []byte(`"serviceName":"checkout"`)), string(body))
+}
+
+func TestBuildPull(t *testing.T) {
+	cfg, err := Parse([]byte(`
+file_format: "0.1"
+meter_provider:
+  histogram_boundaries: [1, 10]
+  controller:
+    pull:
+      cache_period: 0s
+      exporter:
+        prometheus: {}
+`))
+	require.NoError(t, err)
+
+	sdk, err := cfg.Build()
+	require.NoError(t, err)
+	defer sdk.Shutdown()
+	require.NotNil(t, sdk.PullController)
+	require.NotNil(t, sdk.MetricsHandler)
+
+	recorder := metric.Must(sdk.MeterProvider().Meter("test")).NewFloat64ValueRecorder("latency")
+	recorder.Record(context.Background(), 5, kv.String("route", "/"))
+
+	rec := httptest.NewRecorder()
+	sdk.MetricsHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `latency_bucket{route="/",le="10"} 1`)
+}
+
+func TestBuildDisabled(t *testing.T) {
+	cfg, err := Parse([]byte(`
+file_format: "0.1"
+disabled: true
+tracer_provider:
+  processors:
+    - simple: {exporter: {stdout: {}}}
+`))
+	require.NoError(t, err)
+
+	sdk, err := cfg.Build()
+	require.NoError(t, err)
+	assert.Nil(t, sdk.TracerProvider)
+	assert.Nil(t, sdk.MeterProvider())
+	sdk.Shutdown()
+}
@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileFormat is the version of the configuration file format supported
+// by this package.
+const FileFormat = "0.1"
+
+// Config is the root of a configuration file.
+type Config struct {
+	// FileFormat is the version of the file format, it must be
+	// FileFormat.
+	FileFormat string `yaml:"file_format"`
+	// Disabled disables the SDK, Build then returns no-op providers.
+	Disabled bool `yaml:"disabled"`
+	// Resource describes the entity producing telemetry.
+	Resource *Resource `yaml:"resource"`
+	// Propagators lists the propagators by name: tracecontext,
+	// baggage, b3 and b3multi.
+	Propagators []string `yaml:"propagators"`
+	// TracerProvider configures the tracer provider.  No tracer
+	// provider is built when it is nil.
+	TracerProvider *TracerProvider `yaml:"tracer_provider"`
+	// MeterProvider configures the meter provider.  No meter provider
+	// is built when it is nil.
+	MeterProvider *MeterProvider `yaml:"meter_provider"`
+}
+
+// Resource describes the entity producing telemetry.
+type Resource struct {
+	// Attributes are the resource attributes.  Values must be
+	// scalars: strings, numbers or booleans.
+	Attributes map[string]interface{} `yaml:"attributes"`
+}
+
+// TracerProvider configures an sdktrace.Provider.
+type TracerProvider struct {
+	// Sampler is the default sampler, parent_based with an always_on
+	// root when nil.
+	Sampler *Sampler `yaml:"sampler"`
+	// Limits bounds the data recorded per span.
+	Limits *SpanLimits `yaml:"limits"`
+	// Processors are registered with the provider in order.
+	Processors []SpanProcessor `yaml:"processors"`
+}
+
+// Sampler is a tree of samplers, exactly one field must be set.
+type Sampler struct {
+	AlwaysOn          *struct{}                 `yaml:"always_on"`
+	AlwaysOff         *struct{}                 `yaml:"always_off"`
+	TraceIDRatioBased *TraceIDRatioBasedSampler `yaml:"trace_id_ratio_based"`
+	ParentBased       *ParentBasedSampler       `yaml:"parent_based"`
+}
+
+// TraceIDRatioBasedSampler samples a ratio of the traces.
+type TraceIDRatioBasedSampler struct {
+	// Ratio is the sampling probability, in the range [0, 1].
+	Ratio float64 `yaml:"ratio"`
+}
+
+// ParentBasedSampler follows the sampling decision of the parent span
+// and delegates to Root for root spans.
+type ParentBasedSampler struct {
+	// Root samples the root spans, always_on when nil.
+	Root *Sampler `yaml:"root"`
+}
+
+// SpanLimits bounds the data recorded per span.  Zero values keep the
+// SDK defaults.
+type SpanLimits struct {
+	AttributeCount int `yaml:"attribute_count"`
+	EventCount     int `yaml:"event_count"`
+	LinkCount      int `yaml:"link_count"`
+}
+
+// SpanProcessor configures a span processor, exactly one field must be
+// set.
+type SpanProcessor struct {
+	Batch  *BatchSpanProcessor  `yaml:"batch"`
+	Simple *SimpleSpanProcessor `yaml:"simple"`
+}
+
+// BatchSpanProcessor configures an sdktrace.BatchSpanProcessor.  Zero
+// values keep the SDK defaults.
+type BatchSpanProcessor struct {
+	ScheduleDelay      Duration     `yaml:"schedule_delay"`
+	MaxQueueSize       int          `yaml:"max_queue_size"`
+	MaxExportBatchSize int          `yaml:"max_export_batch_size"`
+	Blocking           bool         `yaml:"blocking"`
+	Exporter           SpanExporter `yaml:"exporter"`
+}
+
+// SimpleSpanProcessor configures an sdktrace.SimpleSpanProcessor.
+type SimpleSpanProcessor struct {
+	Exporter SpanExporter `yaml:"exporter"`
+}
+
+// SpanExporter configures a span exporter, exactly one field must be
+// set.
+type SpanExporter struct {
+	OTLP   *OTLPExporter   `yaml:"otlp"`
+	Jaeger *JaegerExporter `yaml:"jaeger"`
+	Zipkin *ZipkinExporter `yaml:"zipkin"`
+	Stdout *StdoutExporter `yaml:"stdout"`
+}
+
+// OTLPExporter configures an otlp.Exporter.
+type OTLPExporter struct {
+	// Endpoint is the collector address, the otlp package default when
+	// empty.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables client transport security.
+	Insecure bool `yaml:"insecure"`
+	// Headers are sent with every request.
+	Headers map[string]string `yaml:"headers"`
+	// Compression is the name of the gRPC compressor, e.g. gzip.
+	Compression string `yaml:"compression"`
+}
+
+// JaegerExporter configures a jaeger.Exporter.  Exactly one of
+// Endpoint and AgentEndpoint must be set.
+type JaegerExporter struct {
+	// Endpoint is the collector HTTP endpoint.
+	Endpoint string `yaml:"endpoint"`
+	// AgentEndpoint is the host:port of the agent.
+	AgentEndpoint string `yaml:"agent_endpoint"`
+}
+
+// ZipkinExporter configures a zipkin.Exporter.
+type ZipkinExporter struct {
+	// Endpoint is the collector URL.
+	Endpoint string `yaml:"endpoint"`
+}
+
+// StdoutExporter configures the stdout span or metric exporter.
+type StdoutExporter struct {
+	PrettyPrint bool `yaml:"pretty_print"`
+}
+
+// MeterProvider configures the metric controller and its aggregation.
+type MeterProvider struct {
+	// Aggregation selects the aggregators of ValueRecorder
+	// instruments: inexpensive, exact, sketch or histogram.  Pull
+	// controllers only support histogram, which is also the default.
+	Aggregation string `yaml:"aggregation"`
+	// HistogramBoundaries are the bucket boundaries of the histogram
+	// aggregation, in increasing order.
+	HistogramBoundaries []float64 `yaml:"histogram_boundaries"`
+	// Controller collects and exports the metrics.
+	Controller MetricController `yaml:"controller"`
+}
+
+// MetricController configures a metric controller, exactly one field
+// must be set.
+type MetricController struct {
+	Push *PushController `yaml:"push"`
+	Pull *PullController `yaml:"pull"`
+}
+
+// PushController configures a push.Controller.  Zero durations keep
+// the SDK defaults.
+type PushController struct {
+	Period   Duration       `yaml:"period"`
+	Timeout  Duration       `yaml:"timeout"`
+	Exporter MetricExporter `yaml:"exporter"`
+}
+
+// MetricExporter configures a push metric exporter, exactly one field
+// must be set.
+type MetricExporter struct {
+	OTLP   *OTLPExporter   `yaml:"otlp"`
+	Stdout *StdoutExporter `yaml:"stdout"`
+}
+
+// PullController configures a pull.Controller.
+type PullController struct {
+	// CachePeriod is the period a collection is reused for, the SDK
+	// default when zero.
+	CachePeriod Duration     `yaml:"cache_period"`
+	Exporter    PullExporter `yaml:"exporter"`
+}
+
+// PullExporter configures a pull metric exporter, exactly one field
+// must be set.
+type PullExporter struct {
+	Prometheus *PrometheusExporter `yaml:"prometheus"`
+}
+
+// PrometheusExporter configures a prometheus.Exporter.
+type PrometheusExporter struct {
+	// Listen is the address the scrape endpoint is served on at
+	// /metrics.  When empty the endpoint is not served and the
+	// application is expected to serve SDK.MetricsHandler itself.
+	Listen string `yaml:"listen"`
+}
+
+// Duration is a time.Duration written as a Go duration string such
+// as "1.5s" or "300ms".
+type Duration time.Duration
+
+var _ yaml.Unmarshaler = (*Duration)(nil)
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
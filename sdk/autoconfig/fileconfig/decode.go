@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError is a problem found with a field of a configuration file.
+type FieldError struct {
+	// Path locates the field, e.g.
+	// "tracer_provider.processors[0].batch.max_queue_size".
+	Path string
+	// Line is the line of the field in the file, or 0 if unknown.
+	Line int
+	// Message describes the problem.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "<root>"
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d): %s", path, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// ValidationError is returned by Parse and Load when a configuration
+// file does not match the schema.  It holds every problem found.
+type ValidationError []*FieldError
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return "invalid configuration:\n\t" + strings.Join(msgs, "\n\t")
+}
+
+// errorList accumulates field errors.
+type errorList struct {
+	errs ValidationError
+}
+
+func (l *errorList) add(path string, node *yaml.Node, format string, args ...interface{}) {
+	fe := &FieldError{Path: path, Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		fe.Line = node.Line
+	}
+	l.errs = append(l.errs, fe)
+}
+
+func (l *errorList) err() error {
+	if len(l.errs) == 0 {
+		return nil
+	}
+	return l.errs
+}
+
+// Load reads, parses and validates the configuration file at path.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates a YAML or JSON configuration.  A
+// ValidationError is returned when the configuration does not match the
+// schema.
+func Parse(data []byte) (*Config, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	var errs errorList
+	cfg := new(Config)
+	if len(root.Content) == 0 {
+		errs.add("", nil, "empty configuration")
+		return nil, errs.err()
+	}
+	decode(root.Content[0], reflect.ValueOf(cfg).Elem(), "", &errs)
+	if len(errs.errs) == 0 {
+		cfg.validate(&errs)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// decode decodes node into v, rejecting fields that are not part of the
+// schema and recording every error with its path.
+func decode(node *yaml.Node, v reflect.Value, path string, errs *errorList) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	null := node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+
+	if v.Kind() == reflect.Ptr {
+		if null && v.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if null {
+			return
+		}
+		decode(node, v.Elem(), path, errs)
+		return
+	}
+	if null {
+		return
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(unmarshalerType) {
+		if err := node.Decode(v.Addr().Interface()); err != nil {
+			errs.add(path, node, "%s", typeErrorMessage(err))
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		decodeStruct(node, v, path, errs)
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			errs.add(path, node, "expected a list")
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), len(node.Content), len(node.Content))
+		for i, item := range node.Content {
+			decode(item, s.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		v.Set(s)
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			errs.add(path, node, "expected a mapping")
+			return
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			elem := reflect.New(v.Type().Elem()).Elem()
+			decode(node.Content[i+1], elem, joinPath(path, key), errs)
+			m.SetMapIndex(reflect.ValueOf(key), elem)
+		}
+		v.Set(m)
+	case reflect.Interface:
+		if node.Kind != yaml.ScalarNode {
+			errs.add(path, node, "expected a string, number or boolean")
+			return
+		}
+		fallthrough
+	default:
+		if node.Kind != yaml.ScalarNode {
+			errs.add(path, node, "expected a %s", v.Kind())
+			return
+		}
+		if err := node.Decode(v.Addr().Interface()); err != nil {
+			errs.add(path, node, "%s", typeErrorMessage(err))
+		}
+	}
+}
+
+func decodeStruct(node *yaml.Node, v reflect.Value, path string, errs *errorList) {
+	if node.Kind != yaml.MappingNode {
+		errs.add(path, node, "expected a mapping")
+		return
+	}
+	fields := make(map[string]int, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		fields[tag] = i
+	}
+	seen := make(map[string]bool, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		fieldPath := joinPath(path, key)
+		if seen[key] {
+			errs.add(fieldPath, keyNode, "duplicate field")
+			continue
+		}
+		seen[key] = true
+		idx, ok := fields[key]
+		if !ok {
+			errs.add(fieldPath, keyNode, "unknown field, expected one of %s", fieldNames(fields))
+			continue
+		}
+		decode(valueNode, v.Field(idx), fieldPath, errs)
+	}
+}
+
+func fieldNames(fields map[string]int) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// typeErrorMessage strips the line information yaml adds to decoding
+// errors, the FieldError carrying it already.
+func typeErrorMessage(err error) string {
+	if te, ok := err.(*yaml.TypeError); ok && len(te.Errors) > 0 {
+		msg := te.Errors[0]
+		if i := strings.Index(msg, ": "); strings.HasPrefix(msg, "line ") && i >= 0 {
+			msg = msg[i+2:]
+		}
+		return msg
+	}
+	return err.Error()
+}
@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fullConfig = `
+file_format: "0.1"
+resource:
+  attributes:
+    service.name: checkout
+    service.instance.count: 3
+propagators: [tracecontext, baggage, b3]
+tracer_provider:
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based:
+          ratio: 0.25
+  limits:
+    attribute_count: 64
+    event_count: 16
+  processors:
+    - batch:
+        schedule_delay: 500ms
+        max_queue_size: 2048
+        max_export_batch_size: 512
+        exporter:
+          otlp:
+            endpoint: collector:55680
+            insecure: true
+            headers:
+              api-key: secret
+    - simple:
+        exporter:
+          stdout:
+meter_provider:
+  aggregation: histogram
+  histogram_boundaries: [5, 10, 25]
+  controller:
+    push:
+      period: 10s
+      exporter:
+        stdout: {pretty_print: true}
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(fullConfig))
+	require.NoError(t, err)
+
+	assert.Equal(t, FileFormat, cfg.FileFormat)
+	assert.Equal(t, map[string]interface{}{
+		"service.name":           "checkout",
+		"service.instance.count": 3,
+	}, cfg.Resource.Attributes)
+	assert.Equal(t, []string{"tracecontext", "baggage", "b3"}, cfg.Propagators)
+
+	tp := cfg.TracerProvider
+	require.NotNil(t, tp)
+	assert.Equal(t, 0.25, tp.Sampler.ParentBased.Root.TraceIDRatioBased.Ratio)
+	assert.Equal(t, &SpanLimits{AttributeCount: 64, EventCount: 16}, tp.Limits)
+	require.Len(t, tp.Processors, 2)
+	assert.Equal(t, &BatchSpanProcessor{
+		ScheduleDelay:      Duration(500 * time.Millisecond),
+		MaxQueueSize:       2048,
+		MaxExportBatchSize: 512,
+		Exporter: SpanExporter{OTLP: &OTLPExporter{
+			Endpoint: "collector:55680",
+			Insecure: true,
+			Headers:  map[string]string{"api-key": "secret"},
+		}},
+	}, tp.Processors[0].Batch)
+	assert.Equal(t, &StdoutExporter{}, tp.Processors[1].Simple.Exporter.Stdout)
+
+	mp := cfg.MeterProvider
+	require.NotNil(t, mp)
+	assert.Equal(t, []float64{5, 10, 25}, mp.HistogramBoundaries)
+	assert.Equal(t, Duration(10*time.Second), mp.Controller.Push.Period)
+	assert.True(t, mp.Controller.Push.Exporter.Stdout.PrettyPrint)
+}
+
+func TestParseJSON(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+		"file_format": "0.1",
+		"tracer_provider": {
+			"sampler": {"always_off": {}},
+			"processors": [{"simple": {"exporter": {"zipkin": {"endpoint": "http://zipkin:9411/api/v2/spans"}}}}]
+		}
+	}`))
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.TracerProvider.Sampler.AlwaysOff)
+	assert.Equal(t, "http://zipkin:9411/api/v2/spans", cfg.TracerProvider.Processors[0].Simple.Exporter.Zipkin.Endpoint)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{
+			name:   "empty",
+			config: "",
+			want:   []string{""},
+		},
+		{
+			name:   "file format",
+			config: `file_format: "2.0"`,
+			want:   []string{"file_format"},
+		},
+		{
+			name: "unknown fields",
+			config: `
+file_format: "0.1"
+tracer_providers: {}
+tracer_provider:
+  processors:
+    - batch:
+        max_queue: 10
+        exporter: {stdout: {}}
+`,
+			want: []string{"tracer_providers", "tracer_provider.processors[0].batch.max_queue"},
+		},
+		{
+			name: "wrong types",
+			config: `
+file_format: "0.1"
+propagators: tracecontext
+resource:
+  attributes:
+    list: [1, 2]
+tracer_provider:
+  limits:
+    attribute_count: many
+  processors:
+    - batch:
+        schedule_delay: 5
+        exporter: {stdout: {}}
+`,
+			want: []string{
+				"propagators",
+				"resource.attributes.list",
+				"tracer_provider.limits.attribute_count",
+				"tracer_provider.processors[0].batch.schedule_delay",
+			},
+		},
+		{
+			name: "semantic",
+			config: `
+file_format: "0.1"
+propagators: [tracecontext, xray]
+tracer_provider:
+  sampler:
+    parent_based:
+      root:
+        trace_id_ratio_based: {ratio: 2}
+  processors:
+    - batch:
+        max_queue_size: 10
+        max_export_batch_size: 20
+        exporter:
+          otlp: {}
+          stdout: {}
+    - simple:
+        exporter:
+          jaeger: {}
+    - {}
+meter_provider:
+  aggregation: exact
+  histogram_boundaries: [10, 5]
+  controller:
+    pull:
+      exporter: {}
+`,
+			want: []string{
+				"propagators[1]",
+				"tracer_provider.sampler.parent_based.root.trace_id_ratio_based.ratio",
+				"tracer_provider.processors[0].batch.max_export_batch_size",
+				"tracer_provider.processors[0].batch.exporter",
+				"tracer_provider.processors[1].simple.exporter.jaeger",
+				"tracer_provider.processors[2]",
+				"meter_provider.histogram_boundaries",
+				"meter_provider.controller.pull",
+				"meter_provider.controller.pull.exporter",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Parse([]byte(c.config))
+			require.Error(t, err)
+			verr, ok := err.(ValidationError)
+			require.True(t, ok, "unexpected error type %T: %v", err, err)
+			var paths []string
+			for _, fe := range verr {
+				paths = append(paths, fe.Path)
+			}
+			assert.Equal(t, c.want, paths, err.Error())
+		})
+	}
+}
+
+func TestFieldErrorLine(t *testing.T) {
+	_, err := Parse([]byte("file_format: \"0.1\"\nunknown: 1\n"))
+	require.Error(t, err)
+	verr := err.(ValidationError)
+	require.Len(t, verr, 1)
+	assert.Equal(t, 2, verr[0].Line)
+	assert.Contains(t, verr[0].Error(), "unknown (line 2): unknown field")
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "otel.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(fullConfig), 0644))
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, FileFormat, cfg.FileFormat)
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+/*
+Package fileconfig builds an OpenTelemetry SDK pipeline from a
+declarative YAML or JSON configuration file.
+
+The file is versioned through its file_format field and describes the
+resource, the propagators, the tracer provider and the meter provider:
+
+	file_format: "0.1"
+	resource:
+	  attributes:
+	    service.name: checkout
+	propagators: [tracecontext, baggage]
+	tracer_provider:
+	  sampler:
+	    parent_based:
+	      root:
+	        trace_id_ratio_based:
+	          ratio: 0.25
+	  limits:
+	    attribute_count: 64
+	  processors:
+	    - batch:
+	        schedule_delay: 5s
+	        max_queue_size: 2048
+	        exporter:
+	          otlp:
+	            endpoint: collector:55680
+	            insecure: true
+	            headers:
+	              api-key: secret
+	meter_provider:
+	  aggregation: histogram
+	  histogram_boundaries: [5, 10, 25, 50, 100]
+	  controller:
+	    push:
+	      period: 10s
+	      exporter:
+	        stdout: {}
+
+Load and Parse validate the file against the schema and report every
+problem found along with the path of the offending field.  Build then
+constructs the SDK components using the constructors of the sdk and
+exporters packages.
+*/
+package fileconfig // import "github.com/Ch1f/otel/sdk/autoconfig/fileconfig"
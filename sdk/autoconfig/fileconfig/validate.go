@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported values of the enumerated fields.
+var (
+	propagatorNames  = []string{"b3", "b3multi", "baggage", "tracecontext"}
+	aggregationNames = []string{"exact", "histogram", "inexpensive", "sketch"}
+)
+
+func (c *Config) validate(errs *errorList) {
+	if c.FileFormat != FileFormat {
+		errs.add("file_format", nil, "unsupported file format %q, expected %q", c.FileFormat, FileFormat)
+	}
+	if c.Resource != nil {
+		for k := range c.Resource.Attributes {
+			if k == "" {
+				errs.add("resource.attributes", nil, "empty attribute key")
+			}
+		}
+	}
+	for i, p := range c.Propagators {
+		if !contains(propagatorNames, p) {
+			errs.add(fmt.Sprintf("propagators[%d]", i), nil, "unknown propagator %q, expected one of %s", p, strings.Join(propagatorNames, ", "))
+		}
+	}
+	if c.TracerProvider != nil {
+		c.TracerProvider.validate("tracer_provider", errs)
+	}
+	if c.MeterProvider != nil {
+		c.MeterProvider.validate("meter_provider", errs)
+	}
+}
+
+func (tp *TracerProvider) validate(path string, errs *errorList) {
+	if tp.Sampler != nil {
+		tp.Sampler.validate(path+".sampler", errs)
+	}
+	if l := tp.Limits; l != nil {
+		nonNegative(path+".limits.attribute_count", int64(l.AttributeCount), errs)
+		nonNegative(path+".limits.event_count", int64(l.EventCount), errs)
+		nonNegative(path+".limits.link_count", int64(l.LinkCount), errs)
+	}
+	for i, p := range tp.Processors {
+		p.validate(fmt.Sprintf("%s.processors[%d]", path, i), errs)
+	}
+}
+
+func (s *Sampler) validate(path string, errs *errorList) {
+	if !exactlyOne(path, errs, map[string]bool{
+		"always_on":            s.AlwaysOn != nil,
+		"always_off":           s.AlwaysOff != nil,
+		"trace_id_ratio_based": s.TraceIDRatioBased != nil,
+		"parent_based":         s.ParentBased != nil,
+	}) {
+		return
+	}
+	switch {
+	case s.TraceIDRatioBased != nil:
+		if r := s.TraceIDRatioBased.Ratio; r < 0 || r > 1 {
+			errs.add(path+".trace_id_ratio_based.ratio", nil, "ratio %v out of range [0, 1]", r)
+		}
+	case s.ParentBased != nil && s.ParentBased.Root != nil:
+		s.ParentBased.Root.validate(path+".parent_based.root", errs)
+	}
+}
+
+func (p SpanProcessor) validate(path string, errs *errorList) {
+	if !exactlyOne(path, errs, map[string]bool{
+		"batch":  p.Batch != nil,
+		"simple": p.Simple != nil,
+	}) {
+		return
+	}
+	if b := p.Batch; b != nil {
+		path += ".batch"
+		nonNegative(path+".schedule_delay", int64(b.ScheduleDelay), errs)
+		nonNegative(path+".max_queue_size", int64(b.MaxQueueSize), errs)
+		nonNegative(path+".max_export_batch_size", int64(b.MaxExportBatchSize), errs)
+		if b.MaxQueueSize > 0 && b.MaxExportBatchSize > b.MaxQueueSize {
+			errs.add(path+".max_export_batch_size", nil, "must not exceed max_queue_size")
+		}
+		b.Exporter.validate(path+".exporter", errs)
+		return
+	}
+	p.Simple.Exporter.validate(path+".simple.exporter", errs)
+}
+
+func (e SpanExporter) validate(path string, errs *errorList) {
+	if !exactlyOne(path, errs, map[string]bool{
+		"otlp":   e.OTLP != nil,
+		"jaeger": e.Jaeger != nil,
+		"zipkin": e.Zipkin != nil,
+		"stdout": e.Stdout != nil,
+	}) {
+		return
+	}
+	switch {
+	case e.Jaeger != nil:
+		if (e.Jaeger.Endpoint == "") == (e.Jaeger.AgentEndpoint == "") {
+			errs.add(path+".jaeger", nil, "exactly one of endpoint, agent_endpoint must be set")
+		}
+	case e.Zipkin != nil:
+		if e.Zipkin.Endpoint == "" {
+			errs.add(path+".zipkin.endpoint", nil, "required")
+		}
+	}
+}
+
+func (mp *MeterProvider) validate(path string, errs *errorList) {
+	if mp.Aggregation != "" && !contains(aggregationNames, mp.Aggregation) {
+		errs.add(path+".aggregation", nil, "unknown aggregation %q, expected one of %s", mp.Aggregation, strings.Join(aggregationNames, ", "))
+	}
+	if !sort.Float64sAreSorted(mp.HistogramBoundaries) {
+		errs.add(path+".histogram_boundaries", nil, "boundaries must be in increasing order")
+	}
+
+	path += ".controller"
+	c := mp.Controller
+	if !exactlyOne(path, errs, map[string]bool{
+		"push": c.Push != nil,
+		"pull": c.Pull != nil,
+	}) {
+		return
+	}
+	if c.Push != nil {
+		nonNegative(path+".push.period", int64(c.Push.Period), errs)
+		nonNegative(path+".push.timeout", int64(c.Push.Timeout), errs)
+		exactlyOne(path+".push.exporter", errs, map[string]bool{
+			"otlp":   c.Push.Exporter.OTLP != nil,
+			"stdout": c.Push.Exporter.Stdout != nil,
+		})
+		return
+	}
+	if mp.Aggregation != "" && mp.Aggregation != "histogram" {
+		errs.add(path+".pull", nil, "pull controllers only support the histogram aggregation")
+	}
+	nonNegative(path+".pull.cache_period", int64(c.Pull.CachePeriod), errs)
+	exactlyOne(path+".pull.exporter", errs, map[string]bool{
+		"prometheus": c.Pull.Exporter.Prometheus != nil,
+	})
+}
+
+// exactlyOne records an error unless exactly one of the named fields is
+// set, and reports whether the check passed.
+func exactlyOne(path string, errs *errorList, set map[string]bool) bool {
+	var names, found []string
+	for name, ok := range set {
+		names = append(names, name)
+		if ok {
+			found = append(found, name)
+		}
+	}
+	if len(found) == 1 {
+		return true
+	}
+	sort.Strings(names)
+	if len(found) == 0 {
+		errs.add(path, nil, "one of %s must be set", strings.Join(names, ", "))
+	} else {
+		sort.Strings(found)
+		errs.add(path, nil, "only one of %s may be set, found %s", strings.Join(names, ", "), strings.Join(found, ", "))
+	}
+	return false
+}
+
+func nonNegative(path string, v int64, errs *errorList) {
+	if v < 0 {
+		errs.add(path, nil, "must not be negative")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,21 @@
+module github.com/Ch1f/otel/sdk/autoconfig
+
+go 1.13
+
+replace (
+	github.com/Ch1f/otel => ../..
+	github.com/Ch1f/otel/exporters/metric/prometheus => ../../exporters/metric/prometheus
+	github.com/Ch1f/otel/exporters/otlp => ../../exporters/otlp
+	github.com/Ch1f/otel/exporters/trace/jaeger => ../../exporters/trace/jaeger
+	github.com/Ch1f/otel/exporters/trace/zipkin => ../../exporters/trace/zipkin
+)
+
+require (
+	github.com/Ch1f/otel v0.7.0
+	github.com/Ch1f/otel/exporters/metric/prometheus v0.7.0
+	github.com/Ch1f/otel/exporters/otlp v0.7.0
+	github.com/Ch1f/otel/exporters/trace/jaeger v0.7.0
+	github.com/Ch1f/otel/exporters/trace/zipkin v0.7.0
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
+)
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package log contains the interfaces and data model used by log
+// exporters.
+package log // import "github.com/Ch1f/otel/sdk/export/log"
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+	apilog "github.com/Ch1f/otel/api/log"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/sdk/instrumentation"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+// LogSyncer is a type for functions that receive a single log record.
+//
+// The ExportLog method is called synchronously. Therefore, it should not take
+// forever to process the record.
+//
+// The Record should not be modified.
+type LogSyncer interface {
+	ExportLog(context.Context, *Record)
+}
+
+// LogBatcher is a type for functions that receive batches of log records.
+//
+// The ExportLogs method is called asynchronously. However its should not take
+// forever to process the records.
+//
+// The Records should not be modified.
+type LogBatcher interface {
+	ExportLogs(context.Context, []*Record)
+}
+
+// Record contains all the information collected for a log entry.
+type Record struct {
+	Timestamp    time.Time
+	Severity     apilog.Severity
+	SeverityText string
+	Body         value.Value
+	Attributes   []kv.KeyValue
+
+	// TraceID, SpanID and TraceFlags identify the span that was active
+	// when the record was emitted. They are zero if there was none.
+	TraceID    apitrace.ID
+	SpanID     apitrace.SpanID
+	TraceFlags byte
+
+	// Resource contains attributes representing an entity that produced
+	// this record.
+	Resource *resource.Resource
+
+	// InstrumentationLibrary defines the instrumentation library used to
+	// emit the record.
+	InstrumentationLibrary instrumentation.Library
+}
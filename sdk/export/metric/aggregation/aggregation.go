@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
 )
 
 // These interfaces describe the various ways to access state from an
@@ -108,6 +110,32 @@ type (
 		Count() (int64, error)
 	}
 
+	// Exemplar is a measurement sampled by an aggregator together
+	// with the trace context it was recorded in.
+	Exemplar struct {
+		// Value is the measured value.
+		Value metric.Number
+
+		// Time is when the measurement was recorded.
+		Time time.Time
+
+		// FilteredLabels are labels of the measurement that are
+		// not part of the aggregated label set.
+		FilteredLabels []kv.KeyValue
+
+		// TraceID and SpanID identify the sampled span the
+		// measurement was recorded in.
+		TraceID apitrace.ID
+		SpanID  apitrace.SpanID
+	}
+
+	// Exemplars returns the exemplars sampled from the values that
+	// were aggregated.
+	Exemplars interface {
+		Aggregation
+		Exemplars() ([]Exemplar, error)
+	}
+
 	// Distribution supports the Min, Max, Sum, Count, and Quantile
 	// interfaces.
 	Distribution interface {
@@ -47,6 +47,7 @@ var cumulativeMemoryKinds = []metric.Kind{
 	metric.ValueObserverKind,
 	metric.CounterKind,
 	metric.UpDownCounterKind,
+	metric.GaugeKind,
 }
 
 func TestExportKindMemoryRequired(t *testing.T) {
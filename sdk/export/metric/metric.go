@@ -361,7 +361,7 @@ func (kind ExportKind) ExportKindFor(_ *metric.Descriptor, _ aggregation.Kind) E
 func (kind ExportKind) MemoryRequired(mkind metric.Kind) bool {
 	switch mkind {
 	case metric.ValueRecorderKind, metric.ValueObserverKind,
-		metric.CounterKind, metric.UpDownCounterKind:
+		metric.CounterKind, metric.UpDownCounterKind, metric.GaugeKind:
 		// Delta-oriented instruments:
 		return kind.Includes(CumulativeExporter)
 
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	export "github.com/Ch1f/otel/sdk/export/log"
+)
+
+const (
+	DefaultMaxQueueSize       = 2048
+	DefaultBatchTimeout       = 1000 * time.Millisecond
+	DefaultMaxExportBatchSize = 512
+)
+
+var (
+	errNilExporter = errors.New("exporter is nil")
+)
+
+type BatchLogProcessorOption func(o *BatchLogProcessorOptions)
+
+type BatchLogProcessorOptions struct {
+	// MaxQueueSize is the maximum queue size to buffer records for delayed processing. If the
+	// queue gets full it drops the records. Use BlockOnQueueFull to change this behavior.
+	// The default value of MaxQueueSize is 2048.
+	MaxQueueSize int
+
+	// BatchTimeout is the maximum duration for constructing a batch. Processor
+	// forcefully sends available records when timeout is reached.
+	// The default value of BatchTimeout is 1000 msec.
+	BatchTimeout time.Duration
+
+	// MaxExportBatchSize is the maximum number of records to process in a single batch.
+	// If there are more than one batch worth of records then it processes multiple batches
+	// of records one batch after the other without any delay.
+	// The default value of MaxExportBatchSize is 512.
+	MaxExportBatchSize int
+
+	// BlockOnQueueFull blocks OnEmit() method if the queue is full
+	// AND if BlockOnQueueFull is set to true.
+	// Blocking option should be used carefully as it can severely affect the performance of an
+	// application.
+	BlockOnQueueFull bool
+}
+
+// BatchLogProcessor implements LogProcessor interfaces. It is used by
+// exporters to receive export.Records asynchronously.
+// Use BatchLogProcessorOptions to change the behavior of the processor.
+type BatchLogProcessor struct {
+	e export.LogBatcher
+	o BatchLogProcessorOptions
+
+	queue   chan *export.Record
+	dropped uint32
+
+	batch    []*export.Record
+	timer    *time.Timer
+	stopWait sync.WaitGroup
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+var _ LogProcessor = (*BatchLogProcessor)(nil)
+
+// NewBatchLogProcessor creates a new instance of BatchLogProcessor
+// for a given export. It returns an error if exporter is nil.
+// The newly created BatchLogProcessor should then be registered with
+// the Provider using RegisterLogProcessor.
+func NewBatchLogProcessor(e export.LogBatcher, opts ...BatchLogProcessorOption) (*BatchLogProcessor, error) {
+	if e == nil {
+		return nil, errNilExporter
+	}
+
+	o := BatchLogProcessorOptions{
+		BatchTimeout:       DefaultBatchTimeout,
+		MaxQueueSize:       DefaultMaxQueueSize,
+		MaxExportBatchSize: DefaultMaxExportBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	blp := &BatchLogProcessor{
+		e:      e,
+		o:      o,
+		batch:  make([]*export.Record, 0, o.MaxExportBatchSize),
+		timer:  time.NewTimer(o.BatchTimeout),
+		queue:  make(chan *export.Record, o.MaxQueueSize),
+		stopCh: make(chan struct{}),
+	}
+
+	blp.stopWait.Add(1)
+	go func() {
+		defer blp.stopWait.Done()
+		blp.processQueue()
+		blp.drainQueue()
+	}()
+
+	return blp, nil
+}
+
+// OnEmit method enqueues export.Record for later processing.
+func (blp *BatchLogProcessor) OnEmit(r *export.Record) {
+	blp.enqueue(r)
+}
+
+// Shutdown flushes the queue and waits until all records are processed.
+// It only executes once. Subsequent call does nothing.
+func (blp *BatchLogProcessor) Shutdown() {
+	blp.stopOnce.Do(func() {
+		close(blp.stopCh)
+		blp.stopWait.Wait()
+	})
+}
+
+// Dropped returns the number of records dropped because the queue was
+// full.
+func (blp *BatchLogProcessor) Dropped() uint32 {
+	return atomic.LoadUint32(&blp.dropped)
+}
+
+func WithMaxQueueSize(size int) BatchLogProcessorOption {
+	return func(o *BatchLogProcessorOptions) {
+		o.MaxQueueSize = size
+	}
+}
+
+func WithMaxExportBatchSize(size int) BatchLogProcessorOption {
+	return func(o *BatchLogProcessorOptions) {
+		o.MaxExportBatchSize = size
+	}
+}
+
+func WithBatchTimeout(delay time.Duration) BatchLogProcessorOption {
+	return func(o *BatchLogProcessorOptions) {
+		o.BatchTimeout = delay
+	}
+}
+
+func WithBlocking() BatchLogProcessorOption {
+	return func(o *BatchLogProcessorOptions) {
+		o.BlockOnQueueFull = true
+	}
+}
+
+// exportLogs is a subroutine of processing and draining the queue.
+func (blp *BatchLogProcessor) exportLogs() {
+	blp.timer.Reset(blp.o.BatchTimeout)
+
+	if len(blp.batch) > 0 {
+		blp.e.ExportLogs(context.Background(), blp.batch)
+		blp.batch = blp.batch[:0]
+	}
+}
+
+// processQueue removes records from the `queue` channel until processor
+// is shut down. It calls the exporter in batches of up to MaxExportBatchSize
+// waiting up to BatchTimeout to form a batch.
+func (blp *BatchLogProcessor) processQueue() {
+	defer blp.timer.Stop()
+
+	for {
+		select {
+		case <-blp.stopCh:
+			return
+		case <-blp.timer.C:
+			blp.exportLogs()
+		case r := <-blp.queue:
+			blp.batch = append(blp.batch, r)
+			if len(blp.batch) == blp.o.MaxExportBatchSize {
+				if !blp.timer.Stop() {
+					<-blp.timer.C
+				}
+				blp.exportLogs()
+			}
+		}
+	}
+}
+
+// drainQueue awaits the any caller that had added to blp.stopWait
+// to finish the enqueue, then exports the final batch.
+func (blp *BatchLogProcessor) drainQueue() {
+	for {
+		select {
+		case r := <-blp.queue:
+			if r == nil {
+				blp.exportLogs()
+				return
+			}
+
+			blp.batch = append(blp.batch, r)
+			if len(blp.batch) == blp.o.MaxExportBatchSize {
+				blp.exportLogs()
+			}
+		default:
+			close(blp.queue)
+		}
+	}
+}
+
+func (blp *BatchLogProcessor) enqueue(r *export.Record) {
+	// This ensures the blp.queue<- below does not panic as the
+	// processor shuts down.
+	defer func() {
+		x := recover()
+		switch err := x.(type) {
+		case nil:
+			return
+		case runtime.Error:
+			if err.Error() == "send on closed channel" {
+				return
+			}
+		}
+		panic(x)
+	}()
+
+	select {
+	case <-blp.stopCh:
+		return
+	default:
+	}
+
+	if blp.o.BlockOnQueueFull {
+		blp.queue <- r
+		return
+	}
+
+	select {
+	case blp.queue <- r:
+	default:
+		atomic.AddUint32(&blp.dropped, 1)
+	}
+}
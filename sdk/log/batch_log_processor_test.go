@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apilog "github.com/Ch1f/otel/api/log"
+	export "github.com/Ch1f/otel/sdk/export/log"
+	sdklog "github.com/Ch1f/otel/sdk/log"
+)
+
+type testBatchExporter struct {
+	mu      sync.Mutex
+	records []*export.Record
+	sizes   []int
+	started chan struct{}
+	block   chan struct{}
+}
+
+func (e *testBatchExporter) ExportLogs(_ context.Context, rs []*export.Record) {
+	if e.block != nil {
+		e.started <- struct{}{}
+		<-e.block
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = append(e.records, rs...)
+	e.sizes = append(e.sizes, len(rs))
+}
+
+func (e *testBatchExporter) len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.records)
+}
+
+var _ export.LogBatcher = (*testBatchExporter)(nil)
+
+func TestNewBatchLogProcessorWithNilExporter(t *testing.T) {
+	_, err := sdklog.NewBatchLogProcessor(nil)
+	assert.Error(t, err)
+}
+
+func emitN(lp *sdklog.Provider, n int) {
+	logger := lp.Logger("instr")
+	for i := 0; i < n; i++ {
+		logger.Emit(context.Background(), apilog.Record{Severity: apilog.SeverityInfo})
+	}
+}
+
+func TestBatchLogProcessorBatchSize(t *testing.T) {
+	exp := &testBatchExporter{}
+	blp, err := sdklog.NewBatchLogProcessor(exp,
+		sdklog.WithMaxExportBatchSize(10),
+		sdklog.WithBatchTimeout(time.Hour),
+		sdklog.WithBlocking(),
+	)
+	require.NoError(t, err)
+	lp, err := sdklog.NewProvider()
+	require.NoError(t, err)
+	lp.RegisterLogProcessor(blp)
+
+	emitN(lp, 25)
+	lp.Shutdown()
+
+	assert.Equal(t, 25, exp.len())
+	assert.Equal(t, []int{10, 10, 5}, exp.sizes)
+}
+
+func TestBatchLogProcessorTimeout(t *testing.T) {
+	exp := &testBatchExporter{}
+	lp, err := sdklog.NewProvider(sdklog.WithBatcher(exp, sdklog.WithBatchTimeout(10*time.Millisecond)))
+	require.NoError(t, err)
+	defer lp.Shutdown()
+
+	emitN(lp, 3)
+	assert.Eventually(t, func() bool { return exp.len() == 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestBatchLogProcessorDropsWhenFull(t *testing.T) {
+	exp := &testBatchExporter{
+		started: make(chan struct{}, 10),
+		block:   make(chan struct{}),
+	}
+	blp, err := sdklog.NewBatchLogProcessor(exp,
+		sdklog.WithMaxQueueSize(5),
+		sdklog.WithMaxExportBatchSize(1),
+	)
+	require.NoError(t, err)
+	lp, err := sdklog.NewProvider()
+	require.NoError(t, err)
+	lp.RegisterLogProcessor(blp)
+
+	// The first record is taken off the queue and blocks in the
+	// exporter, the next five fill the queue and the rest are dropped.
+	emitN(lp, 1)
+	<-exp.started
+	emitN(lp, 10)
+	close(exp.block)
+	lp.Shutdown()
+
+	assert.Equal(t, uint32(5), blp.Dropped())
+	assert.Equal(t, 6, exp.len())
+}
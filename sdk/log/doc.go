@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package log contains support for OpenTelemetry logs.
+
+A Provider creates Loggers that turn api/log Records into export Records,
+stamping them with the Provider's resource, the Logger's instrumentation
+library and, when the emitting context carries an active span, the
+span's trace ID, span ID and trace flags. Records are handed to every
+registered LogProcessor; SimpleLogProcessor and BatchLogProcessor pass
+them on to a LogSyncer or LogBatcher exporter respectively.
+*/
+package log // import "github.com/Ch1f/otel/sdk/log"
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+
+	export "github.com/Ch1f/otel/sdk/export/log"
+)
+
+// LogProcessor is interface to add hooks to emitted log records.
+type LogProcessor interface {
+
+	// OnEmit method is invoked when a record is emitted. It is a
+	// synchronous call and hence should not block.
+	OnEmit(r *export.Record)
+
+	// Shutdown is invoked when SDK shutsdown. Use this call to cleanup any processor
+	// data. No calls to OnEmit method is invoked after Shutdown call is made. It
+	// should not be blocked indefinitely.
+	Shutdown()
+}
+
+type logProcessorMap map[LogProcessor]*sync.Once
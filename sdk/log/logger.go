@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	apilog "github.com/Ch1f/otel/api/log"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/log"
+	"github.com/Ch1f/otel/sdk/instrumentation"
+)
+
+type logger struct {
+	provider               *Provider
+	instrumentationLibrary instrumentation.Library
+}
+
+var _ apilog.Logger = &logger{}
+
+// Emit builds an export.Record from r and passes it to every registered
+// LogProcessor. The record is correlated with the span active in ctx,
+// if any.
+func (l *logger) Emit(ctx context.Context, r apilog.Record) {
+	lps, _ := l.provider.logProcessors.Load().(logProcessorMap)
+	if len(lps) == 0 {
+		return
+	}
+
+	rec := &export.Record{
+		Timestamp:              r.Timestamp,
+		Severity:               r.Severity,
+		SeverityText:           r.SeverityText,
+		Body:                   r.Body,
+		Resource:               l.provider.resource,
+		InstrumentationLibrary: l.instrumentationLibrary,
+	}
+	if rec.Timestamp.IsZero() {
+		rec.Timestamp = time.Now()
+	}
+	if rec.SeverityText == "" && r.Severity != apilog.SeverityUndefined {
+		rec.SeverityText = r.Severity.String()
+	}
+	if len(r.Attributes) > 0 {
+		// The caller may reuse its slice once Emit returns.
+		rec.Attributes = make([]kv.KeyValue, len(r.Attributes))
+		copy(rec.Attributes, r.Attributes)
+	}
+	if sc := apitrace.SpanFromContext(ctx).SpanContext(); sc.IsValid() {
+		rec.TraceID = sc.TraceID
+		rec.SpanID = sc.SpanID
+		rec.TraceFlags = sc.TraceFlags
+	}
+
+	for lp := range lps {
+		lp.OnEmit(rec)
+	}
+}
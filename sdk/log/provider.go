@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+
+	apilog "github.com/Ch1f/otel/api/log"
+	export "github.com/Ch1f/otel/sdk/export/log"
+	"github.com/Ch1f/otel/sdk/instrumentation"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+const (
+	defaultLoggerName = "github.com/Ch1f/otel/sdk/logger"
+)
+
+// batcher contains export.LogBatcher and its options.
+type batcher struct {
+	b    export.LogBatcher
+	opts []BatchLogProcessorOption
+}
+
+// ProviderOptions
+type ProviderOptions struct {
+	syncers  []export.LogSyncer
+	batchers []batcher
+	resource *resource.Resource
+}
+
+type ProviderOption func(*ProviderOptions)
+
+// Provider is the SDK implementation of the api/log Provider.
+type Provider struct {
+	mu            sync.Mutex
+	namedLogger   map[instrumentation.Library]*logger
+	logProcessors atomic.Value
+	resource      *resource.Resource
+}
+
+var _ apilog.Provider = &Provider{}
+
+// NewProvider creates an instance of log provider. Optional
+// parameter configures the provider with common options applicable
+// to all logger instances that will be created by this provider.
+func NewProvider(opts ...ProviderOption) (*Provider, error) {
+	o := &ProviderOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	lp := &Provider{
+		namedLogger: make(map[instrumentation.Library]*logger),
+		resource:    o.resource,
+	}
+
+	for _, syncer := range o.syncers {
+		lp.RegisterLogProcessor(NewSimpleLogProcessor(syncer))
+	}
+
+	for _, batcher := range o.batchers {
+		blp, err := NewBatchLogProcessor(batcher.b, batcher.opts...)
+		if err != nil {
+			lp.Shutdown()
+			return nil, err
+		}
+		lp.RegisterLogProcessor(blp)
+	}
+
+	return lp, nil
+}
+
+// Logger with the given name. If a logger for the given name does not exist,
+// it is created first. If the name is empty, a default name is used.
+func (p *Provider) Logger(name string, opts ...apilog.LoggerOption) apilog.Logger {
+	c := new(apilog.LoggerConfig)
+	for _, o := range opts {
+		o(c)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if name == "" {
+		name = defaultLoggerName
+	}
+	il := instrumentation.Library{
+		Name:    name,
+		Version: c.InstrumentationVersion,
+	}
+	l, ok := p.namedLogger[il]
+	if !ok {
+		l = &logger{
+			provider:               p,
+			instrumentationLibrary: il,
+		}
+		p.namedLogger[il] = l
+	}
+	return l
+}
+
+// RegisterLogProcessor adds the given LogProcessor to the list of LogProcessors
+func (p *Provider) RegisterLogProcessor(s LogProcessor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	new := make(logProcessorMap)
+	if old, ok := p.logProcessors.Load().(logProcessorMap); ok {
+		for k, v := range old {
+			new[k] = v
+		}
+	}
+	new[s] = &sync.Once{}
+	p.logProcessors.Store(new)
+}
+
+// UnregisterLogProcessor removes the given LogProcessor from the list of
+// LogProcessors and shuts it down.
+func (p *Provider) UnregisterLogProcessor(s LogProcessor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	new := make(logProcessorMap)
+	if old, ok := p.logProcessors.Load().(logProcessorMap); ok {
+		for k, v := range old {
+			new[k] = v
+		}
+	}
+	if stopOnce, ok := new[s]; ok && stopOnce != nil {
+		stopOnce.Do(func() {
+			s.Shutdown()
+		})
+	}
+	delete(new, s)
+	p.logProcessors.Store(new)
+}
+
+// Shutdown unregisters and shuts down every registered LogProcessor,
+// flushing any records they buffer.
+func (p *Provider) Shutdown() {
+	p.mu.Lock()
+	old, _ := p.logProcessors.Load().(logProcessorMap)
+	p.logProcessors.Store(make(logProcessorMap))
+	p.mu.Unlock()
+	for s, stopOnce := range old {
+		s := s
+		stopOnce.Do(func() {
+			s.Shutdown()
+		})
+	}
+}
+
+// WithSyncer options appends the syncer to the existing list of Syncers.
+// This option can be used multiple times.
+// The Syncers are wrapped into SimpleLogProcessors and registered
+// with the provider.
+func WithSyncer(syncer export.LogSyncer) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.syncers = append(opts.syncers, syncer)
+	}
+}
+
+// WithBatcher options appends the batcher to the existing list of Batchers.
+// This option can be used multiple times.
+// The Batchers are wrapped into BatchLogProcessors and registered
+// with the provider.
+func WithBatcher(b export.LogBatcher, bopts ...BatchLogProcessorOption) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.batchers = append(opts.batchers, batcher{b, bopts})
+	}
+}
+
+// WithResource option attaches a resource to the provider.
+// The resource is added to every record emitted by its loggers.
+func WithResource(r *resource.Resource) ProviderOption {
+	return func(opts *ProviderOptions) {
+		opts.resource = r
+	}
+}
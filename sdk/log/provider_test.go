@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+	apilog "github.com/Ch1f/otel/api/log"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/log"
+	"github.com/Ch1f/otel/sdk/instrumentation"
+	sdklog "github.com/Ch1f/otel/sdk/log"
+	"github.com/Ch1f/otel/sdk/resource"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+type testSyncExporter struct {
+	mu      sync.Mutex
+	records []*export.Record
+}
+
+func (e *testSyncExporter) ExportLog(_ context.Context, r *export.Record) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.records = append(e.records, r)
+}
+
+func (e *testSyncExporter) get() []*export.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]*export.Record(nil), e.records...)
+}
+
+var _ export.LogSyncer = (*testSyncExporter)(nil)
+
+func TestLoggerEmit(t *testing.T) {
+	exp := &testSyncExporter{}
+	res := resource.New(kv.String("service.name", "test"))
+	lp, err := sdklog.NewProvider(sdklog.WithSyncer(exp), sdklog.WithResource(res))
+	require.NoError(t, err)
+
+	ts := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	attrs := []kv.KeyValue{kv.String("A", "B")}
+	logger := lp.Logger("instr", apilog.WithInstrumentationVersion("v0.1.0"))
+	logger.Emit(context.Background(), apilog.Record{
+		Timestamp:  ts,
+		Severity:   apilog.SeverityWarn,
+		Body:       value.String("hello"),
+		Attributes: attrs,
+	})
+	attrs[0] = kv.String("A", "changed")
+
+	records := exp.get()
+	require.Len(t, records, 1)
+	assert.Equal(t, &export.Record{
+		Timestamp:    ts,
+		Severity:     apilog.SeverityWarn,
+		SeverityText: "WARN",
+		Body:         value.String("hello"),
+		Attributes:   []kv.KeyValue{kv.String("A", "B")},
+		Resource:     res,
+		InstrumentationLibrary: instrumentation.Library{
+			Name:    "instr",
+			Version: "v0.1.0",
+		},
+	}, records[0])
+}
+
+func TestLoggerEmitDefaults(t *testing.T) {
+	exp := &testSyncExporter{}
+	lp, err := sdklog.NewProvider(sdklog.WithSyncer(exp))
+	require.NoError(t, err)
+
+	before := time.Now()
+	lp.Logger("").Emit(context.Background(), apilog.Record{SeverityText: "notice"})
+
+	records := exp.get()
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.False(t, r.Timestamp.Before(before))
+	assert.Equal(t, "notice", r.SeverityText)
+	assert.NotEmpty(t, r.InstrumentationLibrary.Name)
+	assert.False(t, r.TraceID.IsValid())
+	assert.False(t, r.SpanID.IsValid())
+}
+
+func TestLoggerTraceCorrelation(t *testing.T) {
+	exp := &testSyncExporter{}
+	lp, err := sdklog.NewProvider(sdklog.WithSyncer(exp))
+	require.NoError(t, err)
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+
+	ctx, span := tp.Tracer("tracer").Start(context.Background(), "span")
+	lp.Logger("instr").Emit(ctx, apilog.Record{Body: value.String("in span")})
+	span.End()
+
+	records := exp.get()
+	require.Len(t, records, 1)
+	sc := span.SpanContext()
+	assert.Equal(t, sc.TraceID, records[0].TraceID)
+	assert.Equal(t, sc.SpanID, records[0].SpanID)
+	assert.Equal(t, apitrace.FlagsSampled, records[0].TraceFlags)
+}
+
+func TestProviderSameLogger(t *testing.T) {
+	lp, err := sdklog.NewProvider()
+	require.NoError(t, err)
+	assert.Same(t, lp.Logger("a"), lp.Logger("a"))
+	assert.NotSame(t, lp.Logger("a"), lp.Logger("a", apilog.WithInstrumentationVersion("v1")))
+}
+
+type testProcessor struct {
+	emitted  int
+	shutdown int
+}
+
+func (p *testProcessor) OnEmit(*export.Record) { p.emitted++ }
+func (p *testProcessor) Shutdown()             { p.shutdown++ }
+
+func TestProviderProcessors(t *testing.T) {
+	lp, err := sdklog.NewProvider()
+	require.NoError(t, err)
+	p1, p2 := &testProcessor{}, &testProcessor{}
+	lp.RegisterLogProcessor(p1)
+	lp.RegisterLogProcessor(p2)
+
+	logger := lp.Logger("instr")
+	logger.Emit(context.Background(), apilog.Record{})
+
+	lp.UnregisterLogProcessor(p1)
+	lp.UnregisterLogProcessor(p1)
+	logger.Emit(context.Background(), apilog.Record{})
+
+	lp.Shutdown()
+	lp.Shutdown()
+	logger.Emit(context.Background(), apilog.Record{})
+
+	assert.Equal(t, 1, p1.emitted)
+	assert.Equal(t, 1, p1.shutdown)
+	assert.Equal(t, 2, p2.emitted)
+	assert.Equal(t, 1, p2.shutdown)
+}
+
+func TestNewProviderWithNilBatcher(t *testing.T) {
+	_, err := sdklog.NewProvider(sdklog.WithBatcher(nil))
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"context"
+
+	export "github.com/Ch1f/otel/sdk/export/log"
+)
+
+// SimpleLogProcessor implements LogProcessor interfaces. It is used by
+// exporters to receive log records synchronously when they are emitted.
+type SimpleLogProcessor struct {
+	e export.LogSyncer
+}
+
+var _ LogProcessor = (*SimpleLogProcessor)(nil)
+
+// NewSimpleLogProcessor creates a new instance of SimpleLogProcessor
+// for a given export.
+func NewSimpleLogProcessor(e export.LogSyncer) *SimpleLogProcessor {
+	return &SimpleLogProcessor{
+		e: e,
+	}
+}
+
+// OnEmit method exports the Record using associated export.
+func (slp *SimpleLogProcessor) OnEmit(r *export.Record) {
+	if slp.e != nil {
+		slp.e.ExportLog(context.Background(), r)
+	}
+}
+
+// Shutdown method does nothing. There is no data to cleanup.
+func (slp *SimpleLogProcessor) Shutdown() {
+}
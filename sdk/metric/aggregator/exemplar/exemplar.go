@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar provides the reservoir used by aggregators to sample
+// exemplars, measurements annotated with the trace context they were
+// recorded in.
+//
+// Only measurements recorded within a sampled span are offered to a
+// reservoir, so that every exemplar links to a trace that was
+// exported. Labels that should be kept with exemplars but not be part
+// of the aggregated label set, e.g., a user ID, are attached to the
+// measurement context with ContextWithFilteredLabels.
+package exemplar // import "github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+)
+
+type filteredLabelsKeyType int
+
+const filteredLabelsKey filteredLabelsKeyType = 0
+
+// ContextWithFilteredLabels returns a copy of ctx carrying labels that
+// are recorded with any exemplar sampled from a measurement made with
+// the returned context.
+func ContextWithFilteredLabels(ctx context.Context, labels ...kv.KeyValue) context.Context {
+	return context.WithValue(ctx, filteredLabelsKey, labels)
+}
+
+// FilteredLabelsFromContext returns the labels attached to ctx with
+// ContextWithFilteredLabels.
+func FilteredLabelsFromContext(ctx context.Context) []kv.KeyValue {
+	labels, _ := ctx.Value(filteredLabelsKey).([]kv.KeyValue)
+	return labels
+}
+
+// Reservoir keeps a uniform random sample of at most size of the
+// measurements offered to it. It is not safe for concurrent use, the
+// aggregator owning it provides synchronization.
+type Reservoir struct {
+	size      int
+	seen      int64
+	exemplars []aggregation.Exemplar
+}
+
+// NewReservoir returns a Reservoir holding up to size exemplars.
+func NewReservoir(size int) Reservoir {
+	return Reservoir{size: size}
+}
+
+// Offer samples number if ctx carries a sampled span. Reservoir
+// sampling is used so that every measurement offered since the last
+// Reset has the same chance of being kept.
+func (r *Reservoir) Offer(ctx context.Context, number metric.Number) {
+	if r.size <= 0 {
+		return
+	}
+	sc := apitrace.SpanFromContext(ctx).SpanContext()
+	if !sc.IsSampled() {
+		return
+	}
+	r.seen++
+	idx := len(r.exemplars)
+	if idx >= r.size {
+		idx = int(rand.Int63n(r.seen))
+		if idx >= r.size {
+			return
+		}
+	}
+	e := aggregation.Exemplar{
+		Value:          number,
+		Time:           time.Now(),
+		FilteredLabels: FilteredLabelsFromContext(ctx),
+		TraceID:        sc.TraceID,
+		SpanID:         sc.SpanID,
+	}
+	if idx == len(r.exemplars) {
+		r.exemplars = append(r.exemplars, e)
+		return
+	}
+	r.exemplars[idx] = e
+}
+
+// Exemplars returns the sampled exemplars. The returned slice must not
+// be modified.
+func (r *Reservoir) Exemplars() []aggregation.Exemplar {
+	return r.exemplars
+}
+
+// Copy returns a copy of r that does not share its exemplars with r.
+func (r *Reservoir) Copy() Reservoir {
+	c := *r
+	if r.exemplars != nil {
+		c.exemplars = make([]aggregation.Exemplar, len(r.exemplars), r.size)
+		copy(c.exemplars, r.exemplars)
+	}
+	return c
+}
+
+// Merge adds the exemplars of o to r. If they do not fit, the most
+// recent exemplars are kept.
+func (r *Reservoir) Merge(o *Reservoir) {
+	if len(o.exemplars) == 0 {
+		return
+	}
+	r.seen += o.seen
+	merged := make([]aggregation.Exemplar, 0, len(r.exemplars)+len(o.exemplars))
+	merged = append(merged, r.exemplars...)
+	merged = append(merged, o.exemplars...)
+	if len(merged) > r.size {
+		sort.SliceStable(merged, func(i, j int) bool {
+			return merged[i].Time.After(merged[j].Time)
+		})
+		merged = merged[:r.size]
+	}
+	r.exemplars = merged
+}
+
+// Reset discards all exemplars.
+func (r *Reservoir) Reset() {
+	r.seen = 0
+	r.exemplars = nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func sampledContext(t *testing.T, sampled bool) (context.Context, apitrace.SpanContext) {
+	sampler := sdktrace.AlwaysSample()
+	if !sampled {
+		sampler = sdktrace.NeverSample()
+	}
+	tp, err := sdktrace.NewProvider(sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sampler}))
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	sc := span.SpanContext()
+	require.Equal(t, sampled, sc.IsSampled())
+	return ctx, sc
+}
+
+func TestReservoirOffer(t *testing.T) {
+	ctx, sc := sampledContext(t, true)
+	ctx = exemplar.ContextWithFilteredLabels(ctx, kv.String("user.id", "42"))
+	unsampled, _ := sampledContext(t, false)
+
+	r := exemplar.NewReservoir(2)
+	before := time.Now()
+	r.Offer(ctx, metric.NewInt64Number(1))
+	r.Offer(unsampled, metric.NewInt64Number(2))
+	r.Offer(context.Background(), metric.NewInt64Number(3))
+
+	es := r.Exemplars()
+	require.Len(t, es, 1)
+	assert.Equal(t, metric.NewInt64Number(1), es[0].Value)
+	assert.False(t, es[0].Time.Before(before))
+	assert.Equal(t, []kv.KeyValue{kv.String("user.id", "42")}, es[0].FilteredLabels)
+	assert.Equal(t, sc.TraceID, es[0].TraceID)
+	assert.Equal(t, sc.SpanID, es[0].SpanID)
+
+	r.Reset()
+	assert.Empty(t, r.Exemplars())
+}
+
+func TestReservoirSampling(t *testing.T) {
+	ctx, _ := sampledContext(t, true)
+
+	// Each of 10 values offered to a reservoir of 2 should be kept
+	// with probability 1/5.
+	const trials = 5000
+	kept := make([]int, 10)
+	for i := 0; i < trials; i++ {
+		r := exemplar.NewReservoir(2)
+		for v := 0; v < 10; v++ {
+			r.Offer(ctx, metric.NewInt64Number(int64(v)))
+		}
+		require.Len(t, r.Exemplars(), 2)
+		for _, e := range r.Exemplars() {
+			kept[e.Value.AsInt64()]++
+		}
+	}
+	for v, n := range kept {
+		assert.InDelta(t, trials/5, n, trials/20, "value %d", v)
+	}
+}
+
+func TestReservoirDisabled(t *testing.T) {
+	ctx, _ := sampledContext(t, true)
+	r := exemplar.NewReservoir(0)
+	r.Offer(ctx, metric.NewInt64Number(1))
+	assert.Empty(t, r.Exemplars())
+}
+
+func TestReservoirCopy(t *testing.T) {
+	ctx, _ := sampledContext(t, true)
+
+	r := exemplar.NewReservoir(1)
+	r.Offer(ctx, metric.NewInt64Number(0))
+	c := r.Copy()
+
+	// Offered values replace the exemplar of r, but not that of its
+	// copy.
+	for v := 1; v <= 1000; v++ {
+		r.Offer(ctx, metric.NewInt64Number(int64(v)))
+	}
+	require.Len(t, c.Exemplars(), 1)
+	assert.Equal(t, metric.NewInt64Number(0), c.Exemplars()[0].Value)
+}
+
+func TestReservoirMerge(t *testing.T) {
+	ctx, _ := sampledContext(t, true)
+
+	a := exemplar.NewReservoir(2)
+	a.Offer(ctx, metric.NewInt64Number(1))
+	b := exemplar.NewReservoir(2)
+	b.Merge(&a)
+	require.Len(t, b.Exemplars(), 1)
+
+	time.Sleep(time.Millisecond)
+	c := exemplar.NewReservoir(2)
+	c.Offer(ctx, metric.NewInt64Number(2))
+	c.Offer(ctx, metric.NewInt64Number(3))
+	b.Merge(&c)
+
+	var values []int64
+	for _, e := range b.Exemplars() {
+		values = append(values, e.Value.AsInt64())
+	}
+	assert.ElementsMatch(t, []int64{2, 3}, values)
+}
@@ -23,6 +23,7 @@ import (
 	export "github.com/Ch1f/otel/sdk/export/metric"
 	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
 	"github.com/Ch1f/otel/sdk/metric/aggregator"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
 )
 
 // Note: This code uses a Mutex to govern access to the exclusive
@@ -37,6 +38,7 @@ type (
 		lock       sync.Mutex
 		boundaries []float64
 		kind       metric.NumberKind
+		exemplars  int
 		state      state
 	}
 
@@ -47,6 +49,17 @@ type (
 		bucketCounts []float64
 		sum          metric.Number
 		count        int64
+
+		// exemplars holds a reservoir per bucket, it is nil
+		// unless exemplars are enabled.
+		exemplars []exemplar.Reservoir
+	}
+
+	// Option configures a histogram Aggregator.
+	Option func(*config)
+
+	config struct {
+		exemplars int
 	}
 )
 
@@ -54,6 +67,15 @@ var _ export.Aggregator = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
 var _ aggregation.Count = &Aggregator{}
 var _ aggregation.Histogram = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
+
+// WithExemplars enables sampling up to size exemplars per bucket from
+// the measurements recorded within a sampled span.
+func WithExemplars(size int) Option {
+	return func(c *config) {
+		c.exemplars = size
+	}
+}
 
 // New returns a new aggregator for computing Histograms.
 //
@@ -63,7 +85,13 @@ var _ aggregation.Histogram = &Aggregator{}
 // Note that this aggregator maintains each value using independent
 // atomic operations, which introduces the possibility that
 // checkpoints are inconsistent.
-func New(cnt int, desc *metric.Descriptor, boundaries []float64) []Aggregator {
+//
+// Exemplars are sampled per bucket if WithExemplars is given.
+func New(cnt int, desc *metric.Descriptor, boundaries []float64, opts ...Option) []Aggregator {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
 	aggs := make([]Aggregator, cnt)
 
 	// Boundaries MUST be ordered otherwise the histogram could not
@@ -77,7 +105,8 @@ func New(cnt int, desc *metric.Descriptor, boundaries []float64) []Aggregator {
 		aggs[i] = Aggregator{
 			kind:       desc.NumberKind(),
 			boundaries: sortedBoundaries,
-			state:      emptyState(sortedBoundaries),
+			exemplars:  cfg.exemplars,
+			state:      emptyState(sortedBoundaries, cfg.exemplars),
 		}
 	}
 	return aggs
@@ -111,6 +140,15 @@ func (c *Aggregator) Histogram() (aggregation.Buckets, error) {
 	}, nil
 }
 
+// Exemplars returns the exemplars in the checkpoint, ordered by bucket.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	var exemplars []aggregation.Exemplar
+	for i := range c.state.exemplars {
+		exemplars = append(exemplars, c.state.exemplars[i].Exemplars()...)
+	}
+	return exemplars, nil
+}
+
 // SynchronizedMove saves the current state into oa and resets the current state to
 // the empty set.  Since no locks are taken, there is a chance that
 // the independent Sum, Count and Bucket Count are not consistent with each
@@ -122,19 +160,26 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, desc *metric.Descrip
 	}
 
 	c.lock.Lock()
-	o.state, c.state = c.state, emptyState(c.boundaries)
+	o.state, c.state = c.state, emptyState(c.boundaries, c.exemplars)
 	c.lock.Unlock()
 	return nil
 }
 
-func emptyState(boundaries []float64) state {
-	return state{
+func emptyState(boundaries []float64, exemplars int) state {
+	s := state{
 		bucketCounts: make([]float64, len(boundaries)+1),
 	}
+	if exemplars > 0 {
+		s.exemplars = make([]exemplar.Reservoir, len(boundaries)+1)
+		for i := range s.exemplars {
+			s.exemplars[i] = exemplar.NewReservoir(exemplars)
+		}
+	}
+	return s
 }
 
 // Update adds the recorded measurement to the current data set.
-func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metric.Descriptor) error {
+func (c *Aggregator) Update(ctx context.Context, number metric.Number, desc *metric.Descriptor) error {
 	kind := desc.NumberKind()
 	asFloat := number.CoerceToFloat64(kind)
 
@@ -163,6 +208,9 @@ func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metri
 	c.state.count++
 	c.state.sum.AddNumber(kind, number)
 	c.state.bucketCounts[bucketID]++
+	if c.state.exemplars != nil {
+		c.state.exemplars[bucketID].Offer(ctx, number)
+	}
 
 	return nil
 }
@@ -180,5 +228,10 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 	for i := 0; i < len(c.state.bucketCounts); i++ {
 		c.state.bucketCounts[i] += o.state.bucketCounts[i]
 	}
+	if c.state.exemplars != nil && o.state.exemplars != nil {
+		for i := range c.state.exemplars {
+			c.state.exemplars[i].Merge(&o.state.exemplars[i])
+		}
+	}
 	return nil
 }
@@ -15,6 +15,7 @@
 package histogram_test
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"sort"
@@ -25,6 +26,7 @@ import (
 	"github.com/Ch1f/otel/api/metric"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/histogram"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/test"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
 
 const count = 100
@@ -231,6 +233,47 @@ func TestHistogramNotSet(t *testing.T) {
 	})
 }
 
+func TestHistogramExemplars(t *testing.T) {
+	descriptor := test.NewAggregatorTest(metric.ValueRecorderKind, metric.Int64NumberKind)
+	aggs := histogram.New(4, descriptor, boundaries, histogram.WithExemplars(1))
+	agg1, agg2, ckpt1, ckpt2 := &aggs[0], &aggs[1], &aggs[2], &aggs[3]
+
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	require.NoError(t, agg1.Update(ctx, metric.NewInt64Number(100), descriptor))
+	require.NoError(t, agg1.Update(ctx, metric.NewInt64Number(800), descriptor))
+	require.NoError(t, agg1.Update(context.Background(), metric.NewInt64Number(600), descriptor))
+	require.NoError(t, agg2.Update(ctx, metric.NewInt64Number(200), descriptor))
+	require.NoError(t, agg2.Update(ctx, metric.NewInt64Number(300), descriptor))
+
+	require.NoError(t, agg1.SynchronizedMove(ckpt1, descriptor))
+	require.NoError(t, agg2.SynchronizedMove(ckpt2, descriptor))
+
+	exemplars, err := agg1.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars)
+
+	exemplars, err = ckpt1.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2)
+	require.Equal(t, metric.NewInt64Number(100), exemplars[0].Value)
+	require.Equal(t, metric.NewInt64Number(800), exemplars[1].Value)
+	require.Equal(t, span.SpanContext().TraceID, exemplars[0].TraceID)
+	require.Equal(t, span.SpanContext().SpanID, exemplars[0].SpanID)
+
+	// One exemplar per bucket is kept, the most recent on merge.
+	test.CheckedMerge(t, ckpt1, ckpt2, descriptor)
+	exemplars, err = ckpt1.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 3)
+	require.Equal(t, metric.NewInt64Number(200), exemplars[0].Value)
+	require.Equal(t, metric.NewInt64Number(300), exemplars[1].Value)
+	require.Equal(t, metric.NewInt64Number(800), exemplars[2].Value)
+}
+
 func calcBuckets(points []metric.Number, profile test.Profile) []uint64 {
 	sortedBoundaries := make([]float64, len(boundaries))
 
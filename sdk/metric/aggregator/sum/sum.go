@@ -16,11 +16,13 @@ package sum // import "github.com/Ch1f/otel/sdk/metric/aggregator/sum"
 
 import (
 	"context"
+	"sync"
 
 	"github.com/Ch1f/otel/api/metric"
 	export "github.com/Ch1f/otel/sdk/export/metric"
 	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
 	"github.com/Ch1f/otel/sdk/metric/aggregator"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
 )
 
 // Aggregator aggregates counter events.
@@ -28,17 +30,60 @@ type Aggregator struct {
 	// current holds current increments to this counter record
 	// current needs to be aligned for 64-bit atomic operations.
 	value metric.Number
+
+	// exemplars is nil unless exemplars are enabled.
+	exemplars *exemplars
+}
+
+// exemplars guards the exemplar reservoir, which cannot be updated
+// atomically like the value.
+type exemplars struct {
+	lock      sync.Mutex
+	size      int
+	reservoir exemplar.Reservoir
+}
+
+// Option configures a sum Aggregator.
+type Option func(*config)
+
+type config struct {
+	exemplars int
+}
+
+// WithExemplars enables sampling up to size exemplars from the
+// measurements recorded within a sampled span.
+func WithExemplars(size int) Option {
+	return func(c *config) {
+		c.exemplars = size
+	}
 }
 
 var _ export.Aggregator = &Aggregator{}
 var _ export.Subtractor = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new counter aggregator implemented by atomic
 // operations.  This aggregator implements the aggregation.Sum
 // export interface.
-func New(cnt int) []Aggregator {
-	return make([]Aggregator, cnt)
+//
+// Exemplars are sampled if WithExemplars is given, which adds a lock
+// to every update.
+func New(cnt int, opts ...Option) []Aggregator {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	aggs := make([]Aggregator, cnt)
+	if cfg.exemplars > 0 {
+		for i := range aggs {
+			aggs[i].exemplars = &exemplars{
+				size:      cfg.exemplars,
+				reservoir: exemplar.NewReservoir(cfg.exemplars),
+			}
+		}
+	}
+	return aggs
 }
 
 // Aggregation returns an interface for reading the state of this aggregator.
@@ -57,6 +102,16 @@ func (c *Aggregator) Sum() (metric.Number, error) {
 	return c.value, nil
 }
 
+// Exemplars returns the last-checkpointed exemplars.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	if c.exemplars == nil {
+		return nil, nil
+	}
+	c.exemplars.lock.Lock()
+	defer c.exemplars.lock.Unlock()
+	return c.exemplars.reservoir.Exemplars(), nil
+}
+
 // SynchronizedMove atomically saves the current value into oa and resets the
 // current sum to zero.
 func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor) error {
@@ -65,12 +120,23 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	o.value = c.value.SwapNumberAtomic(metric.Number(0))
+	if c.exemplars != nil && o.exemplars != nil {
+		c.exemplars.lock.Lock()
+		o.exemplars.reservoir = c.exemplars.reservoir
+		c.exemplars.reservoir = exemplar.NewReservoir(c.exemplars.size)
+		c.exemplars.lock.Unlock()
+	}
 	return nil
 }
 
 // Update atomically adds to the current value.
-func (c *Aggregator) Update(_ context.Context, number metric.Number, desc *metric.Descriptor) error {
+func (c *Aggregator) Update(ctx context.Context, number metric.Number, desc *metric.Descriptor) error {
 	c.value.AddNumberAtomic(desc.NumberKind(), number)
+	if c.exemplars != nil {
+		c.exemplars.lock.Lock()
+		c.exemplars.reservoir.Offer(ctx, number)
+		c.exemplars.lock.Unlock()
+	}
 	return nil
 }
 
@@ -81,6 +147,9 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	c.value.AddNumber(desc.NumberKind(), o.value)
+	if c.exemplars != nil && o.exemplars != nil {
+		c.exemplars.reservoir.Merge(&o.exemplars.reservoir)
+	}
 	return nil
 }
 
@@ -97,5 +166,8 @@ func (c *Aggregator) Subtract(opAgg, resAgg export.Aggregator, descriptor *metri
 
 	res.value = c.value
 	res.value.AddNumber(descriptor.NumberKind(), metric.NewNumberSignChange(descriptor.NumberKind(), op.value))
+	if c.exemplars != nil && res.exemplars != nil {
+		res.exemplars.reservoir = c.exemplars.reservoir.Copy()
+	}
 	return nil
 }
@@ -15,15 +15,19 @@
 package sum
 
 import (
+	"context"
 	"os"
 	"testing"
 	"unsafe"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/metric"
 	ottest "github.com/Ch1f/otel/internal/testing"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/exemplar"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/test"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
 
 const count = 100
@@ -140,3 +144,55 @@ func TestCounterMerge(t *testing.T) {
 		require.Nil(t, err)
 	})
 }
+
+func TestCounterExemplars(t *testing.T) {
+	alloc := New(4, WithExemplars(2))
+	agg, ckpt, prev, delta := &alloc[0], &alloc[1], &alloc[2], &alloc[3]
+	descriptor := test.NewAggregatorTest(metric.CounterKind, metric.Int64NumberKind)
+
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+	ctx = exemplar.ContextWithFilteredLabels(ctx, kv.String("user.id", "42"))
+
+	test.CheckedUpdate(t, agg, metric.NewInt64Number(1), descriptor)
+	require.NoError(t, agg.Update(ctx, metric.NewInt64Number(2), descriptor))
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	exemplars, err := agg.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars)
+
+	exemplars, err = ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 1)
+	require.Equal(t, metric.NewInt64Number(2), exemplars[0].Value)
+	require.Equal(t, []kv.KeyValue{kv.String("user.id", "42")}, exemplars[0].FilteredLabels)
+	require.Equal(t, span.SpanContext().TraceID, exemplars[0].TraceID)
+
+	require.NoError(t, ckpt.Subtract(prev, delta, descriptor))
+	ckptExemplars := exemplars
+	exemplars, err = delta.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 1)
+	// The difference does not share the exemplars of the checkpoint.
+	require.NotSame(t, &ckptExemplars[0], &exemplars[0])
+
+	test.CheckedMerge(t, prev, ckpt, descriptor)
+	exemplars, err = prev.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 1)
+}
+
+func TestCounterWithoutExemplars(t *testing.T) {
+	agg, ckpt := new2()
+	descriptor := test.NewAggregatorTest(metric.CounterKind, metric.Int64NumberKind)
+
+	test.CheckedUpdate(t, agg, metric.NewInt64Number(1), descriptor)
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	exemplars, err := ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars)
+}
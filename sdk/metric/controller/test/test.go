@@ -49,6 +49,10 @@ func (c MockClock) Add(d time.Duration) {
 	c.mock.Add(d)
 }
 
+func (c MockClock) Set(t time.Time) {
+	c.mock.Set(t)
+}
+
 func (t MockTicker) Stop() {
 	t.ticker.Stop()
 }
@@ -20,6 +20,7 @@ import (
 	"math"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -585,3 +586,139 @@ func TestSyncInAsync(t *testing.T) {
 		"observer.lastvalue//R=V": 10,
 	}, out.Map)
 }
+
+func TestGaugeLastValue(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	gauge := Must(meter).NewFloat64Gauge("gauge.lastvalue")
+	gauge.Set(ctx, 1, kv.String("A", "B"))
+	gauge.Set(ctx, 3, kv.String("A", "B"))
+	bound := gauge.Bind(kv.String("C", "D"))
+	defer bound.Unbind()
+	bound.Set(ctx, -2)
+
+	checkpointed := sdk.Collect(ctx)
+	require.Equal(t, 2, checkpointed)
+
+	out := batchTest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"gauge.lastvalue/A=B/R=V": 3,
+		"gauge.lastvalue/C=D/R=V": -2,
+	}, out.Map)
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestUnregisterObserver(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	calls := 0
+	obs := Must(meter).NewInt64ValueObserver("observer.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			calls++
+			result.Observe(10)
+		},
+	)
+
+	require.Equal(t, 1, sdk.Collect(ctx))
+	require.Equal(t, 1, calls)
+
+	require.NoError(t, obs.Unregister())
+
+	processor.accumulations = nil
+	require.Equal(t, 0, sdk.Collect(ctx))
+	require.Equal(t, 1, calls)
+	require.Empty(t, processor.accumulations)
+
+	require.Equal(t, metricsdk.ErrUninitializedInstrument, sdk.UnregisterAsyncInstrument(metric.NoopAsync{}))
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestUnregisterBatchObserver(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	var intObs metric.Int64ValueObserver
+	var floatObs metric.Float64SumObserver
+	calls := 0
+
+	batch := Must(meter).NewBatchObserver(
+		func(_ context.Context, result metric.BatchObserverResult) {
+			calls++
+			result.Observe(nil,
+				intObs.Observation(1),
+				floatObs.Observation(2),
+			)
+		})
+	intObs = batch.NewInt64ValueObserver("int.valueobserver.lastvalue")
+	floatObs = batch.NewFloat64SumObserver("float.sumobserver.sum")
+
+	// The callback keeps observing the removed instrument, which is
+	// ignored.
+	require.NoError(t, intObs.Unregister())
+
+	require.Equal(t, 1, sdk.Collect(ctx))
+	require.Equal(t, 1, calls)
+
+	out := batchTest.NewOutput(label.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"float.sumobserver.sum//R=V": 2,
+	}, out.Map)
+
+	require.NoError(t, batch.Unregister())
+
+	processor.accumulations = nil
+	require.Equal(t, 0, sdk.Collect(ctx))
+	require.Equal(t, 1, calls)
+	require.NoError(t, testHandler.Flush())
+}
+
+func TestUnregisterInCallback(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	calls := 0
+	var obs metric.Int64ValueObserver
+	obs = Must(meter).NewInt64ValueObserver("observer.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			calls++
+			result.Observe(10)
+			require.NoError(t, obs.Unregister())
+		},
+	)
+
+	var batchObs metric.Int64SumObserver
+	var batch metric.BatchObserverMust
+	batch = Must(meter).NewBatchObserver(
+		func(_ context.Context, result metric.BatchObserverResult) {
+			calls++
+			result.Observe(nil, batchObs.Observation(1))
+			require.NoError(t, batch.Unregister())
+		})
+	batchObs = batch.NewInt64SumObserver("batch.sum")
+
+	done := make(chan int)
+	go func() {
+		done <- sdk.Collect(ctx)
+	}()
+	select {
+	case checkpointed := <-done:
+		// Observations made before unregistering are dropped.
+		require.Equal(t, 0, checkpointed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Collect deadlocked unregistering from a callback")
+	}
+	require.Equal(t, 2, calls)
+	require.Empty(t, processor.accumulations)
+
+	require.Equal(t, 0, sdk.Collect(ctx))
+	require.Equal(t, 2, calls)
+	require.NoError(t, testHandler.Flush())
+}
@@ -31,7 +31,9 @@ items from the sync.Map.
 
 Asynchronous instruments are managed by an internal
 AsyncInstrumentState, which coordinates calling batch and single
-instrument callbacks.
+instrument callbacks.  Unregistering an asynchronous instrument removes
+it from the AsyncInstrumentState and releases its recorders; a batch
+callback stops running once all of its instruments are unregistered.
 
 Internal Structure
 
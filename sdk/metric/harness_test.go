@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch1f/otel/api/testharness"
+	export "github.com/Ch1f/otel/sdk/export/metric"
+	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+	metricsdk "github.com/Ch1f/otel/sdk/metric"
+	"github.com/Ch1f/otel/sdk/metric/processor/basic"
+	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+)
+
+func TestMeterImplFollowsExpectedAPIBehaviour(t *testing.T) {
+	harness := testharness.NewHarness(t)
+	subjectFactory := func() testharness.MeterImplSubject {
+		processor := basic.New(simple.NewWithInexpensiveDistribution(), export.PassThroughExporter)
+		accum := metricsdk.NewAccumulator(processor)
+		return testharness.MeterImplSubject{
+			Impl: accum,
+			Collect: func(ctx context.Context) []testharness.MeterImplResult {
+				processor.Lock()
+				defer processor.Unlock()
+
+				processor.StartCollection()
+				accum.Collect(ctx)
+				if err := processor.FinishCollection(); err != nil {
+					t.Fatal(err)
+				}
+
+				var results []testharness.MeterImplResult
+				err := processor.ForEach(export.PassThroughExporter, func(rec export.Record) error {
+					sum, err := rec.Aggregation().(aggregation.Sum).Sum()
+					if err != nil {
+						return err
+					}
+					results = append(results, testharness.MeterImplResult{
+						Descriptor: *rec.Descriptor(),
+						Labels:     rec.Labels(),
+						Sum:        sum,
+					})
+					return nil
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return results
+			},
+		}
+	}
+
+	harness.TestMeterImpl(subjectFactory)
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertRecords asserts that the records are described by expected,
+// the String of each record in order.  On failure, the difference
+// between the expected and actual lines is reported.
+func AssertRecords(t testing.TB, expected []string, actual Records, msgAndArgs ...interface{}) bool {
+	return assert.Equal(t, expected, actual.Strings(), msgAndArgs...)
+}
+
+// AssertRecord asserts that the record is described by expected, the
+// String of the record.
+func AssertRecord(t testing.TB, expected string, actual Record, msgAndArgs ...interface{}) bool {
+	return assert.Equal(t, expected, actual.String(), msgAndArgs...)
+}
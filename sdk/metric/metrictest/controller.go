@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch1f/otel/api/metric"
+	"github.com/Ch1f/otel/api/metric/registry"
+	export "github.com/Ch1f/otel/sdk/export/metric"
+	sdk "github.com/Ch1f/otel/sdk/metric"
+	controllerTest "github.com/Ch1f/otel/sdk/metric/controller/test"
+	"github.com/Ch1f/otel/sdk/metric/processor/basic"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+// DefaultStartTime is the initial time of the clock of a Controller.
+var DefaultStartTime = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Config contains configuration for a Controller.
+type Config struct {
+	// Resource is the OpenTelemetry resource associated with all
+	// Meters created by the Controller.
+	Resource *resource.Resource
+
+	// StartTime is the initial time of the clock of the
+	// Controller.  Defaults to DefaultStartTime.
+	StartTime time.Time
+
+	// Memory controls whether the processor of the Controller
+	// remembers the records of previous collections.  See
+	// basic.WithMemory.
+	Memory bool
+}
+
+// Option is the interface that applies the value to a configuration
+// option.
+type Option interface {
+	// Apply sets the Option value of a Config.
+	Apply(*Config)
+}
+
+// WithResource sets the Resource configuration option of a Config.
+func WithResource(r *resource.Resource) Option {
+	return resourceOption{r}
+}
+
+type resourceOption struct{ *resource.Resource }
+
+func (o resourceOption) Apply(config *Config) {
+	config.Resource = o.Resource
+}
+
+// WithStartTime sets the StartTime configuration option of a Config.
+func WithStartTime(t time.Time) Option {
+	return startTimeOption(t)
+}
+
+type startTimeOption time.Time
+
+func (o startTimeOption) Apply(config *Config) {
+	config.StartTime = time.Time(o)
+}
+
+// WithMemory sets the Memory configuration option of a Config.
+func WithMemory(memory bool) Option {
+	return memoryOption(memory)
+}
+
+type memoryOption bool
+
+func (o memoryOption) Apply(config *Config) {
+	config.Memory = bool(o)
+}
+
+// Controller is a metric controller that only collects when Collect
+// is called, exporting synchronously to an Exporter.  Its processor
+// reads time from a controllerTest.MockClock.
+type Controller struct {
+	accumulator *sdk.Accumulator
+	processor   *basic.Processor
+	provider    *registry.Provider
+	exporter    export.Exporter
+	clock       controllerTest.MockClock
+}
+
+// NewController returns a Controller exporting the aggregations
+// selected by aselector to exporter.
+func NewController(aselector export.AggregatorSelector, exporter export.Exporter, opts ...Option) *Controller {
+	config := &Config{
+		StartTime: DefaultStartTime,
+	}
+	for _, opt := range opts {
+		opt.Apply(config)
+	}
+	clock := controllerTest.NewMockClock()
+	clock.Set(config.StartTime)
+	processor := basic.New(aselector, exporter, basic.WithClock(clock), basic.WithMemory(config.Memory))
+	accum := sdk.NewAccumulator(
+		processor,
+		sdk.WithResource(config.Resource),
+	)
+	return &Controller{
+		accumulator: accum,
+		processor:   processor,
+		provider:    registry.NewProvider(accum),
+		exporter:    exporter,
+		clock:       clock,
+	}
+}
+
+// Provider returns a metric.Provider for the implementation managed
+// by this controller.
+func (c *Controller) Provider() metric.Provider {
+	return c.provider
+}
+
+// Clock returns the clock of the controller.
+func (c *Controller) Clock() controllerTest.MockClock {
+	return c.clock
+}
+
+// Collect collects the current metrics, including the observations
+// of asynchronous instruments, and exports them.
+func (c *Controller) Collect(ctx context.Context) error {
+	c.processor.Lock()
+	defer c.processor.Unlock()
+
+	c.processor.StartCollection()
+	c.accumulator.Collect(ctx)
+	if err := c.processor.FinishCollection(); err != nil {
+		return err
+	}
+	return c.exporter.Export(ctx, c.processor.CheckpointSet())
+}
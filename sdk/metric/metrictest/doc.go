@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrictest provides an in-memory exporter and a manually
+// collected controller for testing code instrumented with the metric
+// API against the SDK.
+//
+// The Controller uses a mock clock, so the start and end times of
+// the exported records only change when the test advances the clock:
+//
+//	exp := metrictest.NewExporter(export.CumulativeExporter)
+//	cont := metrictest.NewController(simple.NewWithInexpensiveDistribution(), exp)
+//	meter := cont.Provider().Meter("test")
+//	// ... record measurements
+//	cont.Clock().Add(time.Second)
+//	if err := cont.Collect(ctx); err != nil {
+//		// ...
+//	}
+//	metrictest.AssertRecords(t, []string{
+//		"requests{method=GET}: sum=2",
+//	}, exp.Records())
+package metrictest // import "github.com/Ch1f/otel/sdk/metric/metrictest"
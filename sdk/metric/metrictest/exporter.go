@@ -0,0 +1,339 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/label"
+	"github.com/Ch1f/otel/api/metric"
+	export "github.com/Ch1f/otel/sdk/export/metric"
+	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+)
+
+// Record is a snapshot of an exported record.  Only the fields
+// supported by the Aggregation are set.
+type Record struct {
+	// Name is the name of the instrument.
+	Name string
+	// InstrumentationName is the name of the Meter of the instrument.
+	InstrumentationName string
+	// NumberKind is the number kind of the instrument.
+	NumberKind metric.NumberKind
+	// Labels are the labels of the record, encoded with the default
+	// label encoder.
+	Labels string
+	// Resource is the resource of the record, encoded with the
+	// default label encoder.
+	Resource string
+	// Aggregation is the kind of aggregation of the record.
+	Aggregation aggregation.Kind
+
+	Sum       float64
+	Count     int64
+	Min       float64
+	Max       float64
+	LastValue float64
+	// Buckets are set for histogram aggregations.
+	Buckets *aggregation.Buckets
+	// Quantiles are set for distribution aggregations, for each of
+	// the quantiles the Exporter is configured with.
+	Quantiles map[float64]float64
+
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Records is a list of exported records, sorted by key.
+type Records []Record
+
+// ExporterConfig contains configuration for an Exporter.
+type ExporterConfig struct {
+	// Quantiles are the quantiles recorded for distribution
+	// aggregations.
+	Quantiles []float64
+}
+
+// ExporterOption is the interface that applies the value to an
+// Exporter configuration option.
+type ExporterOption interface {
+	// ApplyExporter sets the ExporterOption value of an
+	// ExporterConfig.
+	ApplyExporter(*ExporterConfig)
+}
+
+// WithQuantiles sets the quantiles recorded for distribution
+// aggregations.
+func WithQuantiles(quantiles ...float64) ExporterOption {
+	return quantilesOption(quantiles)
+}
+
+type quantilesOption []float64
+
+func (o quantilesOption) ApplyExporter(config *ExporterConfig) {
+	config.Quantiles = append([]float64(nil), o...)
+}
+
+// Exporter is a synchronous export.Exporter keeping a snapshot of
+// each export in memory.
+type Exporter struct {
+	export.ExportKindSelector
+	config ExporterConfig
+
+	lock    sync.Mutex
+	exports []Records
+}
+
+var _ export.Exporter = &Exporter{}
+
+// NewExporter returns an Exporter requesting the aggregations
+// selected by eselector, e.g. export.CumulativeExporter.
+func NewExporter(eselector export.ExportKindSelector, opts ...ExporterOption) *Exporter {
+	e := &Exporter{
+		ExportKindSelector: eselector,
+	}
+	for _, opt := range opts {
+		opt.ApplyExporter(&e.config)
+	}
+	return e
+}
+
+// Export implements export.Exporter.
+func (e *Exporter) Export(_ context.Context, checkpointSet export.CheckpointSet) error {
+	var records Records
+	err := checkpointSet.ForEach(e, func(record export.Record) error {
+		r, err := e.snapshot(record)
+		if err != nil {
+			return err
+		}
+		records = append(records, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key() < records[j].Key()
+	})
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.exports = append(e.exports, records)
+	return nil
+}
+
+func (e *Exporter) snapshot(record export.Record) (Record, error) {
+	desc := record.Descriptor()
+	kind := desc.NumberKind()
+	agg := record.Aggregation()
+	r := Record{
+		Name:                desc.Name(),
+		InstrumentationName: desc.InstrumentationName(),
+		NumberKind:          kind,
+		Labels:              record.Labels().Encoded(label.DefaultEncoder()),
+		Resource:            record.Resource().Encoded(label.DefaultEncoder()),
+		Aggregation:         agg.Kind(),
+		StartTime:           record.StartTime(),
+		EndTime:             record.EndTime(),
+	}
+
+	if sum, ok := agg.(aggregation.Sum); ok {
+		value, err := sum.Sum()
+		if err != nil {
+			return r, err
+		}
+		r.Sum = value.CoerceToFloat64(kind)
+	}
+	if count, ok := agg.(aggregation.Count); ok {
+		value, err := count.Count()
+		if err != nil {
+			return r, err
+		}
+		r.Count = value
+	}
+	if min, ok := agg.(aggregation.Min); ok {
+		value, err := min.Min()
+		if err != nil {
+			return r, err
+		}
+		r.Min = value.CoerceToFloat64(kind)
+	}
+	if max, ok := agg.(aggregation.Max); ok {
+		value, err := max.Max()
+		if err != nil {
+			return r, err
+		}
+		r.Max = value.CoerceToFloat64(kind)
+	}
+	if lv, ok := agg.(aggregation.LastValue); ok {
+		value, _, err := lv.LastValue()
+		if err != nil {
+			return r, err
+		}
+		r.LastValue = value.CoerceToFloat64(kind)
+	}
+	if hist, ok := agg.(aggregation.Histogram); ok {
+		buckets, err := hist.Histogram()
+		if err != nil {
+			return r, err
+		}
+		r.Buckets = &aggregation.Buckets{
+			Boundaries: append([]float64(nil), buckets.Boundaries...),
+			Counts:     append([]float64(nil), buckets.Counts...),
+		}
+	}
+	if dist, ok := agg.(aggregation.Distribution); ok && len(e.config.Quantiles) != 0 {
+		r.Quantiles = make(map[float64]float64, len(e.config.Quantiles))
+		for _, q := range e.config.Quantiles {
+			value, err := dist.Quantile(q)
+			if err != nil {
+				return r, err
+			}
+			r.Quantiles[q] = value.CoerceToFloat64(kind)
+		}
+	}
+	return r, nil
+}
+
+// Exports returns the records of every export, oldest first.
+func (e *Exporter) Exports() []Records {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]Records(nil), e.exports...)
+}
+
+// Records returns the records of the latest export.
+func (e *Exporter) Records() Records {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if len(e.exports) == 0 {
+		return nil
+	}
+	return e.exports[len(e.exports)-1]
+}
+
+// Get returns the record of the latest export for the instrument name
+// and labels.
+func (e *Exporter) Get(name string, labels ...kv.KeyValue) (Record, bool) {
+	return e.Records().Get(name, labels...)
+}
+
+// Reset forgets all exports.
+func (e *Exporter) Reset() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.exports = nil
+}
+
+// Key returns the key identifying the record in its export, the
+// instrument name followed by the encoded labels in braces.
+func (r Record) Key() string {
+	return Key(r.Name, r.Labels)
+}
+
+// Key returns the key of the record for the instrument name and
+// encoded labels.
+func Key(name, encodedLabels string) string {
+	return name + "{" + encodedLabels + "}"
+}
+
+// String returns the key of the record followed by its values, e.g.
+// "requests{method=GET}: sum=2".  Times are not included, so that the
+// result can be compared across runs.
+func (r Record) String() string {
+	var values []string
+	add := func(name string, value float64) {
+		values = append(values, name+"="+formatFloat(value))
+	}
+	switch r.Aggregation {
+	case aggregation.SumKind:
+		add("sum", r.Sum)
+	case aggregation.LastValueKind:
+		add("value", r.LastValue)
+	case aggregation.HistogramKind:
+		values = append(values, "count="+strconv.FormatInt(r.Count, 10))
+		add("sum", r.Sum)
+	default:
+		values = append(values, "count="+strconv.FormatInt(r.Count, 10))
+		add("sum", r.Sum)
+		add("min", r.Min)
+		add("max", r.Max)
+	}
+	if r.Buckets != nil {
+		values = append(values, fmt.Sprintf("buckets=%s|%s", formatFloats(r.Buckets.Boundaries), formatFloats(r.Buckets.Counts)))
+	}
+	if len(r.Quantiles) != 0 {
+		qs := make([]float64, 0, len(r.Quantiles))
+		for q := range r.Quantiles {
+			qs = append(qs, q)
+		}
+		sort.Float64s(qs)
+		for _, q := range qs {
+			add("q"+formatFloat(q), r.Quantiles[q])
+		}
+	}
+	return r.Key() + ": " + strings.Join(values, " ")
+}
+
+// Get returns the record for the instrument name and labels.
+func (rs Records) Get(name string, labels ...kv.KeyValue) (Record, bool) {
+	set := label.NewSet(labels...)
+	key := Key(name, set.Encoded(label.DefaultEncoder()))
+	for _, r := range rs {
+		if r.Key() == key {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
+
+// ByName returns the records of the instrument name.
+func (rs Records) ByName(name string) Records {
+	var out Records
+	for _, r := range rs {
+		if r.Name == name {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Strings returns the String of each record.
+func (rs Records) Strings() []string {
+	out := make([]string, len(rs))
+	for i, r := range rs {
+		out[i] = r.String()
+	}
+	return out
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func formatFloats(fs []float64) string {
+	s := make([]string, len(fs))
+	for i, f := range fs {
+		s[i] = formatFloat(f)
+	}
+	return "[" + strings.Join(s, " ") + "]"
+}
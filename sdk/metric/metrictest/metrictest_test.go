@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrictest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	export "github.com/Ch1f/otel/sdk/export/metric"
+	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+	"github.com/Ch1f/otel/sdk/metric/metrictest"
+	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+func TestControllerCollect(t *testing.T) {
+	ctx := context.Background()
+	exp := metrictest.NewExporter(export.CumulativeExporter)
+	cont := metrictest.NewController(
+		simple.NewWithInexpensiveDistribution(),
+		exp,
+		metrictest.WithResource(resource.New(kv.String("R", "V"))),
+		metrictest.WithMemory(true),
+	)
+	meter := metric.Must(cont.Provider().Meter("test"))
+
+	counter := meter.NewInt64Counter("counter")
+	recorder := meter.NewFloat64ValueRecorder("recorder")
+	observed := int64(7)
+	meter.NewInt64SumObserver("observer", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(observed, kv.String("A", "B"))
+	})
+
+	counter.Add(ctx, 1, kv.String("A", "B"))
+	counter.Add(ctx, 2, kv.String("A", "B"))
+	counter.Add(ctx, 5)
+	recorder.Record(ctx, 1.5)
+	recorder.Record(ctx, 2.5)
+
+	cont.Clock().Add(time.Second)
+	require.NoError(t, cont.Collect(ctx))
+
+	metrictest.AssertRecords(t, []string{
+		"counter{A=B}: sum=3",
+		"counter{}: sum=5",
+		"observer{A=B}: sum=7",
+		"recorder{}: count=2 sum=4 min=1.5 max=2.5",
+	}, exp.Records())
+
+	r, ok := exp.Get("counter", kv.String("A", "B"))
+	require.True(t, ok)
+	assert.Equal(t, float64(3), r.Sum)
+	assert.Equal(t, "test", r.InstrumentationName)
+	assert.Equal(t, metric.Int64NumberKind, r.NumberKind)
+	assert.Equal(t, aggregation.SumKind, r.Aggregation)
+	assert.Equal(t, "R=V", r.Resource)
+	assert.Equal(t, metrictest.DefaultStartTime, r.StartTime)
+	assert.Equal(t, metrictest.DefaultStartTime.Add(time.Second), r.EndTime)
+
+	_, ok = exp.Get("counter", kv.String("A", "C"))
+	assert.False(t, ok)
+
+	// Cumulative sums keep growing across collections.
+	counter.Add(ctx, 1, kv.String("A", "B"))
+	observed = 8
+	cont.Clock().Add(time.Second)
+	require.NoError(t, cont.Collect(ctx))
+
+	metrictest.AssertRecords(t, []string{
+		"counter{A=B}: sum=4",
+		"counter{}: sum=5",
+		"observer{A=B}: sum=8",
+		"recorder{}: count=2 sum=4 min=1.5 max=2.5",
+	}, exp.Records())
+	r, _ = exp.Get("counter", kv.String("A", "B"))
+	assert.Equal(t, metrictest.DefaultStartTime.Add(2*time.Second), r.EndTime)
+
+	assert.Len(t, exp.Exports(), 2)
+	exp.Reset()
+	assert.Empty(t, exp.Exports())
+	assert.Empty(t, exp.Records())
+}
+
+func TestExporterHistogram(t *testing.T) {
+	ctx := context.Background()
+	exp := metrictest.NewExporter(export.DeltaExporter)
+	cont := metrictest.NewController(
+		simple.NewWithHistogramDistribution([]float64{1, 10}),
+		exp,
+	)
+	meter := metric.Must(cont.Provider().Meter("test"))
+
+	recorder := meter.NewInt64ValueRecorder("latency")
+	for _, v := range []int64{0, 5, 6, 20} {
+		recorder.Record(ctx, v)
+	}
+	require.NoError(t, cont.Collect(ctx))
+
+	r, ok := exp.Get("latency")
+	require.True(t, ok)
+	require.NotNil(t, r.Buckets)
+	assert.Equal(t, []float64{1, 10}, r.Buckets.Boundaries)
+	assert.Equal(t, []float64{1, 2, 1}, r.Buckets.Counts)
+	metrictest.AssertRecord(t, "latency{}: count=4 sum=31 buckets=[1 10]|[1 2 1]", r)
+}
+
+func TestExporterQuantiles(t *testing.T) {
+	ctx := context.Background()
+	exp := metrictest.NewExporter(export.DeltaExporter, metrictest.WithQuantiles(0.5, 1))
+	cont := metrictest.NewController(simple.NewWithExactDistribution(), exp)
+	meter := metric.Must(cont.Provider().Meter("test"))
+
+	recorder := meter.NewFloat64ValueRecorder("size")
+	for _, v := range []float64{1, 2, 3} {
+		recorder.Record(ctx, v)
+	}
+	require.NoError(t, cont.Collect(ctx))
+
+	metrictest.AssertRecords(t, []string{
+		"size{}: count=3 sum=6 min=1 max=3 q0.5=2 q1=3",
+	}, exp.Records())
+	assert.Len(t, exp.Records().ByName("size"), 1)
+}
+
+func TestAssertRecordsReportsDifference(t *testing.T) {
+	mockT := &testing.T{}
+	records := metrictest.Records{{
+		Name:        "counter",
+		Labels:      "A=B",
+		Aggregation: aggregation.SumKind,
+		Sum:         2,
+	}}
+	assert.True(t, metrictest.AssertRecords(mockT, []string{"counter{A=B}: sum=2"}, records))
+	assert.False(t, metrictest.AssertRecords(mockT, []string{"counter{A=B}: sum=3"}, records))
+}
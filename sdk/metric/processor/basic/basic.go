@@ -24,6 +24,7 @@ import (
 	"github.com/Ch1f/otel/api/metric"
 	export "github.com/Ch1f/otel/sdk/export/metric"
 	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
+	controllerTime "github.com/Ch1f/otel/sdk/metric/controller/time"
 	"github.com/Ch1f/otel/sdk/resource"
 )
 
@@ -126,20 +127,23 @@ var ErrInvalidExporterKind = fmt.Errorf("invalid exporter kind")
 // data, so that this Processor can prepare to compute Delta or
 // Cumulative Aggregations as needed.
 func New(aselector export.AggregatorSelector, eselector export.ExportKindSelector, opts ...Option) *Processor {
-	now := time.Now()
-	p := &Processor{
+	config := Config{
+		Clock: controllerTime.RealClock{},
+	}
+	for _, opt := range opts {
+		opt.ApplyProcessor(&config)
+	}
+	now := config.Clock.Now()
+	return &Processor{
 		AggregatorSelector: aselector,
 		ExportKindSelector: eselector,
 		state: state{
+			config:        config,
 			values:        map[stateKey]*stateValue{},
 			processStart:  now,
 			intervalStart: now,
 		},
 	}
-	for _, opt := range opts {
-		opt.ApplyProcessor(&p.config)
-	}
-	return p
 }
 
 // Process implements export.Processor.
@@ -300,7 +304,7 @@ func (b *Processor) StartCollection() {
 // collection has finished and that ForEach will be called to access
 // the CheckpointSet.
 func (b *Processor) FinishCollection() error {
-	b.intervalEnd = time.Now()
+	b.intervalEnd = b.config.Clock.Now()
 	if b.startedCollection != b.finishedCollection+1 {
 		return ErrInconsistentState
 	}
@@ -35,6 +35,7 @@ import (
 	"github.com/Ch1f/otel/sdk/metric/aggregator/lastvalue"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/minmaxsumcount"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/sum"
+	controllerTest "github.com/Ch1f/otel/sdk/metric/controller/test"
 	"github.com/Ch1f/otel/sdk/metric/processor/basic"
 	"github.com/Ch1f/otel/sdk/metric/processor/test"
 	"github.com/Ch1f/otel/sdk/resource"
@@ -379,6 +380,29 @@ func TestBasicTimestamps(t *testing.T) {
 	}
 }
 
+func TestBasicClock(t *testing.T) {
+	mock := controllerTest.NewMockClock()
+	start := mock.Now()
+	b := basic.New(test.AggregatorSelector(), export.PassThroughExporter, basic.WithClock(mock))
+
+	desc := metric.NewDescriptor("inst", metric.CounterKind, metric.Int64NumberKind)
+	accum := export.NewAccumulation(&desc, label.EmptySet(), resource.Empty(), exportTest.NoopAggregator{})
+
+	for i := 1; i <= 2; i++ {
+		mock.Add(time.Second)
+
+		b.StartCollection()
+		require.NoError(t, b.Process(accum))
+		require.NoError(t, b.FinishCollection())
+
+		require.NoError(t, b.ForEach(export.PassThroughExporter, func(rec export.Record) error {
+			require.Equal(t, start.Add(time.Duration(i-1)*time.Second), rec.StartTime())
+			require.Equal(t, start.Add(time.Duration(i)*time.Second), rec.EndTime())
+			return nil
+		}))
+	}
+}
+
 func TestStatefulNoMemoryCumulative(t *testing.T) {
 	res := resource.New(kv.String("R", "V"))
 	ekind := export.CumulativeExporter
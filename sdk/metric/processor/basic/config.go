@@ -14,6 +14,10 @@
 
 package basic // import "github.com/Ch1f/otel/sdk/metric/processor/basic"
 
+import (
+	controllerTime "github.com/Ch1f/otel/sdk/metric/controller/time"
+)
+
 // Config contains the options for configuring a basic metric processor.
 type Config struct {
 	// Memory controls whether the processor remembers metric
@@ -21,6 +25,10 @@ type Config struct {
 	// When Memory is true, CheckpointSet.ForEach() will visit
 	// metrics that were not updated in the most recent interval.
 	Memory bool
+
+	// Clock is used to timestamp the start and end of each
+	// collection interval.  The default is the real clock.
+	Clock controllerTime.Clock
 }
 
 type Option interface {
@@ -40,3 +48,15 @@ type memoryOption bool
 func (m memoryOption) ApplyProcessor(config *Config) {
 	config.Memory = bool(m)
 }
+
+// WithClock sets the clock used by a Processor to timestamp
+// collection intervals.  This is intended for testing.
+func WithClock(clock controllerTime.Clock) Option {
+	return clockOption{clock}
+}
+
+type clockOption struct{ controllerTime.Clock }
+
+func (c clockOption) ApplyProcessor(config *Config) {
+	config.Clock = c.Clock
+}
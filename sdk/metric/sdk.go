@@ -51,6 +51,15 @@ type (
 		asyncLock        sync.Mutex
 		asyncInstruments *internal.AsyncInstrumentState
 
+		// unregisterLock protects observing and
+		// pendingUnregister.  While observer callbacks run,
+		// asyncLock is held, so instruments unregistered
+		// meanwhile (e.g., from their own callback) are queued
+		// and removed once the callbacks return.
+		unregisterLock    sync.Mutex
+		observing         bool
+		pendingUnregister []*asyncInstrument
+
 		// currentEpoch is the current epoch number. It is
 		// incremented in `Collect()`.
 		currentEpoch int64
@@ -133,6 +142,11 @@ type (
 		// recorders maps ordered labels to the pair of
 		// labelset and recorder
 		recorders map[label.Distinct]*labeledRecorder
+
+		// unregistered is set by UnregisterAsyncInstrument,
+		// after which observations are ignored.  It is
+		// protected by the Accumulator's asyncLock.
+		unregistered bool
 	}
 
 	labeledRecorder struct {
@@ -143,10 +157,11 @@ type (
 )
 
 var (
-	_ api.MeterImpl     = &Accumulator{}
-	_ api.AsyncImpl     = &asyncInstrument{}
-	_ api.SyncImpl      = &syncInstrument{}
-	_ api.BoundSyncImpl = &record{}
+	_ api.MeterImpl         = &Accumulator{}
+	_ api.AsyncUnregisterer = &Accumulator{}
+	_ api.AsyncImpl         = &asyncInstrument{}
+	_ api.SyncImpl          = &syncInstrument{}
+	_ api.BoundSyncImpl     = &record{}
 
 	ErrUninitializedInstrument = fmt.Errorf("use of an uninitialized instrument")
 )
@@ -164,6 +179,11 @@ func (s *syncInstrument) Implementation() interface{} {
 }
 
 func (a *asyncInstrument) observe(number api.Number, labels *label.Set) {
+	if a.unregistered {
+		// A batch callback may still observe instruments
+		// that were removed from it.
+		return
+	}
 	if err := aggregator.RangeTest(number, &a.descriptor); err != nil {
 		global.Handle(err)
 		return
@@ -183,13 +203,12 @@ func (a *asyncInstrument) observe(number api.Number, labels *label.Set) {
 func (a *asyncInstrument) getRecorder(labels *label.Set) export.Aggregator {
 	lrec, ok := a.recorders[labels.Equivalent()]
 	if ok {
-		if lrec.observedEpoch == a.meter.currentEpoch {
-			// last value wins for Observers, so if we see the same labels
-			// in the current epoch, we replace the old recorder
-			a.meter.processor.AggregatorFor(&a.descriptor, &lrec.observed)
-		} else {
-			lrec.observedEpoch = a.meter.currentEpoch
-		}
+		// Last value wins for Observers, so if we see the same labels
+		// in the current epoch, we replace the old recorder.  The
+		// recorder is also replaced in a new epoch, so that values
+		// observed in the previous epoch are not carried over.
+		a.meter.processor.AggregatorFor(&a.descriptor, &lrec.observed)
+		lrec.observedEpoch = a.meter.currentEpoch
 		a.recorders[labels.Equivalent()] = lrec
 		return lrec.observed
 	}
@@ -343,6 +362,59 @@ func (m *Accumulator) NewAsyncInstrument(descriptor api.Descriptor, runner metri
 	return a, nil
 }
 
+// UnregisterAsyncInstrument implements api.AsyncUnregisterer.  The
+// instrument's callback stops running, unless it is a batch callback
+// shared with other registered instruments, and its recorders are
+// released.  When called while observer callbacks are running, the
+// instrument is removed after the callbacks return, before the
+// collection is checkpointed.
+func (m *Accumulator) UnregisterAsyncInstrument(inst api.AsyncImpl) error {
+	if inst == nil {
+		return ErrUninitializedInstrument
+	}
+	a, ok := inst.Implementation().(*asyncInstrument)
+	if !ok || a.meter != m {
+		return ErrUninitializedInstrument
+	}
+
+	m.unregisterLock.Lock()
+	if m.observing {
+		m.pendingUnregister = append(m.pendingUnregister, a)
+		m.unregisterLock.Unlock()
+		return nil
+	}
+	m.unregisterLock.Unlock()
+
+	m.asyncLock.Lock()
+	defer m.asyncLock.Unlock()
+
+	m.unregisterAsync(a)
+	return nil
+}
+
+// unregisterAsync removes an asynchronous instrument.  asyncLock must
+// be held.
+func (m *Accumulator) unregisterAsync(a *asyncInstrument) {
+	m.asyncInstruments.Unregister(a)
+	a.unregistered = true
+	a.recorders = nil
+}
+
+// setObserving marks whether observer callbacks are running.  When
+// they stop, the instruments unregistered meanwhile are returned.
+func (m *Accumulator) setObserving(observing bool) []*asyncInstrument {
+	m.unregisterLock.Lock()
+	defer m.unregisterLock.Unlock()
+
+	m.observing = observing
+	if observing {
+		return nil
+	}
+	pending := m.pendingUnregister
+	m.pendingUnregister = nil
+	return pending
+}
+
 // Collect traverses the list of active records and observers and
 // exports data for each active instrument.  Collect() may not be
 // called concurrently.
@@ -422,8 +494,12 @@ func (m *Accumulator) observeAsyncInstruments(ctx context.Context) int {
 
 	asyncCollected := 0
 
+	m.setObserving(true)
 	// TODO: change this to `ctx` (in a separate PR, with tests)
 	m.asyncInstruments.Run(context.Background(), m)
+	for _, a := range m.setObserving(false) {
+		m.unregisterAsync(a)
+	}
 
 	for _, inst := range m.asyncInstruments.Instruments() {
 		if a := m.fromAsync(inst); a != nil {
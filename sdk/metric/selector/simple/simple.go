@@ -20,6 +20,7 @@ import (
 	"github.com/Ch1f/otel/sdk/metric/aggregator/array"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/ddsketch"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/histogram"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/lastvalue"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/minmaxsumcount"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/sum"
 )
@@ -32,7 +33,12 @@ type (
 	}
 	selectorHistogram struct {
 		boundaries []float64
+		exemplars  int
 	}
+
+	// Option configures the selector returned by
+	// NewWithHistogramDistribution.
+	Option func(*selectorHistogram)
 )
 
 var (
@@ -75,12 +81,32 @@ func NewWithExactDistribution() export.AggregatorSelector {
 // histogram, and histogram aggregators for the three kinds of metric. This
 // selector uses more memory than the NewWithInexpensiveDistribution because it
 // uses a counter per bucket.
-func NewWithHistogramDistribution(boundaries []float64) export.AggregatorSelector {
-	return selectorHistogram{boundaries: boundaries}
+func NewWithHistogramDistribution(boundaries []float64, opts ...Option) export.AggregatorSelector {
+	s := selectorHistogram{boundaries: boundaries}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// WithExemplars enables sampling up to size exemplars per sum and per
+// histogram bucket.
+func WithExemplars(size int) Option {
+	return func(s *selectorHistogram) {
+		s.exemplars = size
+	}
 }
 
-func sumAggs(aggPtrs []*export.Aggregator) {
-	aggs := sum.New(len(aggPtrs))
+func sumAggs(aggPtrs []*export.Aggregator, opts ...sum.Option) {
+	aggs := sum.New(len(aggPtrs), opts...)
+	for i := range aggPtrs {
+		*aggPtrs[i] = &aggs[i]
+	}
+}
+
+// lastValueAggs is used by every selector for Gauge instruments.
+func lastValueAggs(aggPtrs []*export.Aggregator) {
+	aggs := lastvalue.New(len(aggPtrs))
 	for i := range aggPtrs {
 		*aggPtrs[i] = &aggs[i]
 	}
@@ -93,6 +119,8 @@ func (selectorInexpensive) AggregatorFor(descriptor *metric.Descriptor, aggPtrs
 		for i := range aggPtrs {
 			*aggPtrs[i] = &aggs[i]
 		}
+	case metric.GaugeKind:
+		lastValueAggs(aggPtrs)
 	default:
 		sumAggs(aggPtrs)
 	}
@@ -105,6 +133,8 @@ func (s selectorSketch) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...
 		for i := range aggPtrs {
 			*aggPtrs[i] = &aggs[i]
 		}
+	case metric.GaugeKind:
+		lastValueAggs(aggPtrs)
 	default:
 		sumAggs(aggPtrs)
 	}
@@ -117,6 +147,8 @@ func (selectorExact) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...*ex
 		for i := range aggPtrs {
 			*aggPtrs[i] = &aggs[i]
 		}
+	case metric.GaugeKind:
+		lastValueAggs(aggPtrs)
 	default:
 		sumAggs(aggPtrs)
 	}
@@ -125,11 +157,13 @@ func (selectorExact) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...*ex
 func (s selectorHistogram) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...*export.Aggregator) {
 	switch descriptor.MetricKind() {
 	case metric.ValueObserverKind, metric.ValueRecorderKind:
-		aggs := histogram.New(len(aggPtrs), descriptor, s.boundaries)
+		aggs := histogram.New(len(aggPtrs), descriptor, s.boundaries, histogram.WithExemplars(s.exemplars))
 		for i := range aggPtrs {
 			*aggPtrs[i] = &aggs[i]
 		}
+	case metric.GaugeKind:
+		lastValueAggs(aggPtrs)
 	default:
-		sumAggs(aggPtrs)
+		sumAggs(aggPtrs, sum.WithExemplars(s.exemplars))
 	}
 }
@@ -15,24 +15,29 @@
 package simple_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/Ch1f/otel/api/metric"
 	export "github.com/Ch1f/otel/sdk/export/metric"
+	"github.com/Ch1f/otel/sdk/export/metric/aggregation"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/array"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/ddsketch"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/histogram"
+	"github.com/Ch1f/otel/sdk/metric/aggregator/lastvalue"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/minmaxsumcount"
 	"github.com/Ch1f/otel/sdk/metric/aggregator/sum"
 	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
 )
 
 var (
 	testCounterDesc       = metric.NewDescriptor("counter", metric.CounterKind, metric.Int64NumberKind)
 	testValueRecorderDesc = metric.NewDescriptor("valuerecorder", metric.ValueRecorderKind, metric.Int64NumberKind)
 	testValueObserverDesc = metric.NewDescriptor("valueobserver", metric.ValueObserverKind, metric.Int64NumberKind)
+	testGaugeDesc         = metric.NewDescriptor("gauge", metric.GaugeKind, metric.Int64NumberKind)
 )
 
 func oneAgg(sel export.AggregatorSelector, desc *metric.Descriptor) export.Aggregator {
@@ -46,6 +51,7 @@ func TestInexpensiveDistribution(t *testing.T) {
 	require.NotPanics(t, func() { _ = oneAgg(inex, &testCounterDesc).(*sum.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(inex, &testValueRecorderDesc).(*minmaxsumcount.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(inex, &testValueObserverDesc).(*minmaxsumcount.Aggregator) })
+	require.NotPanics(t, func() { _ = oneAgg(inex, &testGaugeDesc).(*lastvalue.Aggregator) })
 }
 
 func TestSketchDistribution(t *testing.T) {
@@ -53,6 +59,7 @@ func TestSketchDistribution(t *testing.T) {
 	require.NotPanics(t, func() { _ = oneAgg(sk, &testCounterDesc).(*sum.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(sk, &testValueRecorderDesc).(*ddsketch.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(sk, &testValueObserverDesc).(*ddsketch.Aggregator) })
+	require.NotPanics(t, func() { _ = oneAgg(sk, &testGaugeDesc).(*lastvalue.Aggregator) })
 }
 
 func TestExactDistribution(t *testing.T) {
@@ -60,6 +67,7 @@ func TestExactDistribution(t *testing.T) {
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testCounterDesc).(*sum.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testValueRecorderDesc).(*array.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testValueObserverDesc).(*array.Aggregator) })
+	require.NotPanics(t, func() { _ = oneAgg(ex, &testGaugeDesc).(*lastvalue.Aggregator) })
 }
 
 func TestHistogramDistribution(t *testing.T) {
@@ -67,4 +75,23 @@ func TestHistogramDistribution(t *testing.T) {
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testCounterDesc).(*sum.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testValueRecorderDesc).(*histogram.Aggregator) })
 	require.NotPanics(t, func() { _ = oneAgg(ex, &testValueObserverDesc).(*histogram.Aggregator) })
+	require.NotPanics(t, func() { _ = oneAgg(ex, &testGaugeDesc).(*lastvalue.Aggregator) })
+}
+
+func TestHistogramDistributionWithExemplars(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	ctx, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	ex := simple.NewWithHistogramDistribution(nil, simple.WithExemplars(1))
+	for _, desc := range []*metric.Descriptor{&testCounterDesc, &testValueRecorderDesc} {
+		agg, ckpt := oneAgg(ex, desc), oneAgg(ex, desc)
+		require.NoError(t, agg.Update(ctx, metric.NewInt64Number(1), desc))
+		require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+
+		exemplars, err := ckpt.Aggregation().(aggregation.Exemplars).Exemplars()
+		require.NoError(t, err)
+		require.Len(t, exemplars, 1, desc.Name())
+	}
 }
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+const (
+	// defaultEC2Endpoint is the base URL of the EC2 instance metadata
+	// service.
+	defaultEC2Endpoint = "http://169.254.169.254"
+
+	// ec2TokenTTL is the lifetime in seconds requested for IMDSv2
+	// session tokens.  Tokens are only used for a single detection.
+	ec2TokenTTL = "60"
+
+	// envECSMetadataV4 is the environment variable the ECS agent sets to
+	// the task metadata v4 endpoint of the container.
+	envECSMetadataV4 = "ECS_CONTAINER_METADATA_URI_V4"
+)
+
+// EC2 is a detector that queries the EC2 instance metadata service
+// using the IMDSv2 session token flow.
+type EC2 struct {
+	// Endpoint is the base URL of the instance metadata service.  It
+	// defaults to "http://169.254.169.254".
+	Endpoint string
+	// Timeout bounds each metadata request.  It defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// compile time assertion that EC2 implements Detector interface
+var _ resource.Detector = EC2{}
+
+// ec2IdentityDocument is the subset of the EC2 instance identity
+// document used by the detector.
+type ec2IdentityDocument struct {
+	AccountID        string `json:"accountId"`
+	AvailabilityZone string `json:"availabilityZone"`
+	Region           string `json:"region"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	ImageID          string `json:"imageId"`
+}
+
+// Detect implements resource.Detector.
+func (d EC2) Detect(ctx context.Context) (*resource.Resource, error) {
+	client := newClient(d.Timeout)
+	base := baseURL(d.Endpoint, defaultEC2Endpoint)
+
+	token, err := fetch(ctx, client, http.MethodPut, base+"/latest/api/token", http.Header{
+		"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {ec2TokenTTL},
+	})
+	if notThisCloud(err) {
+		return resource.Empty(), nil
+	}
+	if err != nil {
+		return resource.Empty(), err
+	}
+	header := http.Header{"X-Aws-Ec2-Metadata-Token": {string(token)}}
+
+	body, err := fetch(ctx, client, http.MethodGet, base+"/latest/dynamic/instance-identity/document", header)
+	if err != nil {
+		return resource.Empty(), err
+	}
+	var doc ec2IdentityDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return resource.Empty(), err
+	}
+
+	labels := []kv.KeyValue{
+		standard.CloudProviderAWS,
+		standard.CloudAccountIDKey.String(doc.AccountID),
+		standard.CloudRegionKey.String(doc.Region),
+		standard.CloudZoneKey.String(doc.AvailabilityZone),
+		standard.HostIDKey.String(doc.InstanceID),
+		standard.HostTypeKey.String(doc.InstanceType),
+		standard.HostImageIDKey.String(doc.ImageID),
+	}
+
+	hostname, err := fetch(ctx, client, http.MethodGet, base+"/latest/meta-data/hostname", header)
+	if err != nil {
+		return resource.New(labels...), err
+	}
+	labels = append(labels, standard.HostHostNameKey.String(string(hostname)))
+	return resource.New(labels...), nil
+}
+
+// ECS is a detector that queries the ECS task metadata endpoint
+// version 4.
+type ECS struct {
+	// Endpoint is the task metadata endpoint of the container.  It
+	// defaults to the value of the ECS_CONTAINER_METADATA_URI_V4
+	// environment variable; when both are empty the process is assumed
+	// not to be running on ECS.
+	Endpoint string
+	// Timeout bounds each metadata request.  It defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// compile time assertion that ECS implements Detector interface
+var _ resource.Detector = ECS{}
+
+// ecsContainerMetadata is the subset of the ECS container metadata
+// used by the detector.
+type ecsContainerMetadata struct {
+	DockerID string `json:"DockerId"`
+	Name     string `json:"Name"`
+	Image    string `json:"Image"`
+}
+
+// ecsTaskMetadata is the subset of the ECS task metadata used by the
+// detector.
+type ecsTaskMetadata struct {
+	TaskARN          string `json:"TaskARN"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+}
+
+// Detect implements resource.Detector.
+func (d ECS) Detect(ctx context.Context) (*resource.Resource, error) {
+	endpoint := d.Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv(envECSMetadataV4)
+	}
+	if endpoint == "" {
+		return resource.Empty(), nil
+	}
+	client := newClient(d.Timeout)
+	base := baseURL(endpoint, "")
+
+	body, err := fetch(ctx, client, http.MethodGet, base, nil)
+	if err != nil {
+		return resource.Empty(), err
+	}
+	var container ecsContainerMetadata
+	if err := json.Unmarshal(body, &container); err != nil {
+		return resource.Empty(), err
+	}
+
+	body, err = fetch(ctx, client, http.MethodGet, base+"/task", nil)
+	if err != nil {
+		return resource.Empty(), err
+	}
+	var task ecsTaskMetadata
+	if err := json.Unmarshal(body, &task); err != nil {
+		return resource.Empty(), err
+	}
+
+	labels := []kv.KeyValue{
+		standard.CloudProviderAWS,
+		standard.ContainerIDKey.String(container.DockerID),
+		standard.ContainerNameKey.String(container.Name),
+	}
+	if container.Image != "" {
+		name, tag := splitImage(container.Image)
+		labels = append(labels, standard.ContainerImageNameKey.String(name))
+		if tag != "" {
+			labels = append(labels, standard.ContainerImageTagKey.String(tag))
+		}
+	}
+	if task.AvailabilityZone != "" {
+		labels = append(labels,
+			standard.CloudZoneKey.String(task.AvailabilityZone),
+			standard.CloudRegionKey.String(regionFromZone(task.AvailabilityZone)),
+		)
+	}
+	if account := accountFromARN(task.TaskARN); account != "" {
+		labels = append(labels, standard.CloudAccountIDKey.String(account))
+	}
+	return resource.New(labels...), nil
+}
+
+// splitImage splits a container image reference into its name and tag.
+func splitImage(image string) (name, tag string) {
+	if i := strings.LastIndexByte(image, ':'); i > strings.LastIndexByte(image, '/') {
+		return image[:i], image[i+1:]
+	}
+	return image, ""
+}
+
+// accountFromARN returns the account ID field of an ARN of the form
+// arn:partition:service:region:account-id:resource.
+func accountFromARN(arn string) string {
+	fields := strings.SplitN(arn, ":", 6)
+	if len(fields) < 6 || fields[0] != "arn" {
+		return ""
+	}
+	return fields[4]
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	ottest "github.com/Ch1f/otel/internal/testing"
+)
+
+const testEC2Token = "AQAEAHg2u8n9xbh9"
+
+func newEC2Server(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, ec2TokenTTL, r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+		_, _ = w.Write([]byte(testEC2Token))
+	})
+	requireToken := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-aws-ec2-metadata-token") != testEC2Token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("/latest/dynamic/instance-identity/document", requireToken(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"accountId": "123456789012",
+			"availabilityZone": "us-west-2b",
+			"region": "us-west-2",
+			"instanceId": "i-1234567890abcdef0",
+			"instanceType": "t2.micro",
+			"imageId": "ami-5fb8c835"
+		}`))
+	}))
+	mux.HandleFunc("/latest/meta-data/hostname", requireToken(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ip-10-0-0-1.us-west-2.compute.internal"))
+	}))
+	return httptest.NewServer(mux)
+}
+
+func TestEC2Detect(t *testing.T) {
+	srv := newEC2Server(t)
+	defer srv.Close()
+
+	res, err := EC2{Endpoint: srv.URL}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []kv.KeyValue{
+		standard.CloudProviderAWS,
+		standard.CloudAccountIDKey.String("123456789012"),
+		standard.CloudRegionKey.String("us-west-2"),
+		standard.CloudZoneKey.String("us-west-2b"),
+		standard.HostIDKey.String("i-1234567890abcdef0"),
+		standard.HostTypeKey.String("t2.micro"),
+		standard.HostImageIDKey.String("ami-5fb8c835"),
+		standard.HostHostNameKey.String("ip-10-0-0-1.us-west-2.compute.internal"),
+	}, res.Attributes())
+}
+
+func TestEC2DetectUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	res, err := EC2{Endpoint: url}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestEC2DetectOnAzure(t *testing.T) {
+	srv := newAzureServer(t)
+	defer srv.Close()
+
+	res, err := EC2{Endpoint: srv.URL}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestEC2DetectError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	res, err := EC2{Endpoint: srv.URL}.Detect(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func newECSServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4/abc", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"DockerId": "ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66",
+			"Name": "curl",
+			"Image": "111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest:latest"
+		}`))
+	})
+	mux.HandleFunc("/v4/abc/task", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"Cluster": "default",
+			"TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/158d1c8083dd49d6b527399fd6414f5c",
+			"AvailabilityZone": "us-west-2d"
+		}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestECSDetect(t *testing.T) {
+	srv := newECSServer()
+	defer srv.Close()
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envECSMetadataV4: srv.URL + "/v4/abc",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := ECS{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []kv.KeyValue{
+		standard.CloudProviderAWS,
+		standard.CloudAccountIDKey.String("111122223333"),
+		standard.CloudRegionKey.String("us-west-2"),
+		standard.CloudZoneKey.String("us-west-2d"),
+		standard.ContainerIDKey.String("ea32192c8553fbff06c9340478a2ff089b2bb5646fb718b4ee206641c9086d66"),
+		standard.ContainerNameKey.String("curl"),
+		standard.ContainerImageNameKey.String("111122223333.dkr.ecr.us-west-2.amazonaws.com/curltest"),
+		standard.ContainerImageTagKey.String("latest"),
+	}, res.Attributes())
+}
+
+func TestECSDetectNotOnECS(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envECSMetadataV4: "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := ECS{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestSplitImage(t *testing.T) {
+	for _, c := range []struct {
+		image, name, tag string
+	}{
+		{"nginx", "nginx", ""},
+		{"nginx:1.19", "nginx", "1.19"},
+		{"localhost:5000/app", "localhost:5000/app", ""},
+		{"localhost:5000/app:v2", "localhost:5000/app", "v2"},
+	} {
+		name, tag := splitImage(c.image)
+		assert.Equal(t, c.name, name, c.image)
+		assert.Equal(t, c.tag, tag, c.image)
+	}
+}
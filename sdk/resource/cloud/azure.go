@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+const (
+	// defaultAzureEndpoint is the base URL of the Azure instance
+	// metadata service.
+	defaultAzureEndpoint = "http://169.254.169.254"
+
+	// azureComputePath is the path of the compute metadata of the
+	// Azure instance metadata service.
+	azureComputePath = "/metadata/instance/compute?api-version=2019-08-15&format=json"
+)
+
+// AzureVM is a detector that queries the Azure instance metadata
+// service.
+type AzureVM struct {
+	// Endpoint is the base URL of the instance metadata service.  It
+	// defaults to "http://169.254.169.254".
+	Endpoint string
+	// Timeout bounds each metadata request.  It defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// compile time assertion that AzureVM implements Detector interface
+var _ resource.Detector = AzureVM{}
+
+// azureCompute is the subset of the Azure compute metadata used by the
+// detector.
+type azureCompute struct {
+	Location       string `json:"location"`
+	Name           string `json:"name"`
+	VMID           string `json:"vmId"`
+	VMSize         string `json:"vmSize"`
+	SubscriptionID string `json:"subscriptionId"`
+	Zone           string `json:"zone"`
+}
+
+// Detect implements resource.Detector.
+func (d AzureVM) Detect(ctx context.Context) (*resource.Resource, error) {
+	base := baseURL(d.Endpoint, defaultAzureEndpoint)
+	body, err := fetch(ctx, newClient(d.Timeout), http.MethodGet, base+azureComputePath, http.Header{
+		"Metadata": {"true"},
+	})
+	if notThisCloud(err) {
+		return resource.Empty(), nil
+	}
+	if err != nil {
+		return resource.Empty(), err
+	}
+
+	var compute azureCompute
+	if err := json.Unmarshal(body, &compute); err != nil {
+		return resource.Empty(), err
+	}
+
+	labels := []kv.KeyValue{
+		standard.CloudProviderAzure,
+		standard.CloudAccountIDKey.String(compute.SubscriptionID),
+		standard.CloudRegionKey.String(compute.Location),
+		standard.HostIDKey.String(compute.VMID),
+		standard.HostNameKey.String(compute.Name),
+		standard.HostTypeKey.String(compute.VMSize),
+	}
+	if compute.Zone != "" {
+		labels = append(labels, standard.CloudZoneKey.String(compute.Zone))
+	}
+	return resource.New(labels...), nil
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+)
+
+// newAzureServer mimics the Azure instance metadata service, which
+// rejects requests without the Metadata header and unknown paths.
+func newAzureServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metadata/instance/compute", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, "json", r.URL.Query().Get("format"))
+		_, _ = w.Write([]byte(`{
+			"location": "westeurope",
+			"name": "examplevmname",
+			"vmId": "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			"vmSize": "Standard_A3",
+			"subscriptionId": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+			"zone": "1"
+		}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestAzureVMDetect(t *testing.T) {
+	srv := newAzureServer(t)
+	defer srv.Close()
+
+	res, err := AzureVM{Endpoint: srv.URL}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []kv.KeyValue{
+		standard.CloudProviderAzure,
+		standard.CloudAccountIDKey.String("8d10da13-8125-4ba9-a717-bf7490507b3d"),
+		standard.CloudRegionKey.String("westeurope"),
+		standard.CloudZoneKey.String("1"),
+		standard.HostIDKey.String("02aab8a4-74ef-476e-8182-f6d2ba4166a6"),
+		standard.HostNameKey.String("examplevmname"),
+		standard.HostTypeKey.String("Standard_A3"),
+	}, res.Attributes())
+}
+
+func TestAzureVMDetectTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	res, err := AzureVM{Endpoint: srv.URL, Timeout: 10 * time.Millisecond}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestAzureVMDetectMalformed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+
+	_, err := AzureVM{Endpoint: srv.URL}.Detect(context.Background())
+	assert.Error(t, err)
+}
+
+func TestAzureVMDetectOnEC2(t *testing.T) {
+	srv := newEC2Server(t)
+	defer srv.Close()
+
+	res, err := AzureVM{Endpoint: srv.URL}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestAzureVMDetectError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	_, err := AzureVM{Endpoint: srv.URL}.Detect(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloud provides resource detectors that query the metadata
+// services of cloud providers.
+//
+// Every detector talks to a well-known metadata endpoint using a short
+// timeout.  When the endpoint cannot be reached, or rejects the first
+// request with a 4xx status as the metadata service of another provider
+// at the same address does, the process is assumed not to be running
+// on that cloud and an empty Resource is returned without an error.
+// The base URL of each endpoint can be overridden, which is mostly
+// useful for testing.
+//
+// The detectors are meant to be used with resource.Detect:
+//
+//	res, err := resource.Detect(ctx, cloud.EC2{}, cloud.GCE{}, cloud.AzureVM{})
+package cloud // import "github.com/Ch1f/otel/sdk/resource/cloud"
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+const (
+	// defaultGCEEndpoint is the base URL of the GCE metadata server.
+	defaultGCEEndpoint = "http://metadata.google.internal/computeMetadata/v1"
+
+	// envKubernetesServiceHost is set by Kubernetes in every container
+	// of a pod.
+	envKubernetesServiceHost = "KUBERNETES_SERVICE_HOST"
+)
+
+// gceHeader is the header required by the GCE metadata server.
+var gceHeader = http.Header{"Metadata-Flavor": {"Google"}}
+
+// GCE is a detector that queries the Google Compute Engine metadata
+// server.
+type GCE struct {
+	// Endpoint is the base URL of the metadata server.  It defaults to
+	// "http://metadata.google.internal/computeMetadata/v1".
+	Endpoint string
+	// Timeout bounds each metadata request.  It defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// compile time assertion that GCE implements Detector interface
+var _ resource.Detector = GCE{}
+
+// Detect implements resource.Detector.
+func (d GCE) Detect(ctx context.Context) (*resource.Resource, error) {
+	labels, err := d.detect(ctx, newClient(d.Timeout))
+	return resource.New(labels...), err
+}
+
+func (d GCE) detect(ctx context.Context, client *http.Client) ([]kv.KeyValue, error) {
+	base := baseURL(d.Endpoint, defaultGCEEndpoint)
+	get := func(p string) (string, error) {
+		b, err := fetch(ctx, client, http.MethodGet, base+"/"+p, gceHeader)
+		return string(b), err
+	}
+
+	project, err := get("project/project-id")
+	if notThisCloud(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	labels := []kv.KeyValue{
+		standard.CloudProviderGCP,
+		standard.CloudAccountIDKey.String(project),
+	}
+
+	for _, m := range []struct {
+		path string
+		set  func(string)
+	}{
+		{"instance/id", func(v string) { labels = append(labels, standard.HostIDKey.String(v)) }},
+		{"instance/name", func(v string) { labels = append(labels, standard.HostNameKey.String(v)) }},
+		{"instance/hostname", func(v string) { labels = append(labels, standard.HostHostNameKey.String(v)) }},
+		{"instance/machine-type", func(v string) { labels = append(labels, standard.HostTypeKey.String(path.Base(v))) }},
+		{"instance/zone", func(v string) {
+			zone := path.Base(v)
+			labels = append(labels,
+				standard.CloudZoneKey.String(zone),
+				standard.CloudRegionKey.String(regionFromZone(zone)),
+			)
+		}},
+	} {
+		v, err := get(m.path)
+		if err != nil {
+			return labels, err
+		}
+		m.set(v)
+	}
+	return labels, nil
+}
+
+// GKE is a detector for Google Kubernetes Engine.  It provides the
+// attributes of the GCE detector along with the cluster name, and only
+// reports a Resource when running inside Kubernetes.
+type GKE struct {
+	// Endpoint is the base URL of the metadata server.  It defaults to
+	// "http://metadata.google.internal/computeMetadata/v1".
+	Endpoint string
+	// Timeout bounds each metadata request.  It defaults to
+	// DefaultTimeout.
+	Timeout time.Duration
+}
+
+// compile time assertion that GKE implements Detector interface
+var _ resource.Detector = GKE{}
+
+// Detect implements resource.Detector.
+func (d GKE) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv(envKubernetesServiceHost) == "" {
+		return resource.Empty(), nil
+	}
+	client := newClient(d.Timeout)
+	gce := GCE{Endpoint: d.Endpoint, Timeout: d.Timeout}
+	labels, err := gce.detect(ctx, client)
+	if err != nil || len(labels) == 0 {
+		return resource.New(labels...), err
+	}
+
+	base := baseURL(d.Endpoint, defaultGCEEndpoint)
+	cluster, err := fetch(ctx, client, http.MethodGet, base+"/instance/attributes/cluster-name", gceHeader)
+	if err != nil {
+		return resource.New(labels...), err
+	}
+	labels = append(labels, standard.K8SClusterNameKey.String(string(cluster)))
+	return resource.New(labels...), nil
+}
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	ottest "github.com/Ch1f/otel/internal/testing"
+)
+
+var gceMetadata = map[string]string{
+	"/computeMetadata/v1/project/project-id":               "my-project",
+	"/computeMetadata/v1/instance/id":                      "4520031799277581759",
+	"/computeMetadata/v1/instance/name":                    "gke-node-1",
+	"/computeMetadata/v1/instance/hostname":                "gke-node-1.c.my-project.internal",
+	"/computeMetadata/v1/instance/machine-type":            "projects/123456/machineTypes/n1-standard-1",
+	"/computeMetadata/v1/instance/zone":                    "projects/123456/zones/us-central1-c",
+	"/computeMetadata/v1/instance/attributes/cluster-name": "my-cluster",
+}
+
+func newGCEServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v, ok := gceMetadata[r.URL.Path]
+		if !ok || r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}))
+}
+
+var wantGCE = []kv.KeyValue{
+	standard.CloudProviderGCP,
+	standard.CloudAccountIDKey.String("my-project"),
+	standard.CloudRegionKey.String("us-central1"),
+	standard.CloudZoneKey.String("us-central1-c"),
+	standard.HostIDKey.String("4520031799277581759"),
+	standard.HostNameKey.String("gke-node-1"),
+	standard.HostHostNameKey.String("gke-node-1.c.my-project.internal"),
+	standard.HostTypeKey.String("n1-standard-1"),
+}
+
+func TestGCEDetect(t *testing.T) {
+	srv := newGCEServer()
+	defer srv.Close()
+
+	res, err := GCE{Endpoint: srv.URL + "/computeMetadata/v1/"}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, wantGCE, res.Attributes())
+}
+
+func TestGCEDetectUnavailable(t *testing.T) {
+	srv := newGCEServer()
+	url := srv.URL
+	srv.Close()
+
+	res, err := GCE{Endpoint: url}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestGKEDetect(t *testing.T) {
+	srv := newGCEServer()
+	defer srv.Close()
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envKubernetesServiceHost: "10.0.0.1",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := GKE{Endpoint: srv.URL + "/computeMetadata/v1"}.Detect(context.Background())
+	require.NoError(t, err)
+	want := append([]kv.KeyValue{standard.K8SClusterNameKey.String("my-cluster")}, wantGCE...)
+	assert.ElementsMatch(t, want, res.Attributes())
+}
+
+func TestGKEDetectOutsideKubernetes(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envKubernetesServiceHost: "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := GKE{Endpoint: "http://invalid.example"}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestRegionFromZone(t *testing.T) {
+	for zone, region := range map[string]string{
+		"us-central1-c":  "us-central1",
+		"us-west-2b":     "us-west-2",
+		"eu-west-1a":     "eu-west-1",
+		"europe-west4-a": "europe-west4",
+		"":               "",
+	} {
+		assert.Equal(t, region, regionFromZone(zone), zone)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout is the timeout applied to each metadata request when a
+// detector does not configure one.
+const DefaultTimeout = 2 * time.Second
+
+// maxResponseSize bounds the size of metadata responses read into
+// memory.
+const maxResponseSize = 1 << 20
+
+var (
+	// errUnavailable is returned by fetch when the metadata endpoint
+	// could not be reached.
+	errUnavailable = errors.New("metadata endpoint unavailable")
+
+	// errRejected is wrapped by the error fetch returns when the
+	// endpoint responds with a 4xx status.  Several providers serve
+	// their metadata at 169.254.169.254, so this is how a detector
+	// sees another provider's metadata service.
+	errRejected = errors.New("metadata request rejected")
+)
+
+// newClient returns an HTTP client using timeout, or DefaultTimeout if
+// timeout is not positive.
+func newClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// fetch performs a metadata request and returns the response body.
+// errUnavailable is returned if the request could not be sent and an
+// error wrapping errRejected if the endpoint responded with a 4xx
+// status, any other error indicates that the endpoint responded
+// unexpectedly.
+func fetch(ctx context.Context, client *http.Client, method, url string, header http.Header) ([]byte, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errUnavailable
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		return nil, fmt.Errorf("%s %s: %w: %q", method, url, errRejected, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s: unexpected status %q", method, url, resp.Status)
+	}
+	return body, nil
+}
+
+// notThisCloud reports whether err, returned by the first request of
+// a detector, means that the process does not run on the detector's
+// provider: the metadata endpoint is unreachable or belongs to another
+// provider.
+func notThisCloud(err error) bool {
+	return err == errUnavailable || errors.Is(err, errRejected)
+}
+
+// baseURL returns url, or def if url is empty, without any trailing
+// slash.
+func baseURL(url, def string) string {
+	if url == "" {
+		url = def
+	}
+	return strings.TrimSuffix(url, "/")
+}
+
+// regionFromZone derives a region name from a zone name by dropping
+// the zone suffix, e.g. "us-east-1a" -> "us-east-1" and
+// "us-central1-b" -> "us-central1".
+func regionFromZone(zone string) string {
+	if i := strings.LastIndexByte(zone, '-'); i > 0 && len(zone)-i == 2 {
+		return zone[:i]
+	}
+	if len(zone) > 1 {
+		last := zone[len(zone)-1]
+		if last >= 'a' && last <= 'z' {
+			return zone[:len(zone)-1]
+		}
+	}
+	return zone
+}
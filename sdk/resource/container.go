@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Ch1f/otel/api/standard"
+)
+
+// defaultProcFS is the mount point of the proc filesystem on Linux.
+const defaultProcFS = "/proc"
+
+// containerIDRegexp matches a 64 character hexadecimal container ID as
+// used by Docker, containerd and CRI-O.
+var containerIDRegexp = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Container is a detector that provides the ID of the container the
+// process is running in, as found in the process cgroup file.
+type Container struct {
+	// ProcFS is the root of the proc filesystem.  It defaults to
+	// "/proc" when empty.
+	ProcFS string
+}
+
+// compile time assertion that Container implements Detector interface
+var _ Detector = Container{}
+
+// Detect implements Detector.  An empty Resource is returned when the
+// cgroup file does not exist or does not reference a container.
+func (c Container) Detect(context.Context) (*Resource, error) {
+	root := c.ProcFS
+	if root == "" {
+		root = defaultProcFS
+	}
+	f, err := os.Open(filepath.Join(root, "self", "cgroup"))
+	if os.IsNotExist(err) {
+		return Empty(), nil
+	}
+	if err != nil {
+		return Empty(), err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if id := containerIDFromCgroupLine(scanner.Text()); id != "" {
+			return New(standard.ContainerIDKey.String(id)), nil
+		}
+	}
+	return Empty(), scanner.Err()
+}
+
+// containerIDFromCgroupLine extracts the container ID from a line of a
+// cgroup file, such as:
+//
+//	12:devices:/docker/<id>
+//	1:name=systemd:/kubepods/burstable/pod<uid>/<id>
+//	0::/system.slice/docker-<id>.scope
+//
+// An empty string is returned if the line does not reference a
+// container.
+func containerIDFromCgroupLine(line string) string {
+	line = strings.TrimSpace(line)
+	last := line[strings.LastIndexByte(line, '/')+1:]
+	last = strings.TrimSuffix(last, ".scope")
+	last = last[strings.LastIndexByte(last, '-')+1:]
+	if containerIDRegexp.MatchString(last) {
+		return last
+	}
+	return ""
+}
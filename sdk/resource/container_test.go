@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+)
+
+const testContainerID = "ac679f8a8319c8cf7d38e1adf263bc08d231f2ff81abda3915f6e8ba4d64156a"
+
+// fakeProcFS creates a proc filesystem root holding the given cgroup
+// file contents and returns its path along with a cleanup function.
+func fakeProcFS(t *testing.T, cgroup string) (string, func()) {
+	root, err := ioutil.TempDir("", "procfs")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "self"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "self", "cgroup"), []byte(cgroup), 0644))
+	return root, func() { os.RemoveAll(root) }
+}
+
+func TestContainerDetector(t *testing.T) {
+	cases := []struct {
+		name   string
+		cgroup string
+		want   []kv.KeyValue
+	}{
+		{
+			name:   "docker",
+			cgroup: "12:devices:/docker/" + testContainerID + "\n11:cpu:/docker/" + testContainerID + "\n",
+			want:   []kv.KeyValue{standard.ContainerIDKey.String(testContainerID)},
+		},
+		{
+			name:   "kubernetes",
+			cgroup: "1:name=systemd:/kubepods/burstable/pod2c48913c-b29f-11e7-9350-020000000000/" + testContainerID + "\n",
+			want:   []kv.KeyValue{standard.ContainerIDKey.String(testContainerID)},
+		},
+		{
+			name:   "systemd scope",
+			cgroup: "0::/system.slice/docker-" + testContainerID + ".scope\n",
+			want:   []kv.KeyValue{standard.ContainerIDKey.String(testContainerID)},
+		},
+		{
+			name:   "not in a container",
+			cgroup: "12:devices:/user.slice\n0::/init.scope\n",
+			want:   nil,
+		},
+		{
+			name:   "empty",
+			cgroup: "",
+			want:   nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			root, cleanup := fakeProcFS(t, c.cgroup)
+			defer cleanup()
+
+			res, err := Container{ProcFS: root}.Detect(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, c.want, res.Attributes())
+		})
+	}
+}
+
+func TestContainerDetectorMissingCgroup(t *testing.T) {
+	root, err := ioutil.TempDir("", "procfs")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	res, err := Container{ProcFS: root}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"strings"
+)
+
+// Detector detects OpenTelemetry resource information.
+type Detector interface {
+	// Detect returns an initialized Resource based on gathered
+	// information.  If the source information to construct a
+	// Resource is not available, an empty Resource should be
+	// returned along with a nil error.  An error should only be
+	// returned when the detector encounters a failure, in which case
+	// any partially detected Resource may also be returned.
+	Detect(ctx context.Context) (*Resource, error)
+}
+
+// DetectErrors is the error returned by Detect when one or more
+// detectors failed.  It contains every error returned by a detector,
+// in the order the detectors were called.
+type DetectErrors []error
+
+var _ error = DetectErrors(nil)
+
+// Error implements the error interface.
+func (e DetectErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "resource detection failed: " + strings.Join(msgs, "; ")
+}
+
+// Detect calls all input detectors sequentially and merges each
+// result with the previous one.  When a key is detected by more than
+// one detector, the value from the detector that appears first in the
+// list is preserved.
+//
+// Detection does not stop when a detector fails: the Resource built
+// from every successful detector is returned along with a
+// DetectErrors value holding all the failures.
+func Detect(ctx context.Context, detectors ...Detector) (*Resource, error) {
+	var (
+		res  = Empty()
+		errs DetectErrors
+	)
+	for _, detector := range detectors {
+		if detector == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		r, err := detector.Detect(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		res = Merge(res, r)
+	}
+	if len(errs) == 0 {
+		return res, nil
+	}
+	return res, errs
+}
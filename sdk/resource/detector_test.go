@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+	ottest "github.com/Ch1f/otel/internal/testing"
+	"github.com/Ch1f/otel/sdk/resource"
+)
+
+type fakeDetector struct {
+	res *resource.Resource
+	err error
+}
+
+func (d fakeDetector) Detect(context.Context) (*resource.Resource, error) {
+	return d.res, d.err
+}
+
+func TestDetect(t *testing.T) {
+	errA := errors.New("detector A failed")
+	errB := errors.New("detector B failed")
+
+	cases := []struct {
+		name      string
+		detectors []resource.Detector
+		want      []kv.KeyValue
+		wantErrs  resource.DetectErrors
+	}{
+		{
+			name: "no detectors",
+			want: nil,
+		},
+		{
+			name: "first detector wins",
+			detectors: []resource.Detector{
+				fakeDetector{res: resource.New(kv11, kv21)},
+				fakeDetector{res: resource.New(kv12, kv31)},
+			},
+			want: []kv.KeyValue{kv11, kv21, kv31},
+		},
+		{
+			name: "nil detectors and resources are skipped",
+			detectors: []resource.Detector{
+				nil,
+				fakeDetector{},
+				fakeDetector{res: resource.New(kv41)},
+			},
+			want: []kv.KeyValue{kv41},
+		},
+		{
+			name: "errors are aggregated",
+			detectors: []resource.Detector{
+				fakeDetector{err: errA},
+				fakeDetector{res: resource.New(kv11), err: errB},
+				fakeDetector{res: resource.New(kv21)},
+			},
+			want:     []kv.KeyValue{kv11, kv21},
+			wantErrs: resource.DetectErrors{errA, errB},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := resource.Detect(context.Background(), c.detectors...)
+			if c.wantErrs == nil {
+				require.NoError(t, err)
+			} else {
+				assert.Equal(t, c.wantErrs, err)
+			}
+			assert.Equal(t, c.want, res.Attributes())
+		})
+	}
+}
+
+func TestDetectCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := resource.Detect(ctx, fakeDetector{res: resource.New(kv11)})
+	assert.Equal(t, resource.DetectErrors{context.Canceled}, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestDetectErrorsMessage(t *testing.T) {
+	err := resource.DetectErrors{errors.New("a"), errors.New("b")}
+	assert.EqualError(t, err, "resource detection failed: a; b")
+}
+
+func TestFromEnvDetector(t *testing.T) {
+	cases := []struct {
+		name    string
+		env     string
+		want    []kv.KeyValue
+		wantErr bool
+	}{
+		{
+			name: "unset",
+			env:  "",
+		},
+		{
+			name: "valid",
+			env:  "key=value, k = v ,a=x=y",
+			want: []kv.KeyValue{
+				kv.String("a", "x=y"),
+				kv.String("k", "v"),
+				kv.String("key", "value"),
+			},
+		},
+		{
+			name:    "missing value",
+			env:     "key=value,missing,=nokey",
+			want:    []kv.KeyValue{kv.String("key", "value")},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store, err := ottest.SetEnvVariables(map[string]string{
+				"OTEL_RESOURCE_ATTRIBUTES": c.env,
+			})
+			require.NoError(t, err)
+			defer func() { require.NoError(t, store.Restore()) }()
+
+			res, err := resource.FromEnv{}.Detect(context.Background())
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, c.want, res.Attributes())
+		})
+	}
+}
+
+func TestHostDetector(t *testing.T) {
+	name, err := os.Hostname()
+	require.NoError(t, err)
+
+	res, err := resource.Host{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []kv.KeyValue{standard.HostNameKey.String(name)}, res.Attributes())
+}
+
+func TestProcessDetector(t *testing.T) {
+	res, err := resource.Process{}.Detect(context.Background())
+	require.NoError(t, err)
+
+	got := map[kv.Key]kv.KeyValue{}
+	for iter := res.Iter(); iter.Next(); {
+		got[iter.Label().Key] = iter.Label()
+	}
+	assert.Equal(t, standard.ProcessPIDKey.Int(os.Getpid()), got[standard.ProcessPIDKey])
+	assert.Equal(t, standard.ProcessCommandKey.String(os.Args[0]), got[standard.ProcessCommandKey])
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	assert.Equal(t, standard.ProcessExecutablePathKey.String(exe), got[standard.ProcessExecutablePathKey])
+	assert.Equal(t, standard.ProcessExecutableNameKey.String(filepath.Base(exe)), got[standard.ProcessExecutableNameKey])
+	assert.Contains(t, got, standard.ProcessCommandLineKey)
+	assert.Contains(t, got, standard.ProcessOwnerKey)
+}
+
+func TestKubernetesDetector(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"K8S_NAMESPACE_NAME":  "default",
+		"K8S_POD_NAME":        "web-5d8f7",
+		"K8S_CLUSTER_NAME":    "",
+		"K8S_DEPLOYMENT_NAME": "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := resource.Kubernetes{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []kv.KeyValue{
+		standard.K8SNamespaceNameKey.String("default"),
+		standard.K8SPodNameKey.String("web-5d8f7"),
+	}, res.Attributes())
+}
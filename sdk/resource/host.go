@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"os"
+
+	"github.com/Ch1f/otel/api/standard"
+)
+
+// Host is a detector that provides the host name of the machine the
+// process is running on.
+type Host struct{}
+
+// compile time assertion that Host implements Detector interface
+var _ Detector = Host{}
+
+// Detect implements Detector.
+func (Host) Detect(context.Context) (*Resource, error) {
+	name, err := os.Hostname()
+	if err != nil {
+		return Empty(), err
+	}
+	return New(standard.HostNameKey.String(name)), nil
+}
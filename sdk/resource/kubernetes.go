@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"os"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+)
+
+// Environment variables read by the Kubernetes detector.  They are
+// expected to be populated through the Kubernetes downward API, e.g.
+//
+//	env:
+//	- name: K8S_POD_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.name
+const (
+	envK8SClusterName    = "K8S_CLUSTER_NAME"
+	envK8SNamespaceName  = "K8S_NAMESPACE_NAME"
+	envK8SPodName        = "K8S_POD_NAME"
+	envK8SDeploymentName = "K8S_DEPLOYMENT_NAME"
+)
+
+// Kubernetes is a detector that provides Kubernetes cluster, namespace,
+// pod and deployment names from environment variables populated through
+// the downward API.
+type Kubernetes struct{}
+
+// compile time assertion that Kubernetes implements Detector interface
+var _ Detector = Kubernetes{}
+
+// Detect implements Detector.  Only the environment variables that are
+// set produce attributes.
+func (Kubernetes) Detect(context.Context) (*Resource, error) {
+	var labels []kv.KeyValue
+	for _, e := range []struct {
+		env string
+		key kv.Key
+	}{
+		{envK8SClusterName, standard.K8SClusterNameKey},
+		{envK8SNamespaceName, standard.K8SNamespaceNameKey},
+		{envK8SPodName, standard.K8SPodNameKey},
+		{envK8SDeploymentName, standard.K8SDeploymentNameKey},
+	} {
+		if v := os.Getenv(e.env); v != "" {
+			labels = append(labels, e.key.String(v))
+		}
+	}
+	return New(labels...), nil
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
+)
+
+// Process is a detector that provides information about the current
+// operating system process: its PID, executable, command line and
+// owner.
+type Process struct{}
+
+// compile time assertion that Process implements Detector interface
+var _ Detector = Process{}
+
+// Detect implements Detector.  Attributes that cannot be determined are
+// omitted, and the failures are reported through the returned error.
+func (Process) Detect(context.Context) (*Resource, error) {
+	labels := []kv.KeyValue{
+		standard.ProcessPIDKey.Int(os.Getpid()),
+	}
+	var errs []string
+
+	if len(os.Args) > 0 {
+		labels = append(labels,
+			standard.ProcessCommandKey.String(os.Args[0]),
+			standard.ProcessCommandLineKey.String(strings.Join(os.Args, " ")),
+		)
+	}
+
+	if path, err := os.Executable(); err == nil {
+		labels = append(labels,
+			standard.ProcessExecutableNameKey.String(filepath.Base(path)),
+			standard.ProcessExecutablePathKey.String(path),
+		)
+	} else {
+		errs = append(errs, err.Error())
+	}
+
+	if u, err := user.Current(); err == nil {
+		labels = append(labels, standard.ProcessOwnerKey.String(u.Username))
+	} else {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return New(labels...), fmt.Errorf("process detector: %s", strings.Join(errs, "; "))
+	}
+	return New(labels...), nil
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+// FanOutSpanProcessor implements SpanProcessor interfaces. It forwards
+// the spans to several SpanProcessors, in order. Combined with the
+// processors forwarding to a next processor, like
+// RedactingSpanProcessor and FilterSpanProcessor, it builds a tree of
+// processors that can be registered as one.
+type FanOutSpanProcessor struct {
+	processors []SpanProcessor
+}
+
+var _ SpanProcessor = (*FanOutSpanProcessor)(nil)
+
+// NewFanOutSpanProcessor creates a new instance of FanOutSpanProcessor
+// forwarding to the processors. Nil processors are ignored.
+func NewFanOutSpanProcessor(processors ...SpanProcessor) *FanOutSpanProcessor {
+	fsp := &FanOutSpanProcessor{}
+	for _, sp := range processors {
+		if sp != nil {
+			fsp.processors = append(fsp.processors, sp)
+		}
+	}
+	return fsp
+}
+
+// OnStart method forwards the span to each processor.
+func (fsp *FanOutSpanProcessor) OnStart(sd *export.SpanData) {
+	for _, sp := range fsp.processors {
+		sp.OnStart(sd)
+	}
+}
+
+// OnEnd method forwards the span to each processor.
+func (fsp *FanOutSpanProcessor) OnEnd(sd *export.SpanData) {
+	for _, sp := range fsp.processors {
+		sp.OnEnd(sd)
+	}
+}
+
+// Shutdown shuts down each processor.
+func (fsp *FanOutSpanProcessor) Shutdown() {
+	for _, sp := range fsp.processors {
+		sp.Shutdown()
+	}
+}
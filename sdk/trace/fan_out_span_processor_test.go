@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func TestFanOutSpanProcessor(t *testing.T) {
+	first := NewTestSpanProcessor()
+	second := NewTestSpanProcessor()
+	fsp := sdktrace.NewFanOutSpanProcessor(first, nil, second)
+
+	sd := &export.SpanData{Name: "span"}
+	fsp.OnStart(sd)
+	fsp.OnEnd(sd)
+	fsp.Shutdown()
+
+	for _, sp := range []*testSpanProcesor{first, second} {
+		assert.Equal(t, []*export.SpanData{sd}, sp.spansStarted)
+		assert.Equal(t, []*export.SpanData{sd}, sp.spansEnded)
+		assert.Equal(t, 1, sp.shutdownCount)
+	}
+}
+
+func TestSpanProcessorPipeline(t *testing.T) {
+	redacted := NewTestSpanProcessor()
+	raw := NewTestSpanProcessor()
+	redact, err := sdktrace.NewRedactingSpanProcessor(redacted, sdktrace.RedactKeys(sdktrace.RedactDelete, "token"))
+	require.NoError(t, err)
+	filter, err := sdktrace.NewFilterSpanProcessor(
+		sdktrace.NewFanOutSpanProcessor(redact, raw),
+		sdktrace.SpanNameMatches(regexp.MustCompile(`^health`)),
+	)
+	require.NoError(t, err)
+
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(filter)
+	tr := tp.Tracer("pipeline")
+	_, span := tr.Start(context.Background(), "request")
+	span.SetAttributes(kv.String("token", "secret"), kv.String("user", "a"))
+	span.End()
+	_, span = tr.Start(context.Background(), "healthcheck")
+	span.End()
+	tp.UnregisterSpanProcessor(filter)
+
+	require.Len(t, redacted.spansEnded, 1)
+	assert.Equal(t, "request", redacted.spansEnded[0].Name)
+	assert.Equal(t, []kv.KeyValue{kv.String("user", "a")}, redacted.spansEnded[0].Attributes)
+	require.Len(t, raw.spansEnded, 1)
+	assert.Len(t, raw.spansEnded[0].Attributes, 2)
+	assert.Equal(t, 1, redacted.shutdownCount)
+	assert.Equal(t, 1, raw.shutdownCount)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"regexp"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+// SpanPredicate reports whether a span matches a condition.
+type SpanPredicate func(sd *export.SpanData) bool
+
+// SpanNameMatches returns a SpanPredicate matching the spans whose
+// name matches the pattern.
+func SpanNameMatches(pattern *regexp.Regexp) SpanPredicate {
+	return func(sd *export.SpanData) bool {
+		return pattern.MatchString(sd.Name)
+	}
+}
+
+// SpanKindIs returns a SpanPredicate matching the spans of one of the
+// kinds.
+func SpanKindIs(kinds ...apitrace.SpanKind) SpanPredicate {
+	return func(sd *export.SpanData) bool {
+		for _, kind := range kinds {
+			if sd.SpanKind == kind {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SpanHasAttribute returns a SpanPredicate matching the spans having
+// the attribute.
+func SpanHasAttribute(attr kv.KeyValue) SpanPredicate {
+	return func(sd *export.SpanData) bool {
+		for _, a := range sd.Attributes {
+			if a == attr {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AllOf returns a SpanPredicate matching the spans matched by all the
+// predicates.
+func AllOf(predicates ...SpanPredicate) SpanPredicate {
+	return func(sd *export.SpanData) bool {
+		for _, p := range predicates {
+			if !p(sd) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// FilterSpanProcessor implements SpanProcessor interfaces. It drops
+// the ended spans matched by any of its predicates, and forwards the
+// others to another SpanProcessor.
+//
+// Started spans are all forwarded, since their attributes may still
+// change before they end.
+type FilterSpanProcessor struct {
+	next SpanProcessor
+	drop []SpanPredicate
+}
+
+var _ SpanProcessor = (*FilterSpanProcessor)(nil)
+
+// NewFilterSpanProcessor creates a new instance of FilterSpanProcessor
+// forwarding to next the spans not matched by any of the drop
+// predicates. It returns an error if next is nil.
+func NewFilterSpanProcessor(next SpanProcessor, drop ...SpanPredicate) (*FilterSpanProcessor, error) {
+	if next == nil {
+		return nil, errNilProcessor
+	}
+	return &FilterSpanProcessor{
+		next: next,
+		drop: drop,
+	}, nil
+}
+
+// OnStart method forwards the span.
+func (fsp *FilterSpanProcessor) OnStart(sd *export.SpanData) {
+	fsp.next.OnStart(sd)
+}
+
+// OnEnd method forwards the span unless it is dropped.
+func (fsp *FilterSpanProcessor) OnEnd(sd *export.SpanData) {
+	for _, p := range fsp.drop {
+		if p(sd) {
+			return
+		}
+	}
+	fsp.next.OnEnd(sd)
+}
+
+// Shutdown shuts down the next processor.
+func (fsp *FilterSpanProcessor) Shutdown() {
+	fsp.next.Shutdown()
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func TestNewFilterSpanProcessorWithNilProcessor(t *testing.T) {
+	_, err := sdktrace.NewFilterSpanProcessor(nil)
+	assert.Error(t, err)
+}
+
+func TestFilterSpanProcessor(t *testing.T) {
+	health := &export.SpanData{Name: "GET /healthz", SpanKind: apitrace.SpanKindServer}
+	internal := &export.SpanData{Name: "cache.get", SpanKind: apitrace.SpanKindInternal}
+	debug := &export.SpanData{
+		Name:       "GET /debug",
+		SpanKind:   apitrace.SpanKindServer,
+		Attributes: []kv.KeyValue{kv.Bool("debug", true)},
+	}
+	request := &export.SpanData{Name: "GET /users", SpanKind: apitrace.SpanKindServer}
+	spans := []*export.SpanData{health, internal, debug, request}
+
+	for _, test := range []struct {
+		name string
+		drop []sdktrace.SpanPredicate
+		want []*export.SpanData
+	}{
+		{
+			name: "no predicate",
+			want: spans,
+		},
+		{
+			name: "name",
+			drop: []sdktrace.SpanPredicate{sdktrace.SpanNameMatches(regexp.MustCompile(`/healthz$`))},
+			want: []*export.SpanData{internal, debug, request},
+		},
+		{
+			name: "kind",
+			drop: []sdktrace.SpanPredicate{sdktrace.SpanKindIs(apitrace.SpanKindInternal, apitrace.SpanKindClient)},
+			want: []*export.SpanData{health, debug, request},
+		},
+		{
+			name: "attribute",
+			drop: []sdktrace.SpanPredicate{sdktrace.SpanHasAttribute(kv.Bool("debug", true))},
+			want: []*export.SpanData{health, internal, request},
+		},
+		{
+			name: "any predicate",
+			drop: []sdktrace.SpanPredicate{
+				sdktrace.SpanKindIs(apitrace.SpanKindInternal),
+				sdktrace.SpanHasAttribute(kv.Bool("debug", true)),
+			},
+			want: []*export.SpanData{health, request},
+		},
+		{
+			name: "all of",
+			drop: []sdktrace.SpanPredicate{sdktrace.AllOf(
+				sdktrace.SpanKindIs(apitrace.SpanKindServer),
+				sdktrace.SpanNameMatches(regexp.MustCompile(`^GET /(healthz|debug)$`)),
+			)},
+			want: []*export.SpanData{internal, request},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			next := NewTestSpanProcessor()
+			fsp, err := sdktrace.NewFilterSpanProcessor(next, test.drop...)
+			require.NoError(t, err)
+
+			for _, sd := range spans {
+				fsp.OnStart(sd)
+				fsp.OnEnd(sd)
+			}
+			fsp.Shutdown()
+
+			assert.Equal(t, spans, next.spansStarted)
+			assert.Equal(t, test.want, next.spansEnded)
+			assert.Equal(t, 1, next.shutdownCount)
+		})
+	}
+}
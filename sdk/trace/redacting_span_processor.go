@@ -0,0 +1,296 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+// RedactedMask replaces the values masked by a RedactingSpanProcessor.
+const RedactedMask = "[REDACTED]"
+
+// RedactionAction is the action of a RedactionRule on a matching
+// attribute.
+type RedactionAction int
+
+const (
+	// RedactDelete removes the attribute.
+	RedactDelete RedactionAction = iota
+	// RedactMask replaces the value, or its parts matching the
+	// pattern of the rule, with RedactedMask.
+	RedactMask
+	// RedactHash replaces the value, or its parts matching the
+	// pattern of the rule, with their hex encoded SHA-256 hash, so
+	// that equal values can still be correlated. Without the HashKey
+	// of the rule, hashes of guessable values such as email
+	// addresses can be reversed with a dictionary.
+	RedactHash
+)
+
+// RedactionRule selects the attributes redacted by a
+// RedactingSpanProcessor and how.
+type RedactionRule struct {
+	// Keys restricts the rule to the attributes with one of these
+	// keys. The rule applies to all the attributes if Keys is empty.
+	Keys []kv.Key
+
+	// Pattern, if not nil, restricts the rule to the string
+	// attributes, and the string array attributes with an element,
+	// matching it. Masking and hashing then only replace the matching
+	// parts of the value or of each element. The string and string
+	// array entries of map attributes are redacted the same way, at any
+	// depth, deletion removing the matching entries from the map.
+	Pattern *regexp.Regexp
+
+	// Action is applied to the matching attributes.
+	Action RedactionAction
+
+	// HashKey, if not empty, makes RedactHash compute an HMAC-SHA256
+	// keyed with it instead of a plain SHA-256 hash.
+	HashKey []byte
+}
+
+// RedactKeys returns a RedactionRule applying the action to the
+// attributes with one of the keys.
+func RedactKeys(action RedactionAction, keys ...kv.Key) RedactionRule {
+	return RedactionRule{
+		Keys:   keys,
+		Action: action,
+	}
+}
+
+// RedactPattern returns a RedactionRule applying the action to the
+// parts of the string attributes matching the pattern. If keys are
+// given, only the attributes with one of them are redacted.
+func RedactPattern(action RedactionAction, pattern *regexp.Regexp, keys ...kv.Key) RedactionRule {
+	return RedactionRule{
+		Keys:    keys,
+		Pattern: pattern,
+		Action:  action,
+	}
+}
+
+func (r RedactionRule) validate() error {
+	switch r.Action {
+	case RedactDelete, RedactMask, RedactHash:
+		return nil
+	}
+	return fmt.Errorf("invalid redaction action: %d", r.Action)
+}
+
+func (r RedactionRule) matchesKey(key kv.Key) bool {
+	if len(r.Keys) == 0 {
+		return true
+	}
+	for _, k := range r.Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the redacted attribute, and false if it is deleted.
+func (r RedactionRule) apply(attr kv.KeyValue) (kv.KeyValue, bool) {
+	if !r.matchesKey(attr.Key) {
+		return attr, true
+	}
+	if r.Pattern == nil {
+		switch r.Action {
+		case RedactDelete:
+			return attr, false
+		case RedactMask:
+			return attr.Key.String(RedactedMask), true
+		default:
+			return attr.Key.String(r.hash(attr.Value.Emit())), true
+		}
+	}
+
+	redacted, matched := r.redactValue(attr.Value)
+	if !matched {
+		return attr, true
+	}
+	if r.Action == RedactDelete && attr.Value.Type() != value.MAP {
+		return attr, false
+	}
+	return kv.KeyValue{Key: attr.Key, Value: redacted}, true
+}
+
+// redactValue returns v with the strings matching the pattern redacted,
+// and true if any string matched. STRING and ARRAY values are returned
+// unchanged by RedactDelete for the caller to delete them, while the
+// matching entries of MAP values are removed, at any depth.
+func (r RedactionRule) redactValue(v value.Value) (value.Value, bool) {
+	switch v.Type() {
+	case value.STRING:
+		if !r.Pattern.MatchString(v.AsString()) {
+			return v, false
+		}
+		if r.Action == RedactDelete {
+			return v, true
+		}
+		return value.String(r.redactString(v.AsString())), true
+	case value.ARRAY:
+		elems := v.AsStringArray()
+		matched := false
+		for _, e := range elems {
+			if r.Pattern.MatchString(e) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return v, false
+		}
+		if r.Action == RedactDelete {
+			return v, true
+		}
+		for i, e := range elems {
+			elems[i] = r.redactString(e)
+		}
+		return value.StringArray(elems), true
+	case value.MAP:
+		entries := v.AsMapEntries()
+		m := make(map[string]value.Value, len(entries))
+		matched := false
+		for _, e := range entries {
+			redacted, ok := r.redactValue(e.Value)
+			if ok {
+				matched = true
+				if r.Action == RedactDelete && e.Value.Type() != value.MAP {
+					continue
+				}
+			}
+			m[e.Key] = redacted
+		}
+		if !matched {
+			return v, false
+		}
+		return value.Map(m), true
+	}
+	return v, false
+}
+
+// redactString masks or hashes the parts of s matching the pattern.
+func (r RedactionRule) redactString(s string) string {
+	if r.Action == RedactMask {
+		return r.Pattern.ReplaceAllLiteralString(s, RedactedMask)
+	}
+	return r.Pattern.ReplaceAllStringFunc(s, r.hash)
+}
+
+func (r RedactionRule) hash(s string) string {
+	if len(r.HashKey) == 0 {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, r.HashKey)
+	_, _ = mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RedactingSpanProcessor implements SpanProcessor interfaces. It
+// forwards a copy of the spans to another SpanProcessor, with the
+// attributes of the spans, of their events and of their links redacted
+// by its rules.
+type RedactingSpanProcessor struct {
+	next  SpanProcessor
+	rules []RedactionRule
+}
+
+var _ SpanProcessor = (*RedactingSpanProcessor)(nil)
+
+// NewRedactingSpanProcessor creates a new instance of
+// RedactingSpanProcessor forwarding spans to next. The rules are
+// applied in order to each attribute. It returns an error if next is
+// nil or a rule is invalid.
+func NewRedactingSpanProcessor(next SpanProcessor, rules ...RedactionRule) (*RedactingSpanProcessor, error) {
+	if next == nil {
+		return nil, errNilProcessor
+	}
+	for _, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &RedactingSpanProcessor{
+		next:  next,
+		rules: rules,
+	}, nil
+}
+
+// OnStart method forwards a redacted copy of the span.
+func (rsp *RedactingSpanProcessor) OnStart(sd *export.SpanData) {
+	rsp.next.OnStart(rsp.redact(sd))
+}
+
+// OnEnd method forwards a redacted copy of the span.
+func (rsp *RedactingSpanProcessor) OnEnd(sd *export.SpanData) {
+	rsp.next.OnEnd(rsp.redact(sd))
+}
+
+// Shutdown shuts down the next processor.
+func (rsp *RedactingSpanProcessor) Shutdown() {
+	rsp.next.Shutdown()
+}
+
+// redact returns a redacted copy of the span. The span is shared with
+// the other span processors, so it is never modified.
+func (rsp *RedactingSpanProcessor) redact(sd *export.SpanData) *export.SpanData {
+	cp := *sd
+	cp.Attributes = rsp.redactAttributes(sd.Attributes)
+	if sd.MessageEvents != nil {
+		cp.MessageEvents = make([]export.Event, len(sd.MessageEvents))
+		for i, event := range sd.MessageEvents {
+			event.Attributes = rsp.redactAttributes(event.Attributes)
+			cp.MessageEvents[i] = event
+		}
+	}
+	if sd.Links != nil {
+		cp.Links = make([]apitrace.Link, len(sd.Links))
+		for i, link := range sd.Links {
+			link.Attributes = rsp.redactAttributes(link.Attributes)
+			cp.Links[i] = link
+		}
+	}
+	return &cp
+}
+
+func (rsp *RedactingSpanProcessor) redactAttributes(attrs []kv.KeyValue) []kv.KeyValue {
+	if attrs == nil {
+		return nil
+	}
+	redacted := make([]kv.KeyValue, 0, len(attrs))
+attributes:
+	for _, attr := range attrs {
+		for _, rule := range rsp.rules {
+			var keep bool
+			if attr, keep = rule.apply(attr); !keep {
+				continue attributes
+			}
+		}
+		redacted = append(redacted, attr)
+	}
+	return redacted
+}
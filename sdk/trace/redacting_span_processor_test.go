@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/kv/value"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacHex(key []byte, s string) string {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestNewRedactingSpanProcessorErrors(t *testing.T) {
+	_, err := sdktrace.NewRedactingSpanProcessor(nil)
+	assert.Error(t, err)
+
+	_, err = sdktrace.NewRedactingSpanProcessor(NewTestSpanProcessor(), sdktrace.RedactionRule{Action: 42})
+	assert.Error(t, err)
+}
+
+func TestRedactingSpanProcessor(t *testing.T) {
+	email := regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`)
+	query := regexp.MustCompile(`\?.*$`)
+
+	for _, test := range []struct {
+		name  string
+		rules []sdktrace.RedactionRule
+		in    []kv.KeyValue
+		want  []kv.KeyValue
+	}{
+		{
+			name: "no rule",
+			in:   []kv.KeyValue{kv.String("user", "a@example.com")},
+			want: []kv.KeyValue{kv.String("user", "a@example.com")},
+		},
+		{
+			name:  "delete keys",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactKeys(sdktrace.RedactDelete, "auth.token", "password")},
+			in:    []kv.KeyValue{kv.String("auth.token", "secret"), kv.Int("status", 200), kv.String("password", "secret")},
+			want:  []kv.KeyValue{kv.Int("status", 200)},
+		},
+		{
+			name:  "mask keys",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactKeys(sdktrace.RedactMask, "auth.token", "user.id")},
+			in:    []kv.KeyValue{kv.String("auth.token", "secret"), kv.Int("user.id", 42)},
+			want:  []kv.KeyValue{kv.String("auth.token", sdktrace.RedactedMask), kv.String("user.id", sdktrace.RedactedMask)},
+		},
+		{
+			name:  "hash keys",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactKeys(sdktrace.RedactHash, "user.id")},
+			in:    []kv.KeyValue{kv.Int("user.id", 42)},
+			want:  []kv.KeyValue{kv.String("user.id", sha256Hex("42"))},
+		},
+		{
+			name:  "mask pattern",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactMask, email)},
+			in:    []kv.KeyValue{kv.String("message", "sent to a@example.com and b@example.org"), kv.Int("count", 2)},
+			want:  []kv.KeyValue{kv.String("message", "sent to [REDACTED] and [REDACTED]"), kv.Int("count", 2)},
+		},
+		{
+			name:  "hash pattern",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactHash, email)},
+			in:    []kv.KeyValue{kv.String("user", "a@example.com")},
+			want:  []kv.KeyValue{kv.String("user", sha256Hex("a@example.com"))},
+		},
+		{
+			name: "keyed hash pattern",
+			rules: []sdktrace.RedactionRule{{
+				Pattern: email,
+				Action:  sdktrace.RedactHash,
+				HashKey: []byte("key"),
+			}},
+			in:   []kv.KeyValue{kv.String("user", "a@example.com")},
+			want: []kv.KeyValue{kv.String("user", hmacHex([]byte("key"), "a@example.com"))},
+		},
+		{
+			name:  "mask pattern in string array",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactMask, email)},
+			in:    []kv.KeyValue{kv.Array("to", []string{"a@example.com", "team"}), kv.Array("ids", []int{1, 2})},
+			want:  []kv.KeyValue{kv.Array("to", []string{sdktrace.RedactedMask, "team"}), kv.Array("ids", []int{1, 2})},
+		},
+		{
+			name:  "delete pattern in string array",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactDelete, email)},
+			in:    []kv.KeyValue{kv.Array("to", []string{"team", "a@example.com"}), kv.Array("cc", []string{"team"})},
+			want:  []kv.KeyValue{kv.Array("cc", []string{"team"})},
+		},
+		{
+			name:  "mask pattern in map",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactMask, email)},
+			in: []kv.KeyValue{kv.Map("user", map[string]value.Value{
+				"email":   value.String("a@example.com"),
+				"id":      value.Int(42),
+				"contact": value.Map(map[string]value.Value{"to": value.StringArray([]string{"b@example.com"})}),
+			})},
+			want: []kv.KeyValue{kv.Map("user", map[string]value.Value{
+				"email":   value.String(sdktrace.RedactedMask),
+				"id":      value.Int(42),
+				"contact": value.Map(map[string]value.Value{"to": value.StringArray([]string{sdktrace.RedactedMask})}),
+			})},
+		},
+		{
+			name:  "delete pattern in map",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactDelete, email)},
+			in: []kv.KeyValue{kv.Map("user", map[string]value.Value{
+				"email":   value.String("a@example.com"),
+				"name":    value.String("a"),
+				"contact": value.Map(map[string]value.Value{"to": value.String("b@example.com")}),
+			})},
+			want: []kv.KeyValue{kv.Map("user", map[string]value.Value{
+				"name":    value.String("a"),
+				"contact": value.Map(map[string]value.Value{}),
+			})},
+		},
+		{
+			name:  "delete pattern",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactDelete, email)},
+			in:    []kv.KeyValue{kv.String("user", "a@example.com"), kv.String("name", "a")},
+			want:  []kv.KeyValue{kv.String("name", "a")},
+		},
+		{
+			name:  "pattern restricted to keys",
+			rules: []sdktrace.RedactionRule{sdktrace.RedactPattern(sdktrace.RedactMask, query, "http.url")},
+			in: []kv.KeyValue{
+				kv.String("http.url", "https://example.com/path?token=secret"),
+				kv.String("http.target", "/path?token=secret"),
+			},
+			want: []kv.KeyValue{
+				kv.String("http.url", "https://example.com/path[REDACTED]"),
+				kv.String("http.target", "/path?token=secret"),
+			},
+		},
+		{
+			name: "rules applied in order",
+			rules: []sdktrace.RedactionRule{
+				sdktrace.RedactPattern(sdktrace.RedactMask, email),
+				sdktrace.RedactKeys(sdktrace.RedactDelete, "user"),
+			},
+			in:   []kv.KeyValue{kv.String("user", "a@example.com"), kv.String("to", "b@example.com")},
+			want: []kv.KeyValue{kv.String("to", sdktrace.RedactedMask)},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			next := NewTestSpanProcessor()
+			rsp, err := sdktrace.NewRedactingSpanProcessor(next, test.rules...)
+			require.NoError(t, err)
+
+			sd := &export.SpanData{Name: "span", Attributes: test.in}
+			rsp.OnStart(sd)
+			rsp.OnEnd(sd)
+
+			require.Len(t, next.spansStarted, 1)
+			require.Len(t, next.spansEnded, 1)
+			assert.Equal(t, test.want, next.spansStarted[0].Attributes)
+			assert.Equal(t, test.want, next.spansEnded[0].Attributes)
+		})
+	}
+}
+
+func TestRedactingSpanProcessorEventsAndLinks(t *testing.T) {
+	next := NewTestSpanProcessor()
+	rsp, err := sdktrace.NewRedactingSpanProcessor(next, sdktrace.RedactKeys(sdktrace.RedactMask, "secret"))
+	require.NoError(t, err)
+
+	secret := kv.String("secret", "value")
+	sd := &export.SpanData{
+		Name:          "span",
+		Attributes:    []kv.KeyValue{secret},
+		MessageEvents: []export.Event{{Name: "event", Attributes: []kv.KeyValue{secret}}},
+		Links:         []apitrace.Link{{SpanContext: apitrace.SpanContext{TraceID: apitrace.ID{1}}, Attributes: []kv.KeyValue{secret}}},
+	}
+	rsp.OnEnd(sd)
+	rsp.Shutdown()
+
+	require.Len(t, next.spansEnded, 1)
+	redacted := next.spansEnded[0]
+	masked := []kv.KeyValue{kv.String("secret", sdktrace.RedactedMask)}
+	assert.Equal(t, masked, redacted.Attributes)
+	assert.Equal(t, "event", redacted.MessageEvents[0].Name)
+	assert.Equal(t, masked, redacted.MessageEvents[0].Attributes)
+	assert.Equal(t, apitrace.ID{1}, redacted.Links[0].TraceID)
+	assert.Equal(t, masked, redacted.Links[0].Attributes)
+	assert.Equal(t, 1, next.shutdownCount)
+
+	// The span shared with other processors is not modified.
+	assert.Equal(t, []kv.KeyValue{secret}, sd.Attributes)
+	assert.Equal(t, []kv.KeyValue{secret}, sd.MessageEvents[0].Attributes)
+	assert.Equal(t, []kv.KeyValue{secret}, sd.Links[0].Attributes)
+}
@@ -0,0 +1,326 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reload applies configuration changes to a running
+// sdktrace.Provider.
+//
+// A Watcher updates the sampler and span limits of a Provider and
+// atomically replaces the span processors it manages.  Replaced
+// processors are shut down once every in-flight call to them has
+// returned, which lets batch processors drain their queue.  Updates are
+// applied through Apply, or read from a file by WatchFile.  Each applied
+// change is reported as an AuditEvent.
+//
+// The processors are not swapped with Provider.RegisterSpanProcessor and
+// Provider.UnregisterSpanProcessor.  Unregistering shuts a processor down
+// right away, while spans that started before may still call OnEnd on
+// it, and spans starting between the two calls would see an incomplete
+// set.  The Watcher instead registers a single processor dispatching to
+// the current set, which it replaces atomically and whose in-flight
+// calls it waits for before shutting the replaced processors down.
+package reload // import "github.com/Ch1f/otel/sdk/trace/reload"
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Ch1f/otel/api/global"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// DefaultPollInterval is the interval at which WatchFile checks the
+// watched file for changes.
+const DefaultPollInterval = 10 * time.Second
+
+// Update describes a change to apply to a Provider.
+type Update struct {
+	// Config is applied with Provider.ApplyConfig: zero and nil fields
+	// keep their current value.  The configuration is left untouched
+	// when Config is nil.
+	Config *sdktrace.Config
+
+	// Processors replaces the span processors managed by the Watcher
+	// when not nil.  An empty, non-nil slice removes all of them.
+	Processors []sdktrace.SpanProcessor
+}
+
+// AuditEvent describes an update handled by a Watcher.
+type AuditEvent struct {
+	// Time is when the update was applied.
+	Time time.Time
+	// Source identifies where the update came from, e.g. the path of
+	// the watched file.
+	Source string
+
+	// Sampler is the description of the new default sampler, or empty
+	// if the sampler was not changed.
+	Sampler string
+	// MaxAttributesPerSpan, MaxEventsPerSpan and MaxLinksPerSpan are
+	// the new span limits, zero when not changed.
+	MaxAttributesPerSpan int
+	MaxEventsPerSpan     int
+	MaxLinksPerSpan      int
+
+	// ProcessorsReplaced reports whether the span processors were
+	// replaced, Processors is then the number of new processors.
+	ProcessorsReplaced bool
+	Processors         int
+
+	// Err is the error that prevented an update read by WatchFile
+	// from being applied.  Nothing was changed when Err is not nil.
+	Err error
+}
+
+// Option configures a Watcher.
+type Option func(*config)
+
+type config struct {
+	audit        func(AuditEvent)
+	pollInterval time.Duration
+}
+
+// WithAuditHandler sets the function called with an AuditEvent for
+// every update.  It is called synchronously and should not block.
+func WithAuditHandler(h func(AuditEvent)) Option {
+	return func(c *config) {
+		c.audit = h
+	}
+}
+
+// WithPollInterval sets the interval at which WatchFile checks the
+// watched file for changes.
+func WithPollInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = d
+	}
+}
+
+// Watcher applies configuration updates to a Provider.
+type Watcher struct {
+	provider   *sdktrace.Provider
+	processors *processorSet
+	cfg        config
+
+	// mu serializes updates.
+	mu sync.Mutex
+}
+
+// New returns a Watcher updating p.  The Watcher registers a span
+// processor with p that dispatches to the processors set by updates.
+func New(p *sdktrace.Provider, opts ...Option) *Watcher {
+	cfg := config{
+		audit:        func(AuditEvent) {},
+		pollInterval: DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	w := &Watcher{
+		provider:   p,
+		processors: &processorSet{},
+		cfg:        cfg,
+	}
+	p.RegisterSpanProcessor(w.processors)
+	return w
+}
+
+// Apply applies u to the Provider.  Replaced span processors are shut
+// down before Apply returns.
+func (w *Watcher) Apply(source string, u Update) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ev := AuditEvent{Time: time.Now(), Source: source}
+	if c := u.Config; c != nil {
+		w.provider.ApplyConfig(*c)
+		if c.DefaultSampler != nil {
+			ev.Sampler = c.DefaultSampler.Description()
+		}
+		ev.MaxAttributesPerSpan = c.MaxAttributesPerSpan
+		ev.MaxEventsPerSpan = c.MaxEventsPerSpan
+		ev.MaxLinksPerSpan = c.MaxLinksPerSpan
+	}
+	if u.Processors != nil {
+		w.processors.replace(u.Processors)
+		ev.ProcessorsReplaced = true
+		ev.Processors = len(u.Processors)
+	}
+	w.cfg.audit(ev)
+}
+
+// WatchFile applies the update decoded from the file at path, then
+// checks the file for changes at the poll interval and applies every
+// new content.  It blocks until ctx is done.
+//
+// An error is returned if the file cannot be read or decoded initially.
+// Later failures leave the current configuration in place; they are
+// passed to the global error handler and reported as an AuditEvent.
+func (w *Watcher) WatchFile(ctx context.Context, path string, decode func([]byte) (Update, error)) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	u, err := decode(data)
+	if err != nil {
+		return err
+	}
+	w.Apply(path, u)
+
+	ticker := time.NewTicker(w.cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		next, err := ioutil.ReadFile(path)
+		if err == nil && bytes.Equal(next, data) {
+			continue
+		}
+		if err == nil {
+			data = next
+			if u, err = decode(data); err == nil {
+				w.Apply(path, u)
+				continue
+			}
+		} else if os.IsNotExist(err) {
+			// The file may be replaced by a rename, retry at
+			// the next tick.
+			continue
+		}
+		global.Handle(err)
+		w.cfg.audit(AuditEvent{Time: time.Now(), Source: path, Err: err})
+	}
+}
+
+// Close unregisters the Watcher from the Provider and shuts down the
+// span processors it manages.
+func (w *Watcher) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.provider.UnregisterSpanProcessor(w.processors)
+}
+
+// processorSet is a SpanProcessor dispatching to a replaceable set of
+// processors.
+type processorSet struct {
+	// mu guards processors.  Calls to the processors hold a read
+	// lock, so that replaced processors are only shut down once no
+	// call to them is in flight.
+	mu         sync.RWMutex
+	processors []sdktrace.SpanProcessor
+}
+
+var _ sdktrace.SpanProcessor = (*processorSet)(nil)
+
+func (s *processorSet) OnStart(sd *export.SpanData) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.processors {
+		p.OnStart(sd)
+	}
+}
+
+func (s *processorSet) OnEnd(sd *export.SpanData) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.processors {
+		p.OnEnd(sd)
+	}
+}
+
+func (s *processorSet) Shutdown() {
+	s.replace(nil)
+}
+
+// replace installs processors and shuts down the previous ones, except
+// those that are part of the new set.
+func (s *processorSet) replace(processors []sdktrace.SpanProcessor) {
+	s.mu.Lock()
+	old := s.processors
+	s.processors = append([]sdktrace.SpanProcessor(nil), processors...)
+	s.mu.Unlock()
+
+	for _, p := range old {
+		if !containsProcessor(processors, p) {
+			p.Shutdown()
+		}
+	}
+}
+
+// containsProcessor returns true if processors holds p.  Unlike ==,
+// the comparison does not panic on processors of types that are not
+// comparable, such as structs holding a slice.
+func containsProcessor(processors []sdktrace.SpanProcessor, p sdktrace.SpanProcessor) bool {
+	for _, q := range processors {
+		if identical(reflect.ValueOf(q), reflect.ValueOf(p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// identical returns true if a and b are the same value: pointers,
+// slices, maps and functions refer to the same data, and the elements
+// of structs and arrays are identical.
+func identical(a, b reflect.Value) bool {
+	if a.Type() != b.Type() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Ptr, reflect.UnsafePointer, reflect.Chan, reflect.Map, reflect.Func:
+		return a.Pointer() == b.Pointer()
+	case reflect.Slice:
+		return a.Pointer() == b.Pointer() && a.Len() == b.Len()
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return identical(a.Elem(), b.Elem())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !identical(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !identical(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	}
+	return false
+}
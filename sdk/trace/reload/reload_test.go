@@ -0,0 +1,279 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reload_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+	"github.com/Ch1f/otel/sdk/trace/reload"
+)
+
+type testProcessor struct {
+	mu       sync.Mutex
+	ended    []*export.SpanData
+	shutdown bool
+}
+
+func (p *testProcessor) OnStart(*export.SpanData) {}
+
+func (p *testProcessor) OnEnd(sd *export.SpanData) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.shutdown {
+		panic("OnEnd called after Shutdown")
+	}
+	p.ended = append(p.ended, sd)
+}
+
+func (p *testProcessor) Shutdown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shutdown = true
+}
+
+func (p *testProcessor) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.ended)
+}
+
+func (p *testProcessor) isShutdown() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.shutdown
+}
+
+func startEnd(tp *sdktrace.Provider, attrs ...kv.KeyValue) {
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attrs...)
+	span.End()
+}
+
+func TestApply(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+
+	var events []reload.AuditEvent
+	w := reload.New(tp, reload.WithAuditHandler(func(ev reload.AuditEvent) {
+		events = append(events, ev)
+	}))
+
+	first := &testProcessor{}
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{first}})
+	startEnd(tp)
+	assert.Equal(t, 1, first.count())
+
+	second := &testProcessor{}
+	w.Apply("api", reload.Update{
+		Config: &sdktrace.Config{
+			DefaultSampler:       sdktrace.AlwaysSample(),
+			MaxAttributesPerSpan: 1,
+		},
+		Processors: []sdktrace.SpanProcessor{second},
+	})
+	assert.True(t, first.isShutdown())
+	startEnd(tp, kv.String("a", "1"), kv.String("b", "2"))
+	assert.Equal(t, 1, first.count())
+	require.Equal(t, 1, second.count())
+	assert.Len(t, second.ended[0].Attributes, 1)
+
+	w.Apply("api", reload.Update{Config: &sdktrace.Config{DefaultSampler: sdktrace.NeverSample()}})
+	startEnd(tp)
+	assert.Equal(t, 1, second.count())
+	assert.False(t, second.isShutdown())
+
+	w.Close()
+	assert.True(t, second.isShutdown())
+
+	require.Len(t, events, 3)
+	assert.Equal(t, "api", events[0].Source)
+	assert.True(t, events[0].ProcessorsReplaced)
+	assert.Equal(t, 1, events[0].Processors)
+	assert.Equal(t, "", events[0].Sampler)
+	assert.Equal(t, sdktrace.AlwaysSample().Description(), events[1].Sampler)
+	assert.Equal(t, 1, events[1].MaxAttributesPerSpan)
+	assert.False(t, events[2].ProcessorsReplaced)
+	assert.Equal(t, sdktrace.NeverSample().Description(), events[2].Sampler)
+}
+
+func TestApplyKeepsSharedProcessors(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	w := reload.New(tp)
+	defer w.Close()
+
+	shared, removed := &testProcessor{}, &testProcessor{}
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{shared, removed}})
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{shared}})
+
+	assert.False(t, shared.isShutdown())
+	assert.True(t, removed.isShutdown())
+}
+
+// valueProcessor is a span processor of a type that is not comparable.
+type valueProcessor struct {
+	name       string
+	processors []*testProcessor
+}
+
+func (p valueProcessor) OnStart(*export.SpanData) {}
+
+func (p valueProcessor) OnEnd(sd *export.SpanData) {
+	for _, q := range p.processors {
+		q.OnEnd(sd)
+	}
+}
+
+func (p valueProcessor) Shutdown() {
+	for _, q := range p.processors {
+		q.Shutdown()
+	}
+}
+
+func TestApplyNonComparableProcessors(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	w := reload.New(tp)
+	defer w.Close()
+
+	shared := valueProcessor{name: "shared", processors: []*testProcessor{{}}}
+	removed := valueProcessor{name: "removed", processors: []*testProcessor{{}}}
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{shared, removed}})
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{shared}})
+
+	assert.False(t, shared.processors[0].isShutdown())
+	assert.True(t, removed.processors[0].isShutdown())
+}
+
+func TestApplyConcurrent(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	w := reload.New(tp)
+	defer w.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				startEnd(tp)
+			}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		// testProcessor panics if called after Shutdown.
+		w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{&testProcessor{}}})
+	}
+	cancel()
+	wg.Wait()
+}
+
+type fileConfig struct {
+	Sampler       string `json:"sampler"`
+	MaxAttributes int    `json:"max_attributes"`
+}
+
+func decodeFileConfig(data []byte) (reload.Update, error) {
+	var fc fileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return reload.Update{}, err
+	}
+	cfg := &sdktrace.Config{MaxAttributesPerSpan: fc.MaxAttributes}
+	if fc.Sampler == "never" {
+		cfg.DefaultSampler = sdktrace.NeverSample()
+	} else {
+		cfg.DefaultSampler = sdktrace.AlwaysSample()
+	}
+	return reload.Update{Config: cfg}, nil
+}
+
+func TestWatchFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trace.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"sampler": "always"}`), 0644))
+
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	events := make(chan reload.AuditEvent, 10)
+	w := reload.New(tp,
+		reload.WithPollInterval(5*time.Millisecond),
+		reload.WithAuditHandler(func(ev reload.AuditEvent) { events <- ev }),
+	)
+	defer w.Close()
+	p := &testProcessor{}
+	w.Apply("api", reload.Update{Processors: []sdktrace.SpanProcessor{p}})
+	<-events
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.WatchFile(ctx, path, decodeFileConfig) }()
+
+	next := func() reload.AuditEvent {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("no audit event")
+		}
+		return reload.AuditEvent{}
+	}
+
+	ev := next()
+	assert.Equal(t, path, ev.Source)
+	assert.Equal(t, sdktrace.AlwaysSample().Description(), ev.Sampler)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"sampler": "never", "max_attributes": 4}`), 0644))
+	ev = next()
+	assert.Equal(t, sdktrace.NeverSample().Description(), ev.Sampler)
+	assert.Equal(t, 4, ev.MaxAttributesPerSpan)
+	startEnd(tp)
+	assert.Equal(t, 0, p.count())
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{not json`), 0644))
+	ev = next()
+	assert.Error(t, ev.Err)
+	startEnd(tp)
+	assert.Equal(t, 0, p.count(), "invalid update must not change the configuration")
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestWatchFileInitialError(t *testing.T) {
+	tp, err := sdktrace.NewProvider()
+	require.NoError(t, err)
+	w := reload.New(tp)
+	defer w.Close()
+
+	err = w.WatchFile(context.Background(), filepath.Join(os.TempDir(), "does-not-exist.json"), decodeFileConfig)
+	assert.Error(t, err)
+}
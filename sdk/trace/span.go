@@ -19,6 +19,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,15 +29,20 @@ import (
 	"github.com/Ch1f/otel/api/global"
 	"github.com/Ch1f/otel/api/kv"
 	"github.com/Ch1f/otel/api/kv/value"
+	"github.com/Ch1f/otel/api/standard"
 	apitrace "github.com/Ch1f/otel/api/trace"
 	export "github.com/Ch1f/otel/sdk/export/trace"
 	"github.com/Ch1f/otel/sdk/internal"
 )
 
 const (
-	errorTypeKey    = kv.Key("error.type")
-	errorMessageKey = kv.Key("error.message")
+	errorTypeKey    = standard.ErrorTypeKey
+	errorMessageKey = standard.ErrorMessageKey
 	errorEventName  = "error"
+
+	// maxStackFrames is the maximum number of frames of a stack trace
+	// captured at the caller of RecordError.
+	maxStackFrames = 64
 )
 
 // span implements apitrace.Span interface.
@@ -165,17 +172,98 @@ func (s *span) RecordError(ctx context.Context, err error, opts ...apitrace.Erro
 		s.SetStatus(cfg.StatusCode, "")
 	}
 
+	attrs := []kv.KeyValue{
+		errorTypeKey.String(errorType(err)),
+		errorMessageKey.String(err.Error()),
+	}
+	if cfg.Causes {
+		if causes := errorCauses(err); causes != "" {
+			attrs = append(attrs, standard.ErrorCausesKey.String(causes))
+		}
+	}
+	if cfg.StackTrace {
+		stack := errorStackTrace(err)
+		if stack == "" {
+			// Skip runtime.Callers, callerStackTrace and RecordError.
+			stack = callerStackTrace(3)
+		}
+		attrs = append(attrs, standard.ExceptionStacktraceKey.String(stack))
+	}
+
+	s.AddEventWithTimestamp(ctx, cfg.Timestamp, errorEventName, attrs...)
+}
+
+// errorType returns the package qualified name of the type of err.
+func errorType(err error) string {
 	errType := reflect.TypeOf(err)
 	errTypeString := fmt.Sprintf("%s.%s", errType.PkgPath(), errType.Name())
 	if errTypeString == "." {
 		// PkgPath() and Name() may be empty for builtin Types
 		errTypeString = errType.String()
 	}
+	return errTypeString
+}
 
-	s.AddEventWithTimestamp(ctx, cfg.Timestamp, errorEventName,
-		errorTypeKey.String(errTypeString),
-		errorMessageKey.String(err.Error()),
-	)
+// errorCauses returns the type and message of each error wrapped by
+// err, one per line, outermost first. A wrapped error whose methods
+// panic, such as a typed nil error, ends the chain.
+func errorCauses(err error) (causes string) {
+	var b strings.Builder
+	defer func() {
+		_ = recover()
+		causes = b.String()
+	}()
+	for cause := errors.Unwrap(err); cause != nil; cause = errors.Unwrap(cause) {
+		msg := cause.Error()
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(errorType(cause))
+		b.WriteString(": ")
+		b.WriteString(msg)
+	}
+	return b.String()
+}
+
+// stackTracer is implemented by errors that record the stack trace
+// where they were created.
+type stackTracer interface {
+	StackTrace() string
+}
+
+// errorStackTrace returns the stack trace of the innermost error of the
+// chain of err implementing stackTracer, or an empty string if there is
+// none. An error whose methods panic, such as a typed nil error, ends
+// the chain.
+func errorStackTrace(err error) (stack string) {
+	defer func() { _ = recover() }()
+	for ; err != nil; err = errors.Unwrap(err) {
+		if st, ok := err.(stackTracer); ok {
+			if s := strings.TrimLeft(st.StackTrace(), "\n"); s != "" {
+				stack = s
+			}
+		}
+	}
+	return stack
+}
+
+// callerStackTrace returns the stack trace of the calling goroutine,
+// skipping the given number of frames, with the function and the file
+// and line of each frame.
+func callerStackTrace(skip int) string {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return b.String()
 }
 
 func (s *span) Tracer() apitrace.Tracer {
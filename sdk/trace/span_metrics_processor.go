@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/label"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/api/unit"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+const (
+	// SpanMetricsCalls is the name of the counter of the spans ended.
+	SpanMetricsCalls = "span.calls"
+	// SpanMetricsDuration is the name of the value recorder of the
+	// duration of the spans, in milliseconds.
+	SpanMetricsDuration = "span.duration"
+
+	// SpanMetricsOverflow replaces the span name of the label sets
+	// exceeding the maximum number of label sets.
+	SpanMetricsOverflow = "_overflow"
+
+	DefaultMaxLabelSets = 1000
+)
+
+const (
+	// SpanNameKey is the label key of the span name.
+	SpanNameKey = kv.Key("span.name")
+	// SpanKindKey is the label key of the span kind.
+	SpanKindKey = kv.Key("span.kind")
+	// SpanStatusCodeKey is the label key of the span status code.
+	SpanStatusCodeKey = kv.Key("status.code")
+)
+
+var (
+	errNilMeter = errors.New("meter is nil")
+)
+
+type SpanMetricsProcessorOption func(o *SpanMetricsProcessorOptions)
+
+type SpanMetricsProcessorOptions struct {
+	// Kinds are the kinds of the spans measured.
+	// The default value of Kinds is SpanKindServer and SpanKindClient.
+	Kinds []apitrace.SpanKind
+
+	// Attributes are the keys of the span attributes added to the
+	// labels of the measurements, when the span has them.
+	Attributes []kv.Key
+
+	// MaxLabelSets is the maximum number of distinct label sets. The
+	// measurements of further label sets are recorded with the span
+	// name SpanMetricsOverflow and without attribute labels.
+	// The default value of MaxLabelSets is 1000.
+	MaxLabelSets int
+}
+
+// SpanMetricsProcessor implements SpanProcessor interfaces. It
+// records the request rate, error rate and duration (RED) metrics of
+// the ended spans: a count and a duration labeled with the span name,
+// kind and status code, and a set of span attributes.
+//
+// Only the spans recorded by the Provider are measured, so the
+// metrics are subject to its sampler.
+type SpanMetricsProcessor struct {
+	meter metric.Meter
+	o     SpanMetricsProcessorOptions
+
+	calls    metric.Int64Counter
+	duration metric.Float64ValueRecorder
+
+	lock      sync.Mutex
+	labelSets map[label.Distinct]struct{}
+}
+
+var _ SpanProcessor = (*SpanMetricsProcessor)(nil)
+
+// NewSpanMetricsProcessor creates a new instance of
+// SpanMetricsProcessor recording its metrics with meter. It returns an
+// error if meter has no implementation or the instruments cannot be
+// created.
+func NewSpanMetricsProcessor(meter metric.Meter, opts ...SpanMetricsProcessorOption) (*SpanMetricsProcessor, error) {
+	if meter.MeterImpl() == nil {
+		return nil, errNilMeter
+	}
+
+	o := SpanMetricsProcessorOptions{
+		Kinds:        []apitrace.SpanKind{apitrace.SpanKindServer, apitrace.SpanKindClient},
+		MaxLabelSets: DefaultMaxLabelSets,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	calls, err := meter.NewInt64Counter(SpanMetricsCalls,
+		metric.WithDescription("Number of spans ended"))
+	if err != nil {
+		return nil, err
+	}
+	duration, err := meter.NewFloat64ValueRecorder(SpanMetricsDuration,
+		metric.WithDescription("Duration of the spans"),
+		metric.WithUnit(unit.Milliseconds))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpanMetricsProcessor{
+		meter:     meter,
+		o:         o,
+		calls:     calls,
+		duration:  duration,
+		labelSets: make(map[label.Distinct]struct{}),
+	}, nil
+}
+
+func WithSpanMetricsKinds(kinds ...apitrace.SpanKind) SpanMetricsProcessorOption {
+	return func(o *SpanMetricsProcessorOptions) {
+		o.Kinds = kinds
+	}
+}
+
+func WithSpanMetricsAttributes(keys ...kv.Key) SpanMetricsProcessorOption {
+	return func(o *SpanMetricsProcessorOptions) {
+		o.Attributes = append(o.Attributes, keys...)
+	}
+}
+
+func WithMaxLabelSets(size int) SpanMetricsProcessorOption {
+	return func(o *SpanMetricsProcessorOptions) {
+		o.MaxLabelSets = size
+	}
+}
+
+// OnStart method does nothing.
+func (smp *SpanMetricsProcessor) OnStart(sd *export.SpanData) {
+}
+
+// OnEnd method records the metrics of the span.
+func (smp *SpanMetricsProcessor) OnEnd(sd *export.SpanData) {
+	if !smp.measures(sd.SpanKind) {
+		return
+	}
+
+	duration := float64(sd.EndTime.Sub(sd.StartTime)) / float64(time.Millisecond)
+	smp.meter.RecordBatch(context.Background(), smp.labels(sd),
+		smp.calls.Measurement(1),
+		smp.duration.Measurement(duration),
+	)
+}
+
+// Shutdown method does nothing.
+func (smp *SpanMetricsProcessor) Shutdown() {
+}
+
+func (smp *SpanMetricsProcessor) measures(kind apitrace.SpanKind) bool {
+	for _, k := range smp.o.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// labels returns the labels of the measurements of the span, guarding
+// against the creation of more than MaxLabelSets label sets.
+func (smp *SpanMetricsProcessor) labels(sd *export.SpanData) []kv.KeyValue {
+	labels := []kv.KeyValue{
+		SpanNameKey.String(sd.Name),
+		SpanKindKey.String(sd.SpanKind.String()),
+		SpanStatusCodeKey.String(sd.StatusCode.String()),
+	}
+	for _, key := range smp.o.Attributes {
+		for _, attr := range sd.Attributes {
+			if attr.Key == key {
+				labels = append(labels, attr)
+				break
+			}
+		}
+	}
+
+	set := label.NewSet(labels...)
+	smp.lock.Lock()
+	defer smp.lock.Unlock()
+	if _, ok := smp.labelSets[set.Equivalent()]; ok {
+		return labels
+	}
+	if len(smp.labelSets) < smp.o.MaxLabelSets {
+		smp.labelSets[set.Equivalent()] = struct{}{}
+		return labels
+	}
+	return []kv.KeyValue{
+		SpanNameKey.String(SpanMetricsOverflow),
+		SpanKindKey.String(sd.SpanKind.String()),
+		SpanStatusCodeKey.String(sd.StatusCode.String()),
+	}
+}
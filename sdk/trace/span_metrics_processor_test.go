@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	exportmetric "github.com/Ch1f/otel/sdk/export/metric"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	"github.com/Ch1f/otel/sdk/metric/metrictest"
+	"github.com/Ch1f/otel/sdk/metric/selector/simple"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+func newSpanMetricsProcessor(t *testing.T, opts ...sdktrace.SpanMetricsProcessorOption) (*sdktrace.SpanMetricsProcessor, *metrictest.Controller, *metrictest.Exporter) {
+	exp := metrictest.NewExporter(exportmetric.DeltaExporter)
+	cont := metrictest.NewController(simple.NewWithHistogramDistribution([]float64{10, 100}), exp)
+	smp, err := sdktrace.NewSpanMetricsProcessor(cont.Provider().Meter("spanmetrics"), opts...)
+	require.NoError(t, err)
+	return smp, cont, exp
+}
+
+func metricsSpan(name string, kind apitrace.SpanKind, code codes.Code, duration time.Duration, attrs ...kv.KeyValue) *export.SpanData {
+	return &export.SpanData{
+		Name:       name,
+		SpanKind:   kind,
+		StatusCode: code,
+		StartTime:  tailStart,
+		EndTime:    tailStart.Add(duration),
+		Attributes: attrs,
+	}
+}
+
+func TestNewSpanMetricsProcessorWithoutMeter(t *testing.T) {
+	_, err := sdktrace.NewSpanMetricsProcessor(metric.Meter{})
+	assert.Error(t, err)
+}
+
+func TestSpanMetricsProcessor(t *testing.T) {
+	smp, cont, exp := newSpanMetricsProcessor(t, sdktrace.WithSpanMetricsAttributes("http.method"))
+
+	smp.OnEnd(metricsSpan("/users", apitrace.SpanKindServer, codes.OK, 5*time.Millisecond, kv.String("http.method", "GET"), kv.String("user", "a")))
+	smp.OnEnd(metricsSpan("/users", apitrace.SpanKindServer, codes.OK, 50*time.Millisecond, kv.String("http.method", "GET"), kv.String("user", "b")))
+	smp.OnEnd(metricsSpan("/users", apitrace.SpanKindServer, codes.Internal, 500*time.Millisecond, kv.String("http.method", "GET")))
+	smp.OnEnd(metricsSpan("db.query", apitrace.SpanKindClient, codes.OK, time.Millisecond))
+	// Internal spans are not measured by default.
+	smp.OnEnd(metricsSpan("cache", apitrace.SpanKindInternal, codes.OK, time.Millisecond))
+	smp.Shutdown()
+	require.NoError(t, cont.Collect(context.Background()))
+
+	metrictest.AssertRecords(t, []string{
+		"span.calls{http.method=GET,span.kind=server,span.name=/users,status.code=Internal}: sum=1",
+		"span.calls{http.method=GET,span.kind=server,span.name=/users,status.code=OK}: sum=2",
+		"span.calls{span.kind=client,span.name=db.query,status.code=OK}: sum=1",
+		"span.duration{http.method=GET,span.kind=server,span.name=/users,status.code=Internal}: count=1 sum=500 buckets=[10 100]|[0 0 1]",
+		"span.duration{http.method=GET,span.kind=server,span.name=/users,status.code=OK}: count=2 sum=55 buckets=[10 100]|[1 1 0]",
+		"span.duration{span.kind=client,span.name=db.query,status.code=OK}: count=1 sum=1 buckets=[10 100]|[1 0 0]",
+	}, exp.Records())
+}
+
+func TestSpanMetricsProcessorKinds(t *testing.T) {
+	smp, cont, exp := newSpanMetricsProcessor(t, sdktrace.WithSpanMetricsKinds(apitrace.SpanKindInternal))
+
+	smp.OnEnd(metricsSpan("cache", apitrace.SpanKindInternal, codes.OK, time.Millisecond))
+	smp.OnEnd(metricsSpan("/users", apitrace.SpanKindServer, codes.OK, time.Millisecond))
+	require.NoError(t, cont.Collect(context.Background()))
+
+	assert.Len(t, exp.Records().ByName(sdktrace.SpanMetricsCalls), 1)
+	_, ok := exp.Get(sdktrace.SpanMetricsCalls,
+		sdktrace.SpanNameKey.String("cache"),
+		sdktrace.SpanKindKey.String("internal"),
+		sdktrace.SpanStatusCodeKey.String("OK"),
+	)
+	assert.True(t, ok)
+}
+
+func TestSpanMetricsProcessorMaxLabelSets(t *testing.T) {
+	smp, cont, exp := newSpanMetricsProcessor(t,
+		sdktrace.WithMaxLabelSets(2),
+		sdktrace.WithSpanMetricsAttributes("user"),
+	)
+
+	for _, user := range []string{"a", "b", "a", "c", "d"} {
+		smp.OnEnd(metricsSpan("/users", apitrace.SpanKindServer, codes.OK, time.Millisecond, kv.String("user", user)))
+	}
+	require.NoError(t, cont.Collect(context.Background()))
+
+	metrictest.AssertRecords(t, []string{
+		"span.calls{span.kind=server,span.name=/users,status.code=OK,user=a}: sum=2",
+		"span.calls{span.kind=server,span.name=/users,status.code=OK,user=b}: sum=1",
+		"span.calls{span.kind=server,span.name=_overflow,status.code=OK}: sum=2",
+	}, exp.Records().ByName(sdktrace.SpanMetricsCalls))
+}
+
+func TestSpanMetricsProcessorWithProvider(t *testing.T) {
+	smp, cont, exp := newSpanMetricsProcessor(t)
+	tp := basicProvider(t)
+	tp.RegisterSpanProcessor(smp)
+	defer tp.UnregisterSpanProcessor(smp)
+
+	_, span := tp.Tracer("spanmetrics").Start(context.Background(), "request", apitrace.WithSpanKind(apitrace.SpanKindServer))
+	span.SetStatus(codes.NotFound, "missing")
+	span.End()
+	require.NoError(t, cont.Collect(context.Background()))
+
+	r, ok := exp.Get(sdktrace.SpanMetricsCalls,
+		sdktrace.SpanNameKey.String("request"),
+		sdktrace.SpanKindKey.String("server"),
+		sdktrace.SpanStatusCodeKey.String("NotFound"),
+	)
+	require.True(t, ok)
+	assert.Equal(t, float64(1), r.Sum)
+}
@@ -0,0 +1,481 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"container/list"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/global"
+	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/metric"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+const (
+	DefaultDecisionWait     = 10 * time.Second
+	DefaultMaxTraces        = 10000
+	DefaultMaxSpansPerTrace = 1000
+)
+
+const (
+	// TailSamplingDecisions is the name of the counter of the traces
+	// decided by a TailSamplingSpanProcessor.
+	TailSamplingDecisions = "sdk.trace.tail_sampling.decisions"
+	// TailSamplingSpansDropped is the name of the counter of the spans
+	// dropped by a TailSamplingSpanProcessor because their trace had
+	// too many spans or they ended after the processor was shut down.
+	TailSamplingSpansDropped = "sdk.trace.tail_sampling.spans_dropped"
+
+	// TailSamplingDecisionKey is the label key of the decision of the
+	// TailSamplingDecisions counter: "sampled", "dropped" or
+	// "evicted".
+	TailSamplingDecisionKey = kv.Key("decision")
+	// TailSamplingPolicyKey is the label key of the description of the
+	// policy that sampled a trace.
+	TailSamplingPolicyKey = kv.Key("policy")
+)
+
+var (
+	errNilProcessor = errors.New("span processor is nil")
+)
+
+// TailSamplingPolicy decides whether a trace is sampled once its spans
+// have been buffered by a TailSamplingSpanProcessor.
+type TailSamplingPolicy interface {
+	// ShouldSample returns whether the trace made of the ended spans
+	// should be sampled.
+	ShouldSample(spans []*export.SpanData) bool
+	// Description returns information describing the policy.
+	Description() string
+}
+
+type errorStatusPolicy struct{}
+
+func (errorStatusPolicy) ShouldSample(spans []*export.SpanData) bool {
+	for _, sd := range spans {
+		if sd.StatusCode != codes.OK {
+			return true
+		}
+	}
+	return false
+}
+
+func (errorStatusPolicy) Description() string {
+	return "ErrorStatus"
+}
+
+// ErrorStatusPolicy returns a TailSamplingPolicy that samples traces
+// with a span whose status is not OK.
+func ErrorStatusPolicy() TailSamplingPolicy {
+	return errorStatusPolicy{}
+}
+
+type latencyPolicy struct {
+	threshold time.Duration
+}
+
+func (lp latencyPolicy) ShouldSample(spans []*export.SpanData) bool {
+	var start, end time.Time
+	for _, sd := range spans {
+		if start.IsZero() || sd.StartTime.Before(start) {
+			start = sd.StartTime
+		}
+		if sd.EndTime.After(end) {
+			end = sd.EndTime
+		}
+	}
+	return end.Sub(start) >= lp.threshold
+}
+
+func (lp latencyPolicy) Description() string {
+	return fmt.Sprintf("Latency{%s}", lp.threshold)
+}
+
+// LatencyPolicy returns a TailSamplingPolicy that samples traces
+// lasting at least threshold, from the earliest start to the latest
+// end of their buffered spans.
+func LatencyPolicy(threshold time.Duration) TailSamplingPolicy {
+	return latencyPolicy{threshold: threshold}
+}
+
+type attributePolicy struct {
+	attr kv.KeyValue
+}
+
+func (ap attributePolicy) ShouldSample(spans []*export.SpanData) bool {
+	for _, sd := range spans {
+		for _, attr := range sd.Attributes {
+			if attr == ap.attr {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ap attributePolicy) Description() string {
+	return fmt.Sprintf("Attribute{%s=%s}", ap.attr.Key, ap.attr.Value.Emit())
+}
+
+// AttributePolicy returns a TailSamplingPolicy that samples traces
+// with a span having the attribute.
+func AttributePolicy(attr kv.KeyValue) TailSamplingPolicy {
+	return attributePolicy{attr: attr}
+}
+
+type probabilityPolicy struct {
+	traceIDUpperBound uint64
+	description       string
+}
+
+func (pp probabilityPolicy) ShouldSample(spans []*export.SpanData) bool {
+	if len(spans) == 0 {
+		return false
+	}
+	id := spans[0].SpanContext.TraceID
+	x := binary.BigEndian.Uint64(id[0:8]) >> 1
+	return x < pp.traceIDUpperBound
+}
+
+func (pp probabilityPolicy) Description() string {
+	return pp.description
+}
+
+// ProbabilityPolicy returns a TailSamplingPolicy that samples a given
+// fraction of traces, based on their trace ID like ProbabilitySampler.
+// It is meant to be the last policy, as a fallback.
+func ProbabilityPolicy(fraction float64) TailSamplingPolicy {
+	if fraction >= 1 {
+		fraction = 1
+	} else if fraction <= 0 {
+		fraction = 0
+	}
+	return probabilityPolicy{
+		traceIDUpperBound: uint64(fraction * (1 << 63)),
+		description:       fmt.Sprintf("Probability{%g}", fraction),
+	}
+}
+
+type TailSamplingSpanProcessorOption func(o *TailSamplingSpanProcessorOptions)
+
+type TailSamplingSpanProcessorOptions struct {
+	// DecisionWait is the duration to wait, after the first span of a
+	// trace ended, before deciding whether the trace is sampled.
+	// The default value of DecisionWait is 10 seconds.
+	DecisionWait time.Duration
+
+	// MaxTraces is the maximum number of traces waiting for a
+	// decision. When it is reached, the least recently updated trace
+	// is evicted and its spans are dropped.
+	// The default value of MaxTraces is 10000.
+	MaxTraces int
+
+	// MaxSpansPerTrace is the maximum number of spans buffered for a
+	// trace. Further spans of the trace are dropped.
+	// The default value of MaxSpansPerTrace is 1000.
+	MaxSpansPerTrace int
+
+	// Policies are evaluated in order when a trace is decided. The
+	// trace is sampled by the first policy that samples it, and
+	// dropped if none does.
+	Policies []TailSamplingPolicy
+
+	// Meter is used to record the decisions of the processor.
+	// The default is the global Meter of this package.
+	Meter metric.Meter
+}
+
+// TailSamplingSpanProcessor implements SpanProcessor interfaces. It
+// buffers the ended spans of each trace for a decision window, then
+// forwards the spans of the traces sampled by its policies to another
+// SpanProcessor.
+//
+// Spans that end after their trace was decided follow the decision.
+// The head sampler of the Provider should sample all the traces that
+// may be sampled by this processor, e.g. AlwaysSample.
+type TailSamplingSpanProcessor struct {
+	next SpanProcessor
+	o    TailSamplingSpanProcessorOptions
+
+	decisions    metric.Int64Counter
+	spansDropped metric.Int64Counter
+
+	lock    sync.Mutex
+	traces  map[apitrace.ID]*list.Element
+	lru     *list.List
+	decided map[apitrace.ID]bool
+	// decidedOrder holds the decided trace IDs, oldest first, to
+	// bound the size of decided.
+	decidedOrder []apitrace.ID
+	// stopped is set once Shutdown starts deciding the buffered traces.
+	stopped bool
+
+	stopWait sync.WaitGroup
+	stopOnce sync.Once
+	stopCh   chan struct{}
+}
+
+type tailTrace struct {
+	id        apitrace.ID
+	firstSeen time.Time
+	spans     []*export.SpanData
+}
+
+var _ SpanProcessor = (*TailSamplingSpanProcessor)(nil)
+
+// NewTailSamplingSpanProcessor creates a new instance of
+// TailSamplingSpanProcessor forwarding sampled traces to next. It
+// returns an error if next is nil.
+func NewTailSamplingSpanProcessor(next SpanProcessor, opts ...TailSamplingSpanProcessorOption) (*TailSamplingSpanProcessor, error) {
+	if next == nil {
+		return nil, errNilProcessor
+	}
+
+	o := TailSamplingSpanProcessorOptions{
+		DecisionWait:     DefaultDecisionWait,
+		MaxTraces:        DefaultMaxTraces,
+		MaxSpansPerTrace: DefaultMaxSpansPerTrace,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.Meter.MeterImpl() == nil {
+		o.Meter = global.Meter("github.com/Ch1f/otel/sdk/trace")
+	}
+
+	decisions, err := o.Meter.NewInt64Counter(TailSamplingDecisions,
+		metric.WithDescription("Number of traces decided by the tail sampling span processor"))
+	if err != nil {
+		return nil, err
+	}
+	spansDropped, err := o.Meter.NewInt64Counter(TailSamplingSpansDropped,
+		metric.WithDescription("Number of spans dropped by the tail sampling span processor because their trace had too many spans"))
+	if err != nil {
+		return nil, err
+	}
+
+	tsp := &TailSamplingSpanProcessor{
+		next:         next,
+		o:            o,
+		decisions:    decisions,
+		spansDropped: spansDropped,
+		traces:       make(map[apitrace.ID]*list.Element),
+		lru:          list.New(),
+		decided:      make(map[apitrace.ID]bool),
+		stopCh:       make(chan struct{}),
+	}
+
+	tsp.stopWait.Add(1)
+	go func() {
+		defer tsp.stopWait.Done()
+		tsp.processTraces()
+	}()
+
+	return tsp, nil
+}
+
+func WithDecisionWait(wait time.Duration) TailSamplingSpanProcessorOption {
+	return func(o *TailSamplingSpanProcessorOptions) {
+		o.DecisionWait = wait
+	}
+}
+
+func WithMaxTraces(size int) TailSamplingSpanProcessorOption {
+	return func(o *TailSamplingSpanProcessorOptions) {
+		o.MaxTraces = size
+	}
+}
+
+func WithMaxSpansPerTrace(size int) TailSamplingSpanProcessorOption {
+	return func(o *TailSamplingSpanProcessorOptions) {
+		o.MaxSpansPerTrace = size
+	}
+}
+
+func WithTailSamplingPolicies(policies ...TailSamplingPolicy) TailSamplingSpanProcessorOption {
+	return func(o *TailSamplingSpanProcessorOptions) {
+		o.Policies = append(o.Policies, policies...)
+	}
+}
+
+func WithTailSamplingMeter(meter metric.Meter) TailSamplingSpanProcessorOption {
+	return func(o *TailSamplingSpanProcessorOptions) {
+		o.Meter = meter
+	}
+}
+
+// OnStart method does nothing.
+func (tsp *TailSamplingSpanProcessor) OnStart(sd *export.SpanData) {
+}
+
+// OnEnd method buffers the span until its trace is decided, or
+// forwards it if its trace was already sampled. Spans ending after
+// Shutdown are dropped.
+func (tsp *TailSamplingSpanProcessor) OnEnd(sd *export.SpanData) {
+	id := sd.SpanContext.TraceID
+
+	tsp.lock.Lock()
+	if tsp.stopped {
+		tsp.lock.Unlock()
+		tsp.spansDropped.Add(context.Background(), 1)
+		return
+	}
+	if sampled, ok := tsp.decided[id]; ok {
+		tsp.lock.Unlock()
+		if sampled {
+			tsp.next.OnEnd(sd)
+		}
+		return
+	}
+
+	if elem, ok := tsp.traces[id]; ok {
+		trace := elem.Value.(*tailTrace)
+		tsp.lru.MoveToBack(elem)
+		if len(trace.spans) >= tsp.o.MaxSpansPerTrace {
+			tsp.lock.Unlock()
+			tsp.spansDropped.Add(context.Background(), 1)
+			return
+		}
+		trace.spans = append(trace.spans, sd)
+		tsp.lock.Unlock()
+		return
+	}
+
+	tsp.traces[id] = tsp.lru.PushBack(&tailTrace{
+		id:        id,
+		firstSeen: time.Now(),
+		spans:     []*export.SpanData{sd},
+	})
+	var evicted *tailTrace
+	if tsp.lru.Len() > tsp.o.MaxTraces {
+		evicted = tsp.remove(tsp.lru.Front())
+		tsp.markDecided(evicted.id, false)
+	}
+	tsp.lock.Unlock()
+
+	if evicted != nil {
+		tsp.decisions.Add(context.Background(), 1, TailSamplingDecisionKey.String("evicted"))
+	}
+}
+
+// Shutdown decides all the buffered traces, forwarding the sampled
+// ones, then shuts down the next processor. It only executes once.
+// Subsequent call does nothing.
+func (tsp *TailSamplingSpanProcessor) Shutdown() {
+	tsp.stopOnce.Do(func() {
+		close(tsp.stopCh)
+		tsp.stopWait.Wait()
+		tsp.lock.Lock()
+		tsp.stopped = true
+		tsp.lock.Unlock()
+		tsp.decide(time.Time{})
+		tsp.next.Shutdown()
+	})
+}
+
+// processTraces decides the traces waiting for longer than the
+// decision window until the processor is shut down.
+func (tsp *TailSamplingSpanProcessor) processTraces() {
+	period := tsp.o.DecisionWait / 10
+	if period < time.Millisecond {
+		period = time.Millisecond
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-tsp.stopCh:
+			return
+		case now := <-ticker.C:
+			tsp.decide(now.Add(-tsp.o.DecisionWait))
+		}
+	}
+}
+
+// decide decides the traces whose first span ended before the
+// deadline, or all the traces if the deadline is zero.
+func (tsp *TailSamplingSpanProcessor) decide(deadline time.Time) {
+	type decision struct {
+		trace  *tailTrace
+		policy TailSamplingPolicy
+	}
+	var decisions []decision
+
+	tsp.lock.Lock()
+	for _, elem := range tsp.traces {
+		trace := elem.Value.(*tailTrace)
+		if !deadline.IsZero() && !trace.firstSeen.Before(deadline) {
+			continue
+		}
+		tsp.remove(elem)
+		policy := tsp.evaluate(trace.spans)
+		tsp.markDecided(trace.id, policy != nil)
+		decisions = append(decisions, decision{trace: trace, policy: policy})
+	}
+	tsp.lock.Unlock()
+
+	for _, d := range decisions {
+		if d.policy == nil {
+			tsp.decisions.Add(context.Background(), 1, TailSamplingDecisionKey.String("dropped"))
+			continue
+		}
+		tsp.decisions.Add(context.Background(), 1,
+			TailSamplingDecisionKey.String("sampled"),
+			TailSamplingPolicyKey.String(d.policy.Description()),
+		)
+		for _, sd := range d.trace.spans {
+			tsp.next.OnEnd(sd)
+		}
+	}
+}
+
+// evaluate returns the first policy that samples the spans, or nil.
+func (tsp *TailSamplingSpanProcessor) evaluate(spans []*export.SpanData) TailSamplingPolicy {
+	for _, policy := range tsp.o.Policies {
+		if policy.ShouldSample(spans) {
+			return policy
+		}
+	}
+	return nil
+}
+
+// remove removes a buffered trace.  The lock must be held.
+func (tsp *TailSamplingSpanProcessor) remove(elem *list.Element) *tailTrace {
+	trace := tsp.lru.Remove(elem).(*tailTrace)
+	delete(tsp.traces, trace.id)
+	return trace
+}
+
+// markDecided remembers the decision of a trace for its late spans,
+// forgetting the oldest decisions beyond MaxTraces.  The lock must be
+// held.
+func (tsp *TailSamplingSpanProcessor) markDecided(id apitrace.ID, sampled bool) {
+	tsp.decided[id] = sampled
+	tsp.decidedOrder = append(tsp.decidedOrder, id)
+	if len(tsp.decidedOrder) > tsp.o.MaxTraces {
+		delete(tsp.decided, tsp.decidedOrder[0])
+		tsp.decidedOrder = tsp.decidedOrder[1:]
+	}
+}
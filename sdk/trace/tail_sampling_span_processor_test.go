@@ -0,0 +1,319 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	mockmeter "github.com/Ch1f/otel/internal/metric"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+	"github.com/Ch1f/otel/sdk/trace/tracetest"
+)
+
+var tailStart = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func tailSpan(traceID byte, name string, duration time.Duration) *export.SpanData {
+	return &export.SpanData{
+		SpanContext: apitrace.SpanContext{
+			TraceID:    apitrace.ID{traceID},
+			SpanID:     apitrace.SpanID{traceID, byte(len(name))},
+			TraceFlags: apitrace.FlagsSampled,
+		},
+		Name:      name,
+		StartTime: tailStart,
+		EndTime:   tailStart.Add(duration),
+	}
+}
+
+// tailDecisions returns the number of decisions recorded by the
+// processor, keyed by decision and policy.
+func tailDecisions(impl *mockmeter.MeterImpl) map[string]int64 {
+	decisions := map[string]int64{}
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			if m.Instrument.Descriptor().Name() != sdktrace.TailSamplingDecisions {
+				continue
+			}
+			var decision, policy string
+			for _, l := range batch.Labels {
+				switch l.Key {
+				case sdktrace.TailSamplingDecisionKey:
+					decision = l.Value.AsString()
+				case sdktrace.TailSamplingPolicyKey:
+					policy = l.Value.AsString()
+				}
+			}
+			decisions[decision+"/"+policy] += m.Number.AsInt64()
+		}
+	}
+	return decisions
+}
+
+func TestNewTailSamplingSpanProcessorWithNilProcessor(t *testing.T) {
+	_, err := sdktrace.NewTailSamplingSpanProcessor(nil)
+	assert.Error(t, err)
+}
+
+func TestTailSamplingPolicies(t *testing.T) {
+	errored := tailSpan(1, "errored", time.Millisecond)
+	errored.StatusCode = codes.Internal
+	slow := tailSpan(2, "slow", time.Second)
+	tagged := tailSpan(3, "tagged", time.Millisecond)
+	tagged.Attributes = []kv.KeyValue{kv.String("debug", "true")}
+	plain := tailSpan(4, "plain", time.Millisecond)
+
+	for _, test := range []struct {
+		name     string
+		policies []sdktrace.TailSamplingPolicy
+		sampled  []string
+	}{
+		{
+			name: "no policy",
+		},
+		{
+			name:     "error status",
+			policies: []sdktrace.TailSamplingPolicy{sdktrace.ErrorStatusPolicy()},
+			sampled:  []string{"errored"},
+		},
+		{
+			name:     "latency",
+			policies: []sdktrace.TailSamplingPolicy{sdktrace.LatencyPolicy(500 * time.Millisecond)},
+			sampled:  []string{"slow"},
+		},
+		{
+			name:     "attribute",
+			policies: []sdktrace.TailSamplingPolicy{sdktrace.AttributePolicy(kv.String("debug", "true"))},
+			sampled:  []string{"tagged"},
+		},
+		{
+			name:     "probability always",
+			policies: []sdktrace.TailSamplingPolicy{sdktrace.ProbabilityPolicy(1)},
+			sampled:  []string{"errored", "slow", "tagged", "plain"},
+		},
+		{
+			name:     "probability never",
+			policies: []sdktrace.TailSamplingPolicy{sdktrace.ProbabilityPolicy(0)},
+		},
+		{
+			name: "any policy",
+			policies: []sdktrace.TailSamplingPolicy{
+				sdktrace.ErrorStatusPolicy(),
+				sdktrace.LatencyPolicy(500 * time.Millisecond),
+			},
+			sampled: []string{"errored", "slow"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+				sdktrace.WithTailSamplingPolicies(test.policies...),
+			)
+			require.NoError(t, err)
+
+			for _, sd := range []*export.SpanData{errored, slow, tagged, plain} {
+				tsp.OnEnd(sd)
+			}
+			assert.Empty(t, sr.Ended())
+			tsp.Shutdown()
+
+			var sampled []string
+			for _, sd := range sr.Ended() {
+				sampled = append(sampled, sd.Name)
+			}
+			assert.ElementsMatch(t, test.sampled, sampled)
+		})
+	}
+}
+
+func TestTailSamplingLatencySpansTrace(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithTailSamplingPolicies(sdktrace.LatencyPolicy(time.Second)),
+	)
+	require.NoError(t, err)
+
+	// Neither span is slow, but the trace is.
+	first := tailSpan(1, "first", 600*time.Millisecond)
+	second := tailSpan(1, "second", 600*time.Millisecond)
+	second.StartTime = first.EndTime
+	second.EndTime = second.StartTime.Add(600 * time.Millisecond)
+	tsp.OnEnd(first)
+	tsp.OnEnd(second)
+	tsp.Shutdown()
+
+	assert.Len(t, sr.Ended(), 2)
+}
+
+func TestTailSamplingDecisionWait(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithDecisionWait(20*time.Millisecond),
+		sdktrace.WithTailSamplingPolicies(sdktrace.ErrorStatusPolicy()),
+		sdktrace.WithTailSamplingMeter(meter),
+	)
+	require.NoError(t, err)
+
+	root := tailSpan(1, "root", time.Millisecond)
+	child := tailSpan(1, "child", time.Millisecond)
+	child.StatusCode = codes.Unavailable
+	tsp.OnEnd(child)
+	tsp.OnEnd(tailSpan(2, "dropped", time.Millisecond))
+
+	require.Eventually(t, func() bool {
+		return len(sr.Ended()) == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, "child", sr.Ended()[0].Name)
+
+	// Spans ending after the decision follow it.
+	tsp.OnEnd(root)
+	tsp.OnEnd(tailSpan(2, "late", time.Millisecond))
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, "root", ended[1].Name)
+
+	tsp.Shutdown()
+	assert.Equal(t, map[string]int64{
+		"sampled/ErrorStatus": 1,
+		"dropped/":            1,
+	}, tailDecisions(impl))
+}
+
+func TestTailSamplingMaxTraces(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithMaxTraces(2),
+		sdktrace.WithTailSamplingPolicies(sdktrace.ProbabilityPolicy(1)),
+		sdktrace.WithTailSamplingMeter(meter),
+	)
+	require.NoError(t, err)
+
+	tsp.OnEnd(tailSpan(1, "a", time.Millisecond))
+	tsp.OnEnd(tailSpan(2, "b", time.Millisecond))
+	// Trace 1 becomes the most recently updated trace.
+	tsp.OnEnd(tailSpan(1, "aa", time.Millisecond))
+	// Trace 2 is evicted.
+	tsp.OnEnd(tailSpan(3, "c", time.Millisecond))
+	// Spans of evicted traces are dropped.
+	tsp.OnEnd(tailSpan(2, "bb", time.Millisecond))
+	tsp.Shutdown()
+
+	var names []string
+	for _, sd := range sr.Ended() {
+		names = append(names, sd.Name)
+	}
+	assert.ElementsMatch(t, []string{"a", "aa", "c"}, names)
+	assert.Equal(t, map[string]int64{
+		"evicted/":               1,
+		"sampled/Probability{1}": 2,
+	}, tailDecisions(impl))
+}
+
+func TestTailSamplingMaxSpansPerTrace(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithMaxSpansPerTrace(2),
+		sdktrace.WithTailSamplingPolicies(sdktrace.ProbabilityPolicy(1)),
+		sdktrace.WithTailSamplingMeter(meter),
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "bb", "ccc"} {
+		tsp.OnEnd(tailSpan(1, name, time.Millisecond))
+	}
+	tsp.Shutdown()
+
+	assert.Len(t, sr.Ended(), 2)
+	assert.Equal(t, int64(1), tailSpansDropped(impl))
+}
+
+func TestTailSamplingOnEndAfterShutdown(t *testing.T) {
+	impl, meter := mockmeter.NewMeter()
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithTailSamplingPolicies(sdktrace.ProbabilityPolicy(1)),
+		sdktrace.WithTailSamplingMeter(meter),
+	)
+	require.NoError(t, err)
+
+	tsp.OnEnd(tailSpan(1, "a", time.Millisecond))
+	tsp.Shutdown()
+	// Neither the spans of sampled traces nor those of new traces are
+	// kept once the processor is shut down.
+	tsp.OnEnd(tailSpan(1, "aa", time.Millisecond))
+	tsp.OnEnd(tailSpan(2, "b", time.Millisecond))
+	tsp.Shutdown()
+
+	var names []string
+	for _, sd := range sr.Ended() {
+		names = append(names, sd.Name)
+	}
+	assert.Equal(t, []string{"a"}, names)
+	assert.Equal(t, int64(2), tailSpansDropped(impl))
+}
+
+// tailSpansDropped returns the number of spans dropped by the
+// processor.
+func tailSpansDropped(impl *mockmeter.MeterImpl) int64 {
+	var dropped int64
+	for _, batch := range impl.MeasurementBatches {
+		for _, m := range batch.Measurements {
+			if m.Instrument.Descriptor().Name() == sdktrace.TailSamplingSpansDropped {
+				dropped += m.Number.AsInt64()
+			}
+		}
+	}
+	return dropped
+}
+
+func TestTailSamplingWithProvider(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tsp, err := sdktrace.NewTailSamplingSpanProcessor(sr,
+		sdktrace.WithTailSamplingPolicies(sdktrace.ErrorStatusPolicy()),
+	)
+	require.NoError(t, err)
+	tp, err := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+	)
+	require.NoError(t, err)
+	tp.RegisterSpanProcessor(tsp)
+	tr := tp.Tracer("tail")
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, child := tr.Start(ctx, "child")
+	child.SetStatus(codes.Internal, "failed")
+	child.End()
+	root.End()
+	_, other := tr.Start(context.Background(), "other")
+	other.End()
+	tp.UnregisterSpanProcessor(tsp)
+
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	tracetest.NewExpecter(t).ExpectSpan(ended.ByName("child")[0]).
+		ToHaveStatus(codes.Internal, "failed").
+		ToHaveParent(ended.ByName("root")[0])
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"strings"
 	"sync/atomic"
@@ -31,6 +32,7 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/Ch1f/otel/api/kv"
+	"github.com/Ch1f/otel/api/standard"
 	"github.com/Ch1f/otel/api/testharness"
 	"github.com/Ch1f/otel/api/trace"
 	apitrace "github.com/Ch1f/otel/api/trace"
@@ -1147,3 +1149,185 @@ func TestWithInstrumentationVersion(t *testing.T) {
 		t.Errorf("WithResource:\n  -got +want %s", diff)
 	}
 }
+
+func TestWithSpanRecordsError(t *testing.T) {
+	var te testExporter
+	tp, _ := NewProvider(WithSyncer(&te))
+
+	testErr := ottest.NewTestError("test error")
+	err := tp.Tracer("WithSpan").WithSpan(context.Background(), "span0", func(context.Context) error {
+		return testErr
+	}, apitrace.WithRecord())
+	if err != testErr {
+		t.Fatalf("WithSpan returned %v, want %v", err, testErr)
+	}
+
+	if len(te.spans) != 1 {
+		t.Fatalf("got %d exported spans, want 1", len(te.spans))
+	}
+	got := te.spans[0]
+	if got.StatusCode != codes.Unknown {
+		t.Errorf("got status %v, want %v", got.StatusCode, codes.Unknown)
+	}
+	if len(got.MessageEvents) != 1 || got.MessageEvents[0].Name != errorEventName {
+		t.Fatalf("got events %+v, want a single %q event", got.MessageEvents, errorEventName)
+	}
+	want := []kv.KeyValue{
+		errorTypeKey.String("github.com/Ch1f/otel/internal/testing.TestError"),
+		errorMessageKey.String("test error"),
+	}
+	if diff := cmpDiff(got.MessageEvents[0].Attributes, want); diff != "" {
+		t.Errorf("WithSpan error event: -got +want %s", diff)
+	}
+}
+
+func TestWithSpanRecordsPanic(t *testing.T) {
+	var te testExporter
+	tp, _ := NewProvider(WithSyncer(&te))
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the original panic value", r)
+			}
+		}()
+		_ = tp.Tracer("WithSpan").WithSpan(context.Background(), "span0", func(context.Context) error {
+			panic("boom")
+		}, apitrace.WithRecord())
+	}()
+
+	if len(te.spans) != 1 {
+		t.Fatalf("got %d exported spans, want 1", len(te.spans))
+	}
+	got := te.spans[0]
+	if got.StatusCode != codes.Internal || got.StatusMessage != "panic: boom" {
+		t.Errorf("got status %v %q, want %v %q", got.StatusCode, got.StatusMessage, codes.Internal, "panic: boom")
+	}
+	if len(got.MessageEvents) != 1 || got.MessageEvents[0].Name != "panic" {
+		t.Fatalf("got events %+v, want a single panic event", got.MessageEvents)
+	}
+	attrs := got.MessageEvents[0].Attributes
+	if len(attrs) != 2 {
+		t.Fatalf("got panic event attributes %+v, want 2", attrs)
+	}
+	if attrs[0].Key != "exception.message" || attrs[0].Value.AsString() != "boom" {
+		t.Errorf("got %+v, want exception.message=boom", attrs[0])
+	}
+	if attrs[1].Key != "exception.stacktrace" || !strings.Contains(attrs[1].Value.AsString(), "TestWithSpanRecordsPanic") {
+		t.Errorf("got %+v, want an exception.stacktrace including the panic site", attrs[1])
+	}
+}
+
+// stackTraceError records the stack trace where it was created.
+type stackTraceError struct {
+	msg   string
+	stack string
+}
+
+func (e *stackTraceError) Error() string      { return e.msg }
+func (e *stackTraceError) StackTrace() string { return "\n" + e.stack }
+
+// recordedError records err on a new span with opts and returns the
+// attributes of the error event.
+func recordedError(t *testing.T, err error, opts ...apitrace.ErrorOption) map[kv.Key]string {
+	te := &testExporter{}
+	tp, _ := NewProvider(WithSyncer(te))
+	span := startSpan(tp, "RecordError")
+	span.RecordError(context.Background(), err, opts...)
+
+	got, e := endSpan(te, span)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if len(got.MessageEvents) != 1 || got.MessageEvents[0].Name != errorEventName {
+		t.Fatalf("got events %+v, want a single %q event", got.MessageEvents, errorEventName)
+	}
+	attrs := map[kv.Key]string{}
+	for _, a := range got.MessageEvents[0].Attributes {
+		attrs[a.Key] = a.Value.AsString()
+	}
+	return attrs
+}
+
+func TestRecordErrorWithCauses(t *testing.T) {
+	inner := ottest.NewTestError("inner")
+	err := fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", inner))
+
+	attrs := recordedError(t, err, apitrace.WithErrorCauses())
+	want := "*fmt.wrapError: middle: inner\n" +
+		"github.com/Ch1f/otel/internal/testing.TestError: inner"
+	if got := attrs[standard.ErrorCausesKey]; got != want {
+		t.Errorf("got causes %q, want %q", got, want)
+	}
+	if got := attrs[errorMessageKey]; got != "outer: middle: inner" {
+		t.Errorf("got message %q", got)
+	}
+
+	if attrs := recordedError(t, inner, apitrace.WithErrorCauses()); len(attrs) != 2 {
+		t.Errorf("got attributes %v, want no causes for an unwrapped error", attrs)
+	}
+	if attrs := recordedError(t, err); len(attrs) != 2 {
+		t.Errorf("got attributes %v, want no causes without the option", attrs)
+	}
+}
+
+func TestRecordErrorWithStackTrace(t *testing.T) {
+	attrs := recordedError(t, errors.New("test"), apitrace.WithErrorStackTrace())
+	stack := attrs[standard.ExceptionStacktraceKey]
+	if !strings.HasPrefix(stack, "github.com/Ch1f/otel/sdk/trace.recordedError\n\t") {
+		t.Errorf("got stack trace %q, want it to start at the caller of RecordError", stack)
+	}
+	if !strings.Contains(stack, "TestRecordErrorWithStackTrace") {
+		t.Errorf("got stack trace %q, want it to include the test", stack)
+	}
+
+	// The stack trace of the innermost error is preferred.
+	err := fmt.Errorf("wrapped: %w", &wrappingStackTraceError{
+		stackTraceError: stackTraceError{msg: "middle", stack: "ignored"},
+		cause:           &stackTraceError{msg: "inner", stack: "main.main\n\tmain.go:1\n"},
+	})
+	attrs = recordedError(t, err, apitrace.WithErrorStackTrace())
+	if got := attrs[standard.ExceptionStacktraceKey]; got != "main.main\n\tmain.go:1\n" {
+		t.Errorf("got stack trace %q, want the stack trace of the innermost error", got)
+	}
+
+	// The output of fmt.Formatter errors is not taken for a stack trace.
+	err = fmt.Errorf("wrapped: %w", &formattingError{msg: "inner", details: "details"})
+	attrs = recordedError(t, err, apitrace.WithErrorStackTrace())
+	if got := attrs[standard.ExceptionStacktraceKey]; !strings.HasPrefix(got, "github.com/Ch1f/otel/sdk/trace.recordedError\n\t") {
+		t.Errorf("got stack trace %q, want the stack trace of the caller of RecordError", got)
+	}
+
+	// A typed nil error ends the chain.
+	var cause *stackTraceError
+	err = &wrappingStackTraceError{
+		stackTraceError: stackTraceError{msg: "outer", stack: "main.main\n\tmain.go:1\n"},
+		cause:           cause,
+	}
+	attrs = recordedError(t, err, apitrace.WithErrorStackTrace(), apitrace.WithErrorCauses())
+	if got := attrs[standard.ExceptionStacktraceKey]; got != "main.main\n\tmain.go:1\n" {
+		t.Errorf("got stack trace %q, want the stack trace of the outer error", got)
+	}
+}
+
+// formattingError formats its details after its message with %+v.
+type formattingError struct {
+	msg     string
+	details string
+}
+
+func (e *formattingError) Error() string { return e.msg }
+
+func (e *formattingError) Format(s fmt.State, verb rune) {
+	io.WriteString(s, e.msg)
+	if s.Flag('+') {
+		io.WriteString(s, "\n"+e.details)
+	}
+}
+
+type wrappingStackTraceError struct {
+	stackTraceError
+	cause error
+}
+
+func (e *wrappingStackTraceError) Unwrap() error { return e.cause }
@@ -18,8 +18,11 @@ import (
 	"context"
 
 	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/internal/trace/panics"
 	"github.com/Ch1f/otel/internal/trace/parent"
 	"github.com/Ch1f/otel/sdk/instrumentation"
+
+	"google.golang.org/grpc/codes"
 )
 
 type tracer struct {
@@ -67,12 +70,22 @@ func (tr *tracer) Start(ctx context.Context, name string, o ...apitrace.StartOpt
 	return apitrace.ContextWithSpan(ctx, span), span
 }
 
+// WithSpan wraps the execution of body with a span. An error returned
+// by body is recorded on the span with an error status. A panic in body
+// is recorded as a panic event with its stack trace before the span is
+// ended and the panic propagates to the caller.
 func (tr *tracer) WithSpan(ctx context.Context, name string, body func(ctx context.Context) error, opts ...apitrace.StartOption) error {
 	ctx, span := tr.Start(ctx, name, opts...)
 	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			panics.Record(ctx, span, r)
+			panic(r)
+		}
+	}()
 
 	if err := body(ctx); err != nil {
-		// TODO: set event with boolean attribute for error.
+		span.RecordError(ctx, err, apitrace.WithErrorStatus(codes.Unknown))
 		return err
 	}
 	return nil
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+var (
+	stackTracePruneRE = regexp.MustCompile(`runtime\/debug|testing|sdk\/trace\/tracetest`)
+)
+
+// Expecter creates expectations on recorded spans.
+type Expecter struct {
+	t testing.TB
+}
+
+// NewExpecter returns an Expecter failing t when an expectation is not
+// met.
+func NewExpecter(t testing.TB) *Expecter {
+	return &Expecter{
+		t: t,
+	}
+}
+
+// ExpectSpan returns the expectations of a span. The methods of the
+// expectation return it, so they can be chained.
+func (a *Expecter) ExpectSpan(span *export.SpanData) *SpanExpectation {
+	if span == nil {
+		fail(a.t, "Expected a span, got nil")
+	}
+	return &SpanExpectation{
+		t:    a.t,
+		span: span,
+	}
+}
+
+// SpanExpectation asserts the data of a span.
+type SpanExpectation struct {
+	t    testing.TB
+	span *export.SpanData
+}
+
+// ToHaveName expects the span to have the given name.
+func (e *SpanExpectation) ToHaveName(name string) *SpanExpectation {
+	if e.span.Name != name {
+		e.fail(fmt.Sprintf("Expected span name\n\t%q\nto equal\n\t%q", e.span.Name, name))
+	}
+	return e
+}
+
+// ToHaveKind expects the span to be of the given kind.
+func (e *SpanExpectation) ToHaveKind(kind apitrace.SpanKind) *SpanExpectation {
+	if e.span.SpanKind != kind {
+		e.fail(fmt.Sprintf("Expected span kind\n\t%v\nto equal\n\t%v", e.span.SpanKind, kind))
+	}
+	return e
+}
+
+// ToHaveAttributes expects the span to have each of the given
+// attributes, and possibly others.
+func (e *SpanExpectation) ToHaveAttributes(attrs ...kv.KeyValue) *SpanExpectation {
+	if missing := missingAttributes(e.span.Attributes, attrs); len(missing) > 0 {
+		e.fail(fmt.Sprintf("Expected span attributes\n\t%v\nto contain\n\t%v", e.span.Attributes, missing))
+	}
+	return e
+}
+
+// NotToHaveAttribute expects the span not to have an attribute with the
+// given key.
+func (e *SpanExpectation) NotToHaveAttribute(key kv.Key) *SpanExpectation {
+	for _, attr := range e.span.Attributes {
+		if attr.Key == key {
+			e.fail(fmt.Sprintf("Expected span attributes\n\t%v\nnot to contain key\n\t%v", e.span.Attributes, key))
+			break
+		}
+	}
+	return e
+}
+
+// ToHaveEvent expects the span to have an event with the given name and
+// each of the given attributes.
+func (e *SpanExpectation) ToHaveEvent(name string, attrs ...kv.KeyValue) *SpanExpectation {
+	for _, event := range e.span.MessageEvents {
+		if event.Name == name && len(missingAttributes(event.Attributes, attrs)) == 0 {
+			return e
+		}
+	}
+	e.fail(fmt.Sprintf("Expected span events\n\t%v\nto contain an event named\n\t%q\nwith attributes\n\t%v", e.span.MessageEvents, name, attrs))
+	return e
+}
+
+// NotToHaveEvent expects the span not to have an event with the given
+// name.
+func (e *SpanExpectation) NotToHaveEvent(name string) *SpanExpectation {
+	for _, event := range e.span.MessageEvents {
+		if event.Name == name {
+			e.fail(fmt.Sprintf("Expected span events\n\t%v\nnot to contain an event named\n\t%q", e.span.MessageEvents, name))
+			break
+		}
+	}
+	return e
+}
+
+// ToHaveStatus expects the span to have the given status code and
+// message.
+func (e *SpanExpectation) ToHaveStatus(code codes.Code, msg string) *SpanExpectation {
+	if e.span.StatusCode != code || e.span.StatusMessage != msg {
+		e.fail(fmt.Sprintf("Expected span status\n\t%v %q\nto equal\n\t%v %q", e.span.StatusCode, e.span.StatusMessage, code, msg))
+	}
+	return e
+}
+
+// ToHaveLink expects the span to have a link to the given span context
+// with each of the given attributes.
+func (e *SpanExpectation) ToHaveLink(sc apitrace.SpanContext, attrs ...kv.KeyValue) *SpanExpectation {
+	for _, link := range e.span.Links {
+		if link.SpanContext == sc && len(missingAttributes(link.Attributes, attrs)) == 0 {
+			return e
+		}
+	}
+	e.fail(fmt.Sprintf("Expected span links\n\t%v\nto contain a link to\n\t%v\nwith attributes\n\t%v", e.span.Links, sc, attrs))
+	return e
+}
+
+// ToHaveParent expects the span to be a child of the given span.
+func (e *SpanExpectation) ToHaveParent(parent *export.SpanData) *SpanExpectation {
+	if e.span.SpanContext.TraceID != parent.SpanContext.TraceID || e.span.ParentSpanID != parent.SpanContext.SpanID {
+		e.fail(fmt.Sprintf("Expected span\n\t%v\nto be a child of\n\t%v", e.span.SpanContext, parent.SpanContext))
+	}
+	return e
+}
+
+// ToBeRoot expects the span to have no parent.
+func (e *SpanExpectation) ToBeRoot() *SpanExpectation {
+	if e.span.ParentSpanID.IsValid() {
+		e.fail(fmt.Sprintf("Expected span\n\t%v\nto have no parent, got\n\t%v", e.span.SpanContext, e.span.ParentSpanID))
+	}
+	return e
+}
+
+func (e *SpanExpectation) fail(msg string) {
+	fail(e.t, msg)
+}
+
+// missingAttributes returns the attributes of want that are not in got.
+func missingAttributes(got, want []kv.KeyValue) []kv.KeyValue {
+	var missing []kv.KeyValue
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+	return missing
+}
+
+func fail(t testing.TB, msg string) {
+	// Prune the stack trace so that it's easier to see relevant lines
+	stack := strings.Split(string(debug.Stack()), "\n")
+	var prunedStack []string
+
+	for _, line := range stack {
+		if !stackTracePruneRE.MatchString(line) {
+			prunedStack = append(prunedStack, line)
+		}
+	}
+
+	t.Fatalf("\n%s\n%s\n", strings.Join(prunedStack, "\n"), msg)
+}
@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	"github.com/Ch1f/otel/sdk/trace/tracetest"
+)
+
+// fakeTB records failures instead of stopping the test.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestSpanExpectationPasses(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tr := newTracer(sr)
+
+	linked := apitrace.SpanContext{
+		TraceID: apitrace.ID{1},
+		SpanID:  apitrace.SpanID{1},
+	}
+	ctx, parent := tr.Start(context.Background(), "parent",
+		apitrace.WithSpanKind(apitrace.SpanKindServer),
+		apitrace.WithAttributes(kv.String("a", "1"), kv.Int("b", 2)),
+		apitrace.LinkedTo(linked, kv.String("link", "attr")),
+	)
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	parent.AddEvent(ctx, "event", kv.Bool("c", true), kv.String("d", "4"))
+	parent.SetStatus(codes.NotFound, "missing")
+	parent.End()
+
+	spans := sr.Ended()
+	parentData := spans.ByName("parent")[0]
+
+	e := tracetest.NewExpecter(t)
+	e.ExpectSpan(parentData).
+		ToHaveName("parent").
+		ToHaveKind(apitrace.SpanKindServer).
+		ToHaveAttributes(kv.Int("b", 2)).
+		NotToHaveAttribute("c").
+		ToHaveEvent("event", kv.Bool("c", true)).
+		NotToHaveEvent("other").
+		ToHaveStatus(codes.NotFound, "missing").
+		ToHaveLink(linked, kv.String("link", "attr")).
+		ToBeRoot()
+	e.ExpectSpan(spans.ByName("child")[0]).
+		ToHaveParent(parentData).
+		ToHaveStatus(codes.OK, "")
+}
+
+func TestSpanExpectationFailures(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tr := newTracer(sr)
+
+	ctx, parent := tr.Start(context.Background(), "parent",
+		apitrace.WithAttributes(kv.String("a", "1")),
+	)
+	_, child := tr.Start(ctx, "child")
+	child.End()
+	parent.AddEvent(ctx, "event", kv.Bool("c", true))
+	parent.End()
+
+	spans := sr.Ended()
+	parentData := spans.ByName("parent")[0]
+	childData := spans.ByName("child")[0]
+
+	for _, test := range []struct {
+		name   string
+		expect func(*tracetest.SpanExpectation)
+		msg    string
+	}{
+		{
+			name:   "name",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveName("other") },
+			msg:    "to equal\n\t\"other\"",
+		},
+		{
+			name:   "kind",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveKind(apitrace.SpanKindClient) },
+			msg:    "Expected span kind",
+		},
+		{
+			name:   "attribute value",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveAttributes(kv.String("a", "2")) },
+			msg:    "to contain",
+		},
+		{
+			name:   "unexpected attribute",
+			expect: func(e *tracetest.SpanExpectation) { e.NotToHaveAttribute("a") },
+			msg:    "not to contain key",
+		},
+		{
+			name:   "event attributes",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveEvent("event", kv.Bool("c", false)) },
+			msg:    "to contain an event named",
+		},
+		{
+			name:   "unexpected event",
+			expect: func(e *tracetest.SpanExpectation) { e.NotToHaveEvent("event") },
+			msg:    "not to contain an event named",
+		},
+		{
+			name:   "status",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveStatus(codes.Internal, "") },
+			msg:    "Expected span status",
+		},
+		{
+			name:   "link",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveLink(childData.SpanContext) },
+			msg:    "to contain a link to",
+		},
+		{
+			name:   "parent",
+			expect: func(e *tracetest.SpanExpectation) { e.ToHaveParent(childData) },
+			msg:    "to be a child of",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tb := &fakeTB{TB: t}
+			test.expect(tracetest.NewExpecter(tb).ExpectSpan(parentData))
+			if assert.Len(t, tb.failures, 1) {
+				assert.Contains(t, tb.failures[0], test.msg)
+			}
+		})
+	}
+
+	tb := &fakeTB{TB: t}
+	tracetest.NewExpecter(tb).ExpectSpan(childData).ToBeRoot()
+	if assert.Len(t, tb.failures, 1) {
+		assert.Contains(t, tb.failures[0], "to have no parent")
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracetest provides an in-memory recorder of the spans of the
+// SDK and helpers to query and assert them in tests.
+//
+// Unlike the api/trace/testtrace package, the recorder is plugged into a
+// real sdk/trace Provider, so the recorded spans are subject to its
+// samplers, limits and other span processors:
+//
+//	sr := tracetest.NewSpanRecorder()
+//	tp, _ := sdktrace.NewProvider()
+//	tp.RegisterSpanProcessor(sr)
+//	// ...
+//	e := tracetest.NewExpecter(t)
+//	e.ExpectSpan(sr.Ended().ByName("parent")[0]).
+//		ToHaveAttributes(kv.String("key", "value")).
+//		ToHaveStatus(codes.OK, "")
+package tracetest // import "github.com/Ch1f/otel/sdk/trace/tracetest"
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"context"
+	"sync"
+
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+)
+
+// SpanRecorder records spans in memory. It is a span processor that
+// records every span started and ended with recording on, and a
+// synchronous and batching exporter that records every exported span as
+// ended.
+type SpanRecorder struct {
+	lock    sync.Mutex
+	started Spans
+	ended   Spans
+}
+
+var (
+	_ sdktrace.SpanProcessor = (*SpanRecorder)(nil)
+	_ export.SpanSyncer      = (*SpanRecorder)(nil)
+	_ export.SpanBatcher     = (*SpanRecorder)(nil)
+)
+
+// NewSpanRecorder returns a new empty SpanRecorder.
+func NewSpanRecorder() *SpanRecorder {
+	return &SpanRecorder{}
+}
+
+// OnStart records a copy of the span data as it is when the span is
+// started.
+func (sr *SpanRecorder) OnStart(sd *export.SpanData) {
+	started := *sd
+
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	sr.started = append(sr.started, &started)
+}
+
+// OnEnd records the data of an ended span.
+func (sr *SpanRecorder) OnEnd(sd *export.SpanData) {
+	sr.record(sd)
+}
+
+// Shutdown does nothing, the recorded spans remain available.
+func (sr *SpanRecorder) Shutdown() {
+}
+
+// ExportSpan records the data of an exported span as ended.
+func (sr *SpanRecorder) ExportSpan(_ context.Context, sd *export.SpanData) {
+	sr.record(sd)
+}
+
+// ExportSpans records the data of a batch of exported spans as ended.
+func (sr *SpanRecorder) ExportSpans(_ context.Context, sds []*export.SpanData) {
+	sr.record(sds...)
+}
+
+func (sr *SpanRecorder) record(sds ...*export.SpanData) {
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	sr.ended = append(sr.ended, sds...)
+}
+
+// Started returns the data of the started spans, in the order they were
+// started, as it was at their start.
+func (sr *SpanRecorder) Started() Spans {
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	return append(Spans(nil), sr.started...)
+}
+
+// Ended returns the data of the ended spans, in the order they ended.
+func (sr *SpanRecorder) Ended() Spans {
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	return append(Spans(nil), sr.ended...)
+}
+
+// Reset forgets the recorded spans.
+func (sr *SpanRecorder) Reset() {
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	sr.started = nil
+	sr.ended = nil
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Ch1f/otel/api/kv"
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+	sdktrace "github.com/Ch1f/otel/sdk/trace"
+	"github.com/Ch1f/otel/sdk/trace/tracetest"
+)
+
+func newTracer(sr *tracetest.SpanRecorder) apitrace.Tracer {
+	tp, _ := sdktrace.NewProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+	)
+	tp.RegisterSpanProcessor(sr)
+	return tp.Tracer("tracetest")
+}
+
+func TestSpanRecorderStartedAndEnded(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tr := newTracer(sr)
+
+	ctx, parent := tr.Start(context.Background(), "parent")
+	_, child := tr.Start(ctx, "child")
+
+	assert.Len(t, sr.Started(), 2)
+	assert.Len(t, sr.Ended(), 0)
+
+	child.SetAttributes(kv.String("late", "value"))
+	child.End()
+	parent.End()
+
+	started := sr.Started()
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, "child", ended[0].Name)
+	assert.Equal(t, "parent", ended[1].Name)
+
+	// Started spans are not updated after the span starts.
+	assert.Empty(t, started.ByName("child")[0].Attributes)
+	assert.Equal(t, []kv.KeyValue{kv.String("late", "value")}, ended[0].Attributes)
+
+	sr.Reset()
+	assert.Empty(t, sr.Started())
+	assert.Empty(t, sr.Ended())
+}
+
+func TestSpanRecorderAsExporter(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	sd := &export.SpanData{Name: "exported"}
+
+	sr.ExportSpan(context.Background(), sd)
+	sr.ExportSpans(context.Background(), []*export.SpanData{{Name: "batched"}})
+
+	ended := sr.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, sd, ended[0])
+	assert.Equal(t, "batched", ended[1].Name)
+	assert.Empty(t, sr.Started())
+}
+
+func TestSpansQueries(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tr := newTracer(sr)
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, a := tr.Start(ctx, "a")
+	bctx, b := tr.Start(ctx, "b")
+	_, c := tr.Start(bctx, "c")
+	_, other := tr.Start(context.Background(), "root")
+	for _, s := range []apitrace.Span{c, b, a, root, other} {
+		s.End()
+	}
+
+	spans := sr.Ended()
+	roots := spans.ByName("root")
+	require.Len(t, roots, 2)
+	rootData := spans.BySpanID(root.SpanContext().SpanID)
+	require.NotNil(t, rootData)
+	assert.Nil(t, spans.BySpanID(apitrace.SpanID{}))
+
+	assert.Len(t, spans.ByTraceID(root.SpanContext().TraceID), 4)
+	assert.Len(t, spans.ByTraceID(other.SpanContext().TraceID), 1)
+	assert.Len(t, spans.ByParentSpanID(root.SpanContext().SpanID), 2)
+
+	children := spans.ChildrenOf(rootData)
+	require.Len(t, children, 2)
+	assert.ElementsMatch(t, []string{"a", "b"}, []string{children[0].Name, children[1].Name})
+}
+
+func TestSpansTrees(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tr := newTracer(sr)
+
+	ctx, root := tr.Start(context.Background(), "root")
+	_, a := tr.Start(ctx, "a")
+	bctx, b := tr.Start(ctx, "b")
+	_, c := tr.Start(bctx, "c")
+	for _, s := range []apitrace.Span{c, b, a, root} {
+		s.End()
+	}
+
+	trees := sr.Ended().Trees()
+	require.Len(t, trees, 1)
+
+	var visited []string
+	var depths []int
+	trees[0].Walk(func(sd *export.SpanData, depth int) {
+		visited = append(visited, sd.Name)
+		depths = append(depths, depth)
+	})
+	assert.Equal(t, []string{"root", "a", "b", "c"}, visited)
+	assert.Equal(t, []int{0, 1, 1, 2}, depths)
+
+	// Spans whose parent was not recorded become roots.
+	partial := sr.Ended().ByName("c")
+	trees = partial.Trees()
+	require.Len(t, trees, 1)
+	assert.Equal(t, "c", trees[0].Span.Name)
+	assert.Empty(t, trees[0].Children)
+}
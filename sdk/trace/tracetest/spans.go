@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"sort"
+
+	apitrace "github.com/Ch1f/otel/api/trace"
+	export "github.com/Ch1f/otel/sdk/export/trace"
+)
+
+// Spans is a list of recorded spans.
+type Spans []*export.SpanData
+
+// filter returns the spans for which keep returns true.
+func (s Spans) filter(keep func(*export.SpanData) bool) Spans {
+	var out Spans
+	for _, sd := range s {
+		if keep(sd) {
+			out = append(out, sd)
+		}
+	}
+	return out
+}
+
+// ByName returns the spans with the given name.
+func (s Spans) ByName(name string) Spans {
+	return s.filter(func(sd *export.SpanData) bool {
+		return sd.Name == name
+	})
+}
+
+// ByTraceID returns the spans of the given trace.
+func (s Spans) ByTraceID(id apitrace.ID) Spans {
+	return s.filter(func(sd *export.SpanData) bool {
+		return sd.SpanContext.TraceID == id
+	})
+}
+
+// ByParentSpanID returns the spans whose parent has the given ID.
+func (s Spans) ByParentSpanID(id apitrace.SpanID) Spans {
+	return s.filter(func(sd *export.SpanData) bool {
+		return sd.ParentSpanID == id
+	})
+}
+
+// ChildrenOf returns the spans whose parent is the given span.
+func (s Spans) ChildrenOf(parent *export.SpanData) Spans {
+	return s.filter(func(sd *export.SpanData) bool {
+		return sd.SpanContext.TraceID == parent.SpanContext.TraceID &&
+			sd.ParentSpanID == parent.SpanContext.SpanID
+	})
+}
+
+// BySpanID returns the span with the given ID, or nil if there is none.
+func (s Spans) BySpanID(id apitrace.SpanID) *export.SpanData {
+	for _, sd := range s {
+		if sd.SpanContext.SpanID == id {
+			return sd
+		}
+	}
+	return nil
+}
+
+// SpanTree is a span and the trees of its child spans.
+type SpanTree struct {
+	Span     *export.SpanData
+	Children []*SpanTree
+}
+
+// Trees reconstructs the trees of the spans. The roots are the spans
+// whose parent is not in the list, such as the local root spans and the
+// spans with a remote parent. Roots and children are ordered by start
+// time.
+func (s Spans) Trees() []*SpanTree {
+	type spanKey struct {
+		traceID apitrace.ID
+		spanID  apitrace.SpanID
+	}
+	nodes := make(map[spanKey]*SpanTree, len(s))
+	for _, sd := range s {
+		nodes[spanKey{sd.SpanContext.TraceID, sd.SpanContext.SpanID}] = &SpanTree{Span: sd}
+	}
+
+	var roots []*SpanTree
+	for _, sd := range s {
+		node := nodes[spanKey{sd.SpanContext.TraceID, sd.SpanContext.SpanID}]
+		if parent, ok := nodes[spanKey{sd.SpanContext.TraceID, sd.ParentSpanID}]; ok && sd.ParentSpanID.IsValid() {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortTrees(roots)
+	return roots
+}
+
+func sortTrees(trees []*SpanTree) {
+	sort.SliceStable(trees, func(i, j int) bool {
+		return trees[i].Span.StartTime.Before(trees[j].Span.StartTime)
+	})
+	for _, tree := range trees {
+		sortTrees(tree.Children)
+	}
+}
+
+// Walk calls fn for the span of the tree and the spans of its children,
+// depth first, with their depth in the tree starting at 0.
+func (t *SpanTree) Walk(fn func(sd *export.SpanData, depth int)) {
+	t.walk(fn, 0)
+}
+
+func (t *SpanTree) walk(fn func(*export.SpanData, int), depth int) {
+	fn(t.Span, depth)
+	for _, child := range t.Children {
+		child.walk(fn, depth+1)
+	}
+}